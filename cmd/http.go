@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/config"
+	"github.com/saleh-ghazimoradi/X/internal/handler"
+	"github.com/saleh-ghazimoradi/X/internal/health"
+	"github.com/saleh-ghazimoradi/X/internal/metrics"
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"github.com/saleh-ghazimoradi/X/internal/route"
+	"github.com/saleh-ghazimoradi/X/internal/server"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"github.com/saleh-ghazimoradi/X/internal/storage"
+	"github.com/saleh-ghazimoradi/X/internal/tracing"
+	"github.com/saleh-ghazimoradi/X/migrations"
+	"github.com/saleh-ghazimoradi/X/utils"
+	"log/slog"
+	"net/http"
+	"sync"
+)
+
+type database struct {
+	postgresql *utils.Postgresql
+	db         *sql.DB
+}
+
+// HTTP starts serving immediately with the readiness state set to not ready, and
+// only reports ready once Postgres is reachable and migrations are applied. If
+// Postgres never becomes reachable the server is stopped and an error returned.
+func HTTP(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	if cfg.JWT.Secret == "" {
+		return errors.New("JWT_SECRET must be set")
+	}
+
+	shutdownTracing, err := tracing.New(
+		tracing.WithServiceName(cfg.Tracing.ServiceName),
+		tracing.WithServiceVersion(health.Version),
+		tracing.WithExporter(cfg.Tracing.Exporter),
+		tracing.WithEndpoint(cfg.Tracing.Endpoint, cfg.Tracing.Insecure),
+		tracing.WithSampleRatio(cfg.Tracing.SampleRatio),
+	).Start(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			logger.Error("failed to flush traces", "err", err.Error())
+		}
+	}()
+
+	primary, err := openDatabase(newPostgresql(cfg, logger))
+	if err != nil {
+		return fmt.Errorf("failed to open postgresql: %w", err)
+	}
+	defer closeDatabase(primary, logger)
+
+	replica := primary
+	if cfg.Postgresql.ReplicaURL != "" {
+		replica, err = openDatabase(newPostgresql(cfg, logger, utils.WithURL(cfg.Postgresql.ReplicaURL)))
+		if err != nil {
+			return fmt.Errorf("failed to open postgresql replica: %w", err)
+		}
+		defer closeDatabase(replica, logger)
+	}
+
+	readiness := health.NewReadiness("waiting for postgresql")
+	registry := health.NewRegistry(cfg.Server.HealthTimeout)
+	registry.Register("postgresql_primary", health.PostgresqlChecker(primary.db), true)
+	if replica != primary {
+		registry.Register("postgresql_replica", health.PostgresqlChecker(replica.db), false)
+	}
+
+	appMetrics := metrics.New()
+	if err := appMetrics.RegisterDB("primary", primary.db); err != nil {
+		return fmt.Errorf("failed to register primary pool metrics: %w", err)
+	}
+	if replica != primary {
+		if err := appMetrics.RegisterDB("replica", replica.db); err != nil {
+			return fmt.Errorf("failed to register replica pool metrics: %w", err)
+		}
+	}
+
+	userRepository := metrics.NewUserRepository(repository.NewUserRepository(primary.db, replica.db), appMetrics)
+	postRepository := repository.NewPostRepository(primary.db, replica.db)
+	followRepository := repository.NewFollowRepository(primary.db, replica.db)
+	timelineRepository := repository.NewTimelineRepository(primary.db, replica.db)
+	likeRepository := repository.NewLikeRepository(primary.db, replica.db)
+	bookmarkRepository := repository.NewBookmarkRepository(primary.db, replica.db)
+	listRepository := repository.NewListRepository(primary.db, replica.db)
+	pollRepository := repository.NewPollRepository(primary.db, replica.db)
+	repostRepository := repository.NewRepostRepository(primary.db, replica.db)
+	notificationRepository := repository.NewNotificationRepository(primary.db, replica.db)
+	streamRepository := repository.NewStreamRepository(primary.db, replica.db)
+	messageRepository := repository.NewMessageRepository(primary.db, replica.db)
+	blockRepository := repository.NewBlockRepository(primary.db, replica.db)
+	mutedWordRepository := repository.NewMutedWordRepository(primary.db, replica.db)
+	mediaRepository := repository.NewMediaRepository(primary.db, replica.db)
+	blobStore := storage.NewLocalStore(cfg.Media.Dir, storage.WithLocalBaseURL(cfg.Media.BaseURL))
+
+	dsn, err := primary.postgresql.DSN()
+	if err != nil {
+		return fmt.Errorf("failed to build postgresql dsn: %w", err)
+	}
+
+	tokenService := service.NewTokenService(cfg.JWT.Secret, cfg.JWT.Issuer, cfg.JWT.AccessTokenTTL)
+	authService := metrics.NewAuthService(tracing.NewAuthService(service.NewAuthService(userRepository, tokenService,
+		service.WithAuthLogger(logger),
+		service.WithPasswordHashObserver(appMetrics.ObservePasswordHash),
+	)), appMetrics)
+	streamService := service.NewStreamService(streamRepository, postRepository, userRepository, repository.NewStreamListener(dsn, logger),
+		service.WithStreamHeartbeat(cfg.Stream.HeartbeatInterval),
+		service.WithStreamMaxBacklog(cfg.Stream.MaxBacklog),
+		service.WithStreamRetention(cfg.Stream.Retention),
+		service.WithStreamLogger(logger),
+	)
+	timelineService := service.NewTimelineService(timelineRepository,
+		service.WithFanOutThreshold(cfg.Timeline.FanOutThreshold),
+		service.WithBackfillSize(cfg.Timeline.BackfillSize),
+		service.WithFanOutInterval(cfg.Timeline.FanOutInterval),
+		service.WithTimelineStream(streamService),
+		service.WithTimelineLogger(logger),
+	)
+	notificationService := service.NewNotificationService(notificationRepository,
+		service.WithNotificationQueueSize(cfg.Notification.QueueSize),
+		service.WithNotificationWorkers(cfg.Notification.Workers),
+		service.WithNotificationStream(streamService),
+		service.WithNotificationLogger(logger),
+	)
+	blockService := service.NewBlockService(blockRepository, userRepository, followRepository,
+		service.WithBlockTimeline(timelineService),
+		service.WithBlockLogger(logger),
+	)
+	mutedWordService := service.NewMutedWordService(mutedWordRepository)
+	mediaService := service.NewMediaService(mediaRepository, blobStore,
+		service.WithMediaMaxBytes(cfg.Media.MaxBytes),
+		service.WithMediaOrphanTTL(cfg.Media.OrphanTTL),
+		service.WithMediaLogger(logger),
+	)
+	postService := service.NewPostService(postRepository, userRepository,
+		service.WithPostTimeline(timelineService),
+		service.WithPostNotifier(notificationService),
+		service.WithPostLogger(logger),
+	)
+	followService := service.NewFollowService(followRepository, userRepository,
+		service.WithFollowBlocks(blockService),
+		service.WithFollowTimeline(timelineService),
+		service.WithFollowNotifier(notificationService),
+		service.WithFollowLogger(logger),
+	)
+	userService := service.NewUserService(userRepository,
+		service.WithUserFollowRequests(followService),
+	)
+	likeService := service.NewLikeService(likeRepository, postRepository, userRepository,
+		service.WithLikeNotifier(notificationService),
+	)
+	bookmarkService := service.NewBookmarkService(bookmarkRepository, postRepository)
+	listService := service.NewListService(listRepository, userRepository)
+	pollService := service.NewPollService(pollRepository, postRepository,
+		service.WithPollStream(streamService),
+		service.WithPollFinalizeInterval(cfg.Poll.FinalizeInterval),
+		service.WithPollLogger(logger),
+	)
+	repostService := service.NewRepostService(repostRepository, postRepository, userRepository,
+		service.WithRepostTimeline(timelineService),
+		service.WithRepostNotifier(notificationService),
+		service.WithRepostLogger(logger),
+	)
+	messageService := service.NewMessageService(messageRepository, userRepository, followRepository,
+		service.WithMessageBlocks(blockService),
+		service.WithMessageStream(streamService),
+		service.WithMessageLogger(logger),
+	)
+
+	// The metrics are only served to scrapers presenting the configured token.
+	var authenticateMetrics func(http.Handler) http.Handler
+	if cfg.Metrics.Token != "" {
+		authenticateMetrics = middleware.RequireToken(cfg.Metrics.Token)
+	} else {
+		logger.Warn("METRICS_TOKEN is not set, /metrics is not served")
+	}
+
+	srv := server.New(
+		server.WithHost(cfg.Server.Host),
+		server.WithPort(cfg.Server.Port),
+		server.WithReadTimeout(cfg.Server.ReadTimeout),
+		server.WithWriteTimeout(cfg.Server.WriteTimeout),
+		server.WithIdleTimeout(cfg.Server.IdleTimeout),
+		server.WithShutdownTimeout(cfg.Server.ShutdownTimeout),
+		server.WithLogger(logger),
+		server.WithHandler(route.New(route.Handlers{
+			Health:       handler.NewHealthHandler(readiness, registry),
+			Auth:         handler.NewAuthHandler(authService, logger),
+			User:         handler.NewUserHandler(userService, logger),
+			Post:         handler.NewPostHandler(postService, logger),
+			Media:        handler.NewMediaHandler(mediaService, logger),
+			Follow:       handler.NewFollowHandler(followService, logger),
+			Block:        handler.NewBlockHandler(blockService, logger),
+			MutedWord:    handler.NewMutedWordHandler(mutedWordService, logger),
+			Timeline:     handler.NewTimelineHandler(timelineService, logger),
+			Like:         handler.NewLikeHandler(likeService, logger),
+			Bookmark:     handler.NewBookmarkHandler(bookmarkService, logger),
+			List:         handler.NewListHandler(listService, logger),
+			Poll:         handler.NewPollHandler(pollService, logger),
+			Repost:       handler.NewRepostHandler(repostService, logger),
+			Notification: handler.NewNotificationHandler(notificationService, logger),
+			Message:      handler.NewMessageHandler(messageService, logger),
+			Stream: handler.NewStreamHandler(streamService, logger,
+				handler.WithStreamWriteTimeout(cfg.Stream.WriteTimeout),
+				handler.WithStreamAllowedOrigins(cfg.Stream.AllowedOrigins),
+			),
+			Blobs:               blobStore.Handler(),
+			Metrics:             appMetrics,
+			Logger:              logger,
+			Authenticate:        middleware.Authenticate(tokenService),
+			AuthenticateStream:  middleware.AuthenticateQuery(tokenService),
+			AuthenticateMetrics: authenticateMetrics,
+		})),
+	)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// The background workers query the schema right away, so they only start
+	// once the migrations are applied. They stop after the server did.
+	var workers sync.WaitGroup
+	startWorker := func(run func(ctx context.Context)) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run(ctx)
+		}()
+	}
+
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		if err := startup(ctx, logger, primary, replica, readiness, registry); err != nil {
+			cancel(err)
+			return
+		}
+
+		// Notifications are written in the background; once the server stopped
+		// accepting requests the workers store what is still queued.
+		startWorker(notificationService.Run)
+
+		// Open streams end once the stream service stops, which lets the server
+		// shut down without waiting for them to time out.
+		startWorker(func(ctx context.Context) {
+			if err := streamService.Run(ctx); err != nil {
+				logger.Error("stream listener failed", "err", err.Error())
+			}
+		})
+
+		// Uploads never attached to a post and unused images are removed in the
+		// background.
+		startWorker(mediaService.Run)
+
+		// New posts and reposts reach the followers' timelines in the background.
+		startWorker(timelineService.Run)
+
+		// Closed polls are finalized in the background, which stores the
+		// notifications of their results.
+		startWorker(pollService.Run)
+
+		logger.Info("instance is ready")
+	}()
+
+	runErr := srv.Run(ctx)
+	readiness.SetNotReady("shutting down")
+
+	// The workers are all started once startup returned.
+	cancel(nil)
+	<-started
+	workers.Wait()
+
+	if runErr != nil {
+		return runErr
+	}
+	if err := context.Cause(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// startup waits for the databases and applies the migrations. The migrate
+// handle is closed right after, giving its connection back to the pool; the
+// schema version is then reported from the pool.
+func startup(ctx context.Context, logger *slog.Logger, primary, replica *database, readiness *health.Readiness, registry *health.Registry) error {
+	if err := primary.postgresql.WaitReady(ctx, primary.db); err != nil {
+		return err
+	}
+
+	if replica != primary {
+		readiness.SetNotReady("waiting for postgresql replica")
+		if err := replica.postgresql.WaitReady(ctx, replica.db); err != nil {
+			return err
+		}
+	}
+
+	readiness.SetNotReady("applying migrations")
+	migrate, err := migrations.NewMigrate(primary.db, primary.postgresql.DatabaseName(), logger)
+	if err != nil {
+		return fmt.Errorf("failed to load migrations: %w", err)
+	}
+	defer func() {
+		if err := migrate.Close(); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	if err := migrate.UP(); err != nil {
+		return err
+	}
+
+	registry.Register("migrations", health.MigrationChecker(func(ctx context.Context) (uint, bool, error) {
+		return migrations.SchemaVersion(ctx, primary.db)
+	}), true)
+	readiness.SetReady()
+	return nil
+}
+
+func openDatabase(postgresql *utils.Postgresql) (*database, error) {
+	db, err := postgresql.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &database{postgresql: postgresql, db: db}, nil
+}
+
+func closeDatabase(d *database, logger *slog.Logger) {
+	if err := d.db.Close(); err != nil {
+		logger.Error(err.Error())
+	}
+}
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/config"
+	"github.com/saleh-ghazimoradi/X/migrations"
+	"log/slog"
+)
+
+func MigrateUp(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	return withMigrate(ctx, cfg, logger, func(m *migrations.Migrate) error {
+		return m.UP()
+	})
+}
+
+func MigrateDown(ctx context.Context, cfg *config.Config, logger *slog.Logger) error {
+	return withMigrate(ctx, cfg, logger, func(m *migrations.Migrate) error {
+		return m.Rollback()
+	})
+}
+
+func withMigrate(ctx context.Context, cfg *config.Config, logger *slog.Logger, fn func(m *migrations.Migrate) error) error {
+	postgresql := newPostgresql(cfg, logger)
+	db, err := postgresql.Open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	if err := postgresql.WaitReady(ctx, db); err != nil {
+		return err
+	}
+
+	migrate, err := migrations.NewMigrate(db, postgresql.DatabaseName(), logger)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := migrate.Close(); err != nil {
+			logger.Error(err.Error())
+		}
+	}()
+
+	return fn(migrate)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/saleh-ghazimoradi/X/config"
+	"github.com/saleh-ghazimoradi/X/internal/tracing"
+	"github.com/saleh-ghazimoradi/X/utils"
+	"log/slog"
+)
+
+// newPostgresql builds the connection settings from the configuration; extra
+// options are applied last so callers can override them, e.g. for a replica.
+func newPostgresql(cfg *config.Config, logger *slog.Logger, extra ...utils.Options) *utils.Postgresql {
+	opts := []utils.Options{
+		utils.WithURL(cfg.Postgresql.URL),
+		utils.WithHost(cfg.Postgresql.Host),
+		utils.WithPort(cfg.Postgresql.Port),
+		utils.WithUser(cfg.Postgresql.User),
+		utils.WithPassword(cfg.Postgresql.Password),
+		utils.WithName(cfg.Postgresql.Name),
+		utils.WithTimeout(cfg.Postgresql.Timeout),
+		utils.WithSSLMode(cfg.Postgresql.SSLMode),
+		utils.WithSSLRootCert(cfg.Postgresql.SSLRootCert),
+		utils.WithSSLClientCert(cfg.Postgresql.SSLCert, cfg.Postgresql.SSLKey),
+		utils.WithApplicationName(cfg.Postgresql.ApplicationName),
+		utils.WithSearchPath(cfg.Postgresql.SearchPath),
+		utils.WithStatementTimeout(cfg.Postgresql.StatementTimeout),
+		utils.WithConnectTimeout(cfg.Postgresql.ConnectTimeout),
+		utils.WithMaxOpenConn(cfg.Postgresql.MaxOpenConn),
+		utils.WithMaxIdleTime(cfg.Postgresql.MaxIdleTime),
+		utils.WithMaxLifetime(cfg.Postgresql.MaxLifetime),
+		utils.WithMaxIdleConn(cfg.Postgresql.MaxIdleConn),
+		utils.WithRetry(cfg.Postgresql.Retry.MaxAttempts, cfg.Postgresql.Retry.Deadline, utils.Backoff{
+			Initial:    cfg.Postgresql.Retry.InitialBackoff,
+			Max:        cfg.Postgresql.Retry.MaxBackoff,
+			Multiplier: cfg.Postgresql.Retry.Multiplier,
+			Jitter:     cfg.Postgresql.Retry.Jitter,
+		}),
+		utils.WithLogger(logger),
+		utils.WithOpener(tracing.OpenDB),
+	}
+	return utils.NewPostgresql(append(opts, extra...)...)
+}
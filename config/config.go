@@ -12,7 +12,17 @@ var (
 )
 
 type Config struct {
-	Postgresql Postgresql
+	Logger       Logger
+	JWT          JWT
+	Server       Server
+	Postgresql   Postgresql
+	Tracing      Tracing
+	Timeline     Timeline
+	Notification Notification
+	Stream       Stream
+	Media        Media
+	Poll         Poll
+	Metrics      Metrics
 }
 
 func NewConfig() (*Config, error) {
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type JWT struct {
+	Secret         string        `env:"JWT_SECRET"`
+	Issuer         string        `env:"JWT_ISSUER" envDefault:"x"`
+	AccessTokenTTL time.Duration `env:"JWT_ACCESS_TOKEN_TTL" envDefault:"24h"`
+}
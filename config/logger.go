@@ -0,0 +1,7 @@
+package config
+
+type Logger struct {
+	Level      string `env:"LOG_LEVEL" envDefault:"info"`
+	Format     string `env:"LOG_FORMAT" envDefault:"json"`
+	HashEmails bool   `env:"LOG_HASH_EMAILS" envDefault:"true"`
+}
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+type Media struct {
+	Dir       string        `env:"MEDIA_DIR" envDefault:"./data/media"`
+	BaseURL   string        `env:"MEDIA_BASE_URL" envDefault:"/blobs"`
+	MaxBytes  int64         `env:"MEDIA_MAX_BYTES" envDefault:"5242880"`
+	OrphanTTL time.Duration `env:"MEDIA_ORPHAN_TTL" envDefault:"24h"`
+}
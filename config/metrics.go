@@ -0,0 +1,7 @@
+package config
+
+type Metrics struct {
+	// Token is the bearer token scrapers present to read /metrics, which is
+	// not served when it is empty.
+	Token string `env:"METRICS_TOKEN"`
+}
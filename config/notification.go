@@ -0,0 +1,6 @@
+package config
+
+type Notification struct {
+	QueueSize int `env:"NOTIFICATION_QUEUE_SIZE" envDefault:"1024"`
+	Workers   int `env:"NOTIFICATION_WORKERS" envDefault:"2"`
+}
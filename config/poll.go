@@ -0,0 +1,7 @@
+package config
+
+import "time"
+
+type Poll struct {
+	FinalizeInterval time.Duration `env:"POLL_FINALIZE_INTERVAL" envDefault:"1m"`
+}
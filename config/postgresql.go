@@ -3,14 +3,34 @@ package config
 import "time"
 
 type Postgresql struct {
-	Host        string        `env:"POSTGRES_HOST"`
-	Port        string        `env:"POSTGRES_PORT"`
-	User        string        `env:"POSTGRES_USER"`
-	Password    string        `env:"POSTGRES_PASSWORD"`
-	Name        string        `env:"POSTGRES_NAME"`
-	MaxOpenConn int           `env:"POSTGRES_MAX_OPEN_CONN"`
-	MaxIdleConn int           `env:"POSTGRES_MAX_IDLE_CONN"`
-	MaxIdleTime time.Duration `env:"POSTGRES_MAX_IDLE_TIME"`
-	SSLMode     string        `env:"POSTGRES_SSL_MODE"`
-	Timeout     time.Duration `env:"POSTGRES_TIMEOUT"`
+	URL              string        `env:"DATABASE_URL"`
+	ReplicaURL       string        `env:"DATABASE_REPLICA_URL"`
+	Host             string        `env:"POSTGRES_HOST"`
+	Port             string        `env:"POSTGRES_PORT"`
+	User             string        `env:"POSTGRES_USER"`
+	Password         string        `env:"POSTGRES_PASSWORD"`
+	Name             string        `env:"POSTGRES_NAME"`
+	MaxOpenConn      int           `env:"POSTGRES_MAX_OPEN_CONN"`
+	MaxIdleConn      int           `env:"POSTGRES_MAX_IDLE_CONN"`
+	MaxIdleTime      time.Duration `env:"POSTGRES_MAX_IDLE_TIME"`
+	MaxLifetime      time.Duration `env:"POSTGRES_MAX_LIFETIME"`
+	SSLMode          string        `env:"POSTGRES_SSL_MODE"`
+	SSLRootCert      string        `env:"POSTGRES_SSL_ROOT_CERT"`
+	SSLCert          string        `env:"POSTGRES_SSL_CERT"`
+	SSLKey           string        `env:"POSTGRES_SSL_KEY"`
+	ApplicationName  string        `env:"POSTGRES_APPLICATION_NAME"`
+	SearchPath       string        `env:"POSTGRES_SEARCH_PATH"`
+	StatementTimeout time.Duration `env:"POSTGRES_STATEMENT_TIMEOUT"`
+	ConnectTimeout   time.Duration `env:"POSTGRES_CONNECT_TIMEOUT"`
+	Timeout          time.Duration `env:"POSTGRES_TIMEOUT"`
+	Retry            PostgresqlRetry
+}
+
+type PostgresqlRetry struct {
+	MaxAttempts    int           `env:"POSTGRES_RETRY_MAX_ATTEMPTS" envDefault:"0"`
+	Deadline       time.Duration `env:"POSTGRES_RETRY_DEADLINE" envDefault:"1m"`
+	InitialBackoff time.Duration `env:"POSTGRES_RETRY_INITIAL_BACKOFF" envDefault:"250ms"`
+	MaxBackoff     time.Duration `env:"POSTGRES_RETRY_MAX_BACKOFF" envDefault:"10s"`
+	Multiplier     float64       `env:"POSTGRES_RETRY_MULTIPLIER" envDefault:"2"`
+	Jitter         float64       `env:"POSTGRES_RETRY_JITTER" envDefault:"0.2"`
 }
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+type Server struct {
+	Host            string        `env:"SERVER_HOST"`
+	Port            string        `env:"SERVER_PORT" envDefault:"8080"`
+	ReadTimeout     time.Duration `env:"SERVER_READ_TIMEOUT" envDefault:"10s"`
+	WriteTimeout    time.Duration `env:"SERVER_WRITE_TIMEOUT" envDefault:"10s"`
+	IdleTimeout     time.Duration `env:"SERVER_IDLE_TIMEOUT" envDefault:"1m"`
+	ShutdownTimeout time.Duration `env:"SERVER_SHUTDOWN_TIMEOUT" envDefault:"15s"`
+	HealthTimeout   time.Duration `env:"SERVER_HEALTH_TIMEOUT" envDefault:"2s"`
+}
@@ -0,0 +1,11 @@
+package config
+
+import "time"
+
+type Stream struct {
+	HeartbeatInterval time.Duration `env:"STREAM_HEARTBEAT_INTERVAL" envDefault:"25s"`
+	WriteTimeout      time.Duration `env:"STREAM_WRITE_TIMEOUT" envDefault:"10s"`
+	MaxBacklog        int           `env:"STREAM_MAX_BACKLOG" envDefault:"500"`
+	Retention         time.Duration `env:"STREAM_RETENTION" envDefault:"24h"`
+	AllowedOrigins    []string      `env:"STREAM_ALLOWED_ORIGINS" envSeparator:","`
+}
@@ -0,0 +1,9 @@
+package config
+
+import "time"
+
+type Timeline struct {
+	FanOutThreshold int           `env:"TIMELINE_FANOUT_THRESHOLD" envDefault:"5000"`
+	BackfillSize    int           `env:"TIMELINE_BACKFILL_SIZE" envDefault:"50"`
+	FanOutInterval  time.Duration `env:"TIMELINE_FANOUT_INTERVAL" envDefault:"1s"`
+}
@@ -0,0 +1,9 @@
+package config
+
+type Tracing struct {
+	ServiceName string  `env:"TRACING_SERVICE_NAME" envDefault:"x"`
+	Exporter    string  `env:"TRACING_EXPORTER" envDefault:"none"`
+	Endpoint    string  `env:"TRACING_OTLP_ENDPOINT"`
+	Insecure    bool    `env:"TRACING_OTLP_INSECURE"`
+	SampleRatio float64 `env:"TRACING_SAMPLE_RATIO" envDefault:"1"`
+}
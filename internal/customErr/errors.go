@@ -8,4 +8,10 @@ var (
 	ErrUserNameTaken = errors.New("user name already taken")
 	ErrEmailTaken    = errors.New("email already taken")
 	ErrBadCredential = errors.New("email/password wrong combination")
+	ErrUnauthorized  = errors.New("authentication required")
+	ErrForbidden     = errors.New("not allowed")
+	ErrSelfFollow    = errors.New("cannot follow yourself")
+	ErrUserDeleted   = errors.New("user deactivated")
+	ErrFolderTaken   = errors.New("folder name already taken")
+	ErrAlreadyVoted  = errors.New("already voted")
 )
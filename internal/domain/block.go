@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// Block is a block or a mute made by UserId on TargetId. User is the target
+// account when the blocks or mutes of a user are listed.
+type Block struct {
+	UserId    string
+	TargetId  string
+	CreatedAt time.Time
+	User      *User
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// MaxBookmarkFolders is the number of folders a user may file bookmarks in.
+const MaxBookmarkFolders = 100
+
+// Bookmark is a post a user saved for later, filed in one of their folders or
+// in none. Bookmarks are only ever shown to the user who saved them.
+type Bookmark struct {
+	UserId    string
+	PostId    string
+	FolderId  *string
+	CreatedAt time.Time
+	Post      *Post
+}
+
+type BookmarkFolder struct {
+	Id        string
+	UserId    string
+	Name      string
+	CreatedAt time.Time
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "time"
+
+// Cursor marks a position in a list ordered by time and then id, both descending.
+type Cursor struct {
+	Time time.Time
+	Id   string
+}
@@ -0,0 +1,20 @@
+package domain
+
+type EntityType string
+
+const (
+	EntityMention EntityType = "mention"
+	EntityHashtag EntityType = "hashtag"
+	EntityURL     EntityType = "url"
+)
+
+// Entity is a span of a post's text that clients render as a link. Start and End
+// are code point offsets, End exclusive. Text is the username for mentions, the
+// tag for hashtags and the address for URLs; UserId is set for mentions.
+type Entity struct {
+	Type   EntityType `json:"type"`
+	Start  int        `json:"start"`
+	End    int        `json:"end"`
+	Text   string     `json:"text"`
+	UserId *string    `json:"user_id,omitempty"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// Follow is an edge of the follow graph. User is the account on the other end of
+// the edge when the follow is listed for one of its ends.
+type Follow struct {
+	FollowerId string
+	FolloweeId string
+	CreatedAt  time.Time
+	User       *User
+}
+
+// Relationship is seen from one user's side: Blocking and Muting tell whether
+// that user blocked or muted the other one, and Requested whether the user's
+// request to follow the other one awaits approval. Whether the other one
+// blocked the user is never revealed.
+type Relationship struct {
+	Following  bool
+	FollowedBy bool
+	Requested  bool
+	Blocking   bool
+	Muting     bool
+}
+
+// FollowOutcome tells what following an account did: a protected account is
+// only requested, and following twice or across a block leaves things
+// unchanged.
+type FollowOutcome string
+
+const (
+	FollowUnchanged FollowOutcome = "unchanged"
+	FollowCreated   FollowOutcome = "created"
+	FollowRequested FollowOutcome = "requested"
+)
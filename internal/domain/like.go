@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// Like is a user liking a post. User and Post are filled in depending on which
+// side of the like is being listed.
+type Like struct {
+	UserId    string
+	PostId    string
+	CreatedAt time.Time
+	User      *User
+	Post      *Post
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+const (
+	// MaxListMembers is the number of accounts a list may hold.
+	MaxListMembers = 5000
+	// MaxOwnedLists is the number of lists a user may create.
+	MaxOwnedLists = 1000
+)
+
+// List is a curated timeline of the posts of its members. A private list is
+// only ever shown to its owner. Subscribed is seen from the user reading the
+// list.
+type List struct {
+	Id              string
+	OwnerId         string
+	Owner           *User
+	Name            string
+	Description     string
+	Private         bool
+	MemberCount     int
+	SubscriberCount int
+	Subscribed      bool
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ListUpdate holds the fields of a list to change; nil fields are left as they
+// are.
+type ListUpdate struct {
+	Name        *string
+	Description *string
+	Private     *bool
+}
+
+// ListMembership is a user being a member of a list or subscribed to it. User
+// and List are filled in depending on which side of the membership is being
+// listed.
+type ListMembership struct {
+	ListId    string
+	UserId    string
+	CreatedAt time.Time
+	User      *User
+	List      *List
+}
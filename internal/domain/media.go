@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// MaxPostMedia is the number of images a post can carry.
+const MaxPostMedia = 4
+
+// MediaVariant is a stored rendition of an image; the original is the variant
+// named "original".
+type MediaVariant struct {
+	Name        string `json:"name"`
+	Key         string `json:"key"`
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// MediaBlob is the stored content of an image, shared by every upload of the
+// same bytes. Hash is the hex SHA-256 of the uploaded bytes.
+type MediaBlob struct {
+	Hash        string          `json:"hash"`
+	ContentType string          `json:"content_type"`
+	Width       int             `json:"width"`
+	Height      int             `json:"height"`
+	Size        int64           `json:"size"`
+	Blurhash    string          `json:"blurhash"`
+	Variants    []*MediaVariant `json:"variants"`
+}
+
+// Media is an image uploaded by a user, which stays an orphan until it is
+// attached to a post.
+type Media struct {
+	MediaBlob
+	Id        string    `json:"id"`
+	UserId    string    `json:"user_id"`
+	PostId    *string   `json:"post_id"`
+	Position  int       `json:"position"`
+	AltText   string    `json:"alt_text"`
+	CreatedAt time.Time `json:"created_at"`
+}
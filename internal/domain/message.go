@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// MessagePrivacy decides who may start a conversation with a user.
+type MessagePrivacy string
+
+const (
+	MessageEveryone  MessagePrivacy = "everyone"
+	MessageFollowers MessagePrivacy = "followers"
+	MessageNobody    MessagePrivacy = "nobody"
+)
+
+var MessagePrivacies = []MessagePrivacy{
+	MessageEveryone,
+	MessageFollowers,
+	MessageNobody,
+}
+
+// Conversation is a one-to-one conversation when it has a direct key, and a
+// group otherwise. LastMessage and UnreadCount are as seen by the user the
+// conversation was loaded for.
+type Conversation struct {
+	Id           string
+	DirectKey    *string
+	CreatedBy    *string
+	Participants []*Participant
+	LastMessage  *Message
+	UnreadCount  int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (c *Conversation) IsGroup() bool {
+	return c.DirectKey == nil
+}
+
+// Others returns the participants other than the user.
+func (c *Conversation) Others(userId string) []*Participant {
+	var others []*Participant
+	for _, participant := range c.Participants {
+		if participant.User.Id != userId {
+			others = append(others, participant)
+		}
+	}
+	return others
+}
+
+// Participant is a member of a conversation with their read cursor.
+type Participant struct {
+	User              *User      `json:"user"`
+	LastReadMessageId *string    `json:"last_read_message_id"`
+	ReadAt            *time.Time `json:"read_at"`
+}
+
+type Message struct {
+	Id             string    `json:"id"`
+	ConversationId string    `json:"conversation_id"`
+	SenderId       string    `json:"sender_id"`
+	Sender         *User     `json:"sender"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ReadReceipt is a participant's read cursor moving to a message.
+type ReadReceipt struct {
+	ConversationId string
+	UserId         string
+	MessageId      string
+	ReadAt         time.Time
+}
@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// MutedWordScope is a feed a muted word hides posts from.
+type MutedWordScope string
+
+const (
+	MutedWordHome          MutedWordScope = "home"
+	MutedWordNotifications MutedWordScope = "notifications"
+	MutedWordReplies       MutedWordScope = "replies"
+)
+
+var MutedWordScopes = []MutedWordScope{
+	MutedWordHome,
+	MutedWordNotifications,
+	MutedWordReplies,
+}
+
+// MutedWord hides the posts containing Phrase, a word, phrase or hashtag, from
+// the user's feeds in Scopes until ExpiresAt, or for good when it is nil.
+type MutedWord struct {
+	Id        string
+	UserId    string
+	Phrase    string
+	Scopes    []MutedWordScope
+	ExpiresAt *time.Time
+	CreatedAt time.Time
+}
+
+func (m *MutedWord) IsExpired(now time.Time) bool {
+	return m.ExpiresAt != nil && !m.ExpiresAt.After(now)
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type NotificationType string
+
+const (
+	NotificationFollow  NotificationType = "follow"
+	NotificationLike    NotificationType = "like"
+	NotificationRepost  NotificationType = "repost"
+	NotificationReply   NotificationType = "reply"
+	NotificationMention NotificationType = "mention"
+	// NotificationFollowRequest tells a protected account about a request to
+	// follow it.
+	NotificationFollowRequest NotificationType = "follow_request"
+	// NotificationPollClosed tells the author of a poll and its voters that it
+	// closed. Its actor is the author, who is notified as well.
+	NotificationPollClosed NotificationType = "poll_closed"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationFollow,
+	NotificationLike,
+	NotificationRepost,
+	NotificationReply,
+	NotificationMention,
+	NotificationFollowRequest,
+	NotificationPollClosed,
+}
+
+// NotificationEvent is something a user did that another user may be told about.
+// PostId is the post acted upon: the liked or reposted post, or the reply or
+// the mentioning post itself.
+type NotificationEvent struct {
+	Type        NotificationType
+	RecipientId string
+	ActorId     string
+	PostId      *string
+	CreatedAt   time.Time
+}
+
+// Notification groups the events of one kind about the same subject while it is
+// unread. Actors holds the most recent actors; ActorCount counts all of them.
+type Notification struct {
+	Id         string
+	UserId     string
+	Type       NotificationType
+	PostId     *string
+	Actors     []*User
+	ActorCount int
+	ReadAt     *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// A poll offers between MinPollOptions and MaxPollOptions options.
+const (
+	MinPollOptions = 2
+	MaxPollOptions = 4
+)
+
+// PollOption is one of the answers of a poll. VoteCount is nil while the tally
+// is hidden from the reader.
+type PollOption struct {
+	Position  int    `json:"position"`
+	Text      string `json:"text"`
+	VoteCount *int   `json:"vote_count"`
+}
+
+// Poll is attached to the post asking it. The tallies are hidden from a reader
+// until they voted or the poll closed; its author always sees them. Vote is the
+// position the reader voted for.
+type Poll struct {
+	PostId      string        `json:"post_id"`
+	Options     []*PollOption `json:"options"`
+	VoteCount   *int          `json:"vote_count"`
+	Vote        *int          `json:"vote"`
+	ClosesAt    time.Time     `json:"closes_at"`
+	FinalizedAt *time.Time    `json:"finalized_at"`
+}
+
+// IsClosed reports whether votes are no longer accepted at now. A poll may be
+// closed before it has been finalized.
+func (p *Poll) IsClosed(now time.Time) bool {
+	return p.FinalizedAt != nil || !now.Before(p.ClosesAt)
+}
+
+// PollAnnouncement is a page of the "poll closed" notifications of a finalized
+// poll, which were stored for RecipientIds at CreatedAt. The author of the poll
+// is the actor of all of them.
+type PollAnnouncement struct {
+	PostId       string
+	AuthorId     string
+	RecipientIds []string
+	CreatedAt    time.Time
+}
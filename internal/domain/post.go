@@ -0,0 +1,31 @@
+package domain
+
+import "time"
+
+type Post struct {
+	Id             string     `json:"id"`
+	AuthorId       string     `json:"author_id"`
+	Body           string     `json:"body"`
+	ParentId       *string    `json:"parent_id"`
+	ConversationId string     `json:"conversation_id"`
+	Depth          int        `json:"depth"`
+	LikeCount      int64      `json:"like_count"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+	Author         *User      `json:"author"`
+	QuoteId        *string    `json:"quote_id"`
+	Quote          *Post      `json:"quote"`
+	Entities       []*Entity  `json:"entities"`
+	Media          []*Media   `json:"media"`
+	Poll           *Poll      `json:"poll"`
+	// Unavailable is set on a post shown to a reader that the author blocked
+	// or was blocked by. It is rendered like a deleted post.
+	Unavailable bool `json:"unavailable"`
+}
+
+// IsDeleted reports whether the post was deleted and only remains as a tombstone
+// keeping its conversation readable.
+func (p *Post) IsDeleted() bool {
+	return p.DeletedAt != nil
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type Repost struct {
+	UserId    string
+	PostId    string
+	CreatedAt time.Time
+	Post      *Post
+}
+
+// TimelineEntry is a post as it appears in a timeline. RepostedBy is set when the
+// post got there through a repost, and CreatedAt is then the time of the repost.
+type TimelineEntry struct {
+	Post       *Post
+	RepostedBy *User
+	CreatedAt  time.Time
+}
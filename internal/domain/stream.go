@@ -0,0 +1,39 @@
+package domain
+
+import "time"
+
+type StreamEventType string
+
+const (
+	StreamNotification StreamEventType = "notification"
+	StreamTimeline     StreamEventType = "timeline"
+	StreamMessage      StreamEventType = "message"
+	StreamReceipt      StreamEventType = "receipt"
+	// StreamReset tells a client its position could not be resumed and it should
+	// reload what it shows instead.
+	StreamReset StreamEventType = "reset"
+)
+
+// StreamEvent is an event delivered to connected clients. It goes either to the
+// user UserId or to the author AuthorId and everyone following them.
+type StreamEvent struct {
+	Id        int64
+	XactId    uint64
+	UserId    *string
+	AuthorId  *string
+	Type      StreamEventType
+	Payload   []byte
+	CreatedAt time.Time
+}
+
+// Position is where the event stands in stream order.
+func (e *StreamEvent) Position() StreamPosition {
+	return StreamPosition{XactId: e.XactId, Id: e.Id}
+}
+
+// StreamPosition is a place in stream order, the order events are delivered
+// in: by publishing transaction, then by id.
+type StreamPosition struct {
+	XactId uint64
+	Id     int64
+}
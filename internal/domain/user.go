@@ -3,10 +3,37 @@ package domain
 import "time"
 
 type User struct {
-	Id        string    `json:"id"`
-	Username  string    `json:"username"`
-	Email     string    `json:"email"`
-	Password  string    `json:"password"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	Id             string     `json:"id"`
+	Username       string     `json:"username"`
+	Email          string     `json:"email"`
+	Password       string     `json:"-"`
+	DisplayName    string     `json:"display_name"`
+	Bio            string     `json:"bio"`
+	Location       string     `json:"location"`
+	Website        string     `json:"website"`
+	AvatarURL      string     `json:"avatar_url"`
+	BannerURL      string     `json:"banner_url"`
+	PostCount      int        `json:"post_count"`
+	FollowerCount  int        `json:"follower_count"`
+	FollowingCount int        `json:"following_count"`
+	Protected      bool       `json:"protected"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"`
+}
+
+func (u *User) IsDeleted() bool {
+	return u.DeletedAt != nil
+}
+
+// ProfileUpdate lists the profile fields to change; nil fields are left as they
+// are and empty ones are cleared.
+type ProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	Location    *string
+	Website     *string
+	AvatarURL   *string
+	BannerURL   *string
+	Protected   *bool
 }
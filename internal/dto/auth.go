@@ -3,7 +3,6 @@ package dto
 import (
 	"fmt"
 	"github.com/saleh-ghazimoradi/X/internal/customErr"
-	"github.com/saleh-ghazimoradi/X/internal/domain"
 	"regexp"
 	"strings"
 )
@@ -23,8 +22,8 @@ type AuthenticationInput struct {
 }
 
 type AuthenticationResponse struct {
-	AccessToken string       `json:"access_token"`
-	User        *domain.User `json:"user"`
+	AccessToken string    `json:"access_token"`
+	User        *SelfUser `json:"user"`
 }
 
 func (a *AuthenticationInput) Sanitize() {
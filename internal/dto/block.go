@@ -0,0 +1,24 @@
+package dto
+
+import "github.com/saleh-ghazimoradi/X/internal/domain"
+
+type BlockListResponse struct {
+	Users      []*UserProfile `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+// NewBlockListResponse builds a page of the accounts a user blocked or muted,
+// most recent first. The cursor is the block time and the account's id.
+func NewBlockListResponse(blocks []*domain.Block, limit int) *BlockListResponse {
+	res := &BlockListResponse{Users: make([]*UserProfile, 0, len(blocks))}
+	if len(blocks) > limit {
+		last := blocks[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.TargetId})
+		blocks = blocks[:limit]
+	}
+
+	for _, block := range blocks {
+		res.Users = append(res.Users, NewUserProfile(block.User))
+	}
+	return res
+}
@@ -0,0 +1,108 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"time"
+)
+
+// FolderNameMaxLength is counted in grapheme clusters, like post bodies.
+const FolderNameMaxLength = 50
+
+// BookmarkInput files the bookmark in a folder; without folder_id the bookmark
+// is unfiled.
+type BookmarkInput struct {
+	FolderId *string `json:"folder_id"`
+}
+
+func (b *BookmarkInput) Sanitize() {
+	if b.FolderId != nil {
+		*b.FolderId = strings.TrimSpace(*b.FolderId)
+	}
+}
+
+func (b *BookmarkInput) Validate() error {
+	if b.FolderId != nil && !IsValidId(*b.FolderId) {
+		return fmt.Errorf("%w: invalid folder_id", customErr.ErrValidation)
+	}
+	return nil
+}
+
+type BookmarkFolderInput struct {
+	Name string `json:"name"`
+}
+
+// Sanitize collapses the whitespace of the name, so names differing only by it
+// are the same folder.
+func (b *BookmarkFolderInput) Sanitize() {
+	b.Name = strings.Join(strings.Fields(norm.NFC.String(b.Name)), " ")
+}
+
+func (b *BookmarkFolderInput) Validate() error {
+	if b.Name == "" {
+		return fmt.Errorf("%w: name required", customErr.ErrValidation)
+	}
+
+	if length := uniseg.GraphemeClusterCount(b.Name); length > FolderNameMaxLength {
+		return fmt.Errorf("%w: name too long, (%d) character at most, got (%d)", customErr.ErrValidation, FolderNameMaxLength, length)
+	}
+
+	if hasControl(b.Name, false) {
+		return fmt.Errorf("%w: name must not contain control characters", customErr.ErrValidation)
+	}
+	return nil
+}
+
+type BookmarkResponse struct {
+	Bookmarked bool    `json:"bookmarked"`
+	FolderId   *string `json:"folder_id,omitempty"`
+}
+
+type BookmarkFolderResponse struct {
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type BookmarkFolderListResponse struct {
+	Folders []*BookmarkFolderResponse `json:"folders"`
+}
+
+func NewBookmarkFolderResponse(folder *domain.BookmarkFolder) *BookmarkFolderResponse {
+	if folder == nil {
+		return nil
+	}
+	return &BookmarkFolderResponse{
+		Id:        folder.Id,
+		Name:      folder.Name,
+		CreatedAt: folder.CreatedAt,
+	}
+}
+
+func NewBookmarkFolderListResponse(folders []*domain.BookmarkFolder) *BookmarkFolderListResponse {
+	res := &BookmarkFolderListResponse{Folders: make([]*BookmarkFolderResponse, 0, len(folders))}
+	for _, folder := range folders {
+		res.Folders = append(res.Folders, NewBookmarkFolderResponse(folder))
+	}
+	return res
+}
+
+// NewBookmarkedPostListResponse builds a page of the posts a user saved. Like
+// liked posts it is ordered by bookmark time, which the cursor carries.
+func NewBookmarkedPostListResponse(bookmarks []*domain.Bookmark, limit int) *PostListResponse {
+	res := &PostListResponse{Posts: make([]*PostResponse, 0, len(bookmarks))}
+	if len(bookmarks) > limit {
+		last := bookmarks[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.PostId})
+		bookmarks = bookmarks[:limit]
+	}
+
+	for _, bookmark := range bookmarks {
+		res.Posts = append(res.Posts, NewPostResponse(bookmark.Post))
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestBookmarkFolderInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    *BookmarkFolderInput
+		wantName string
+		wantErr  bool
+	}{
+		{name: "whitespace is collapsed", input: &BookmarkFolderInput{Name: "  Read\t later "}, wantName: "Read later"},
+		{name: "case is kept", input: &BookmarkFolderInput{Name: "Go Talks"}, wantName: "Go Talks"},
+		{name: "name required", input: &BookmarkFolderInput{Name: " \n "}, wantErr: true},
+		{name: "too long", input: &BookmarkFolderInput{Name: strings.Repeat("a", FolderNameMaxLength+1)}, wantErr: true},
+		{name: "control characters", input: &BookmarkFolderInput{Name: "a\u0007b"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.input.Sanitize()
+			err := tc.input.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, customErr.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantName, tc.input.Name)
+		})
+	}
+}
+
+func TestBookmarkInput(t *testing.T) {
+	folderId, invalid := " 6ba7b810-9dad-11d1-80b4-00c04fd430c8 ", "folder"
+
+	input := &BookmarkInput{FolderId: &folderId}
+	input.Sanitize()
+	require.NoError(t, input.Validate())
+	require.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", *input.FolderId)
+
+	require.NoError(t, (&BookmarkInput{}).Validate())
+	require.ErrorIs(t, (&BookmarkInput{FolderId: &invalid}).Validate(), customErr.ErrValidation)
+}
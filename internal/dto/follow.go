@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+)
+
+// UserProfile is a public user together with the follow graph counts kept on
+// the user row.
+type UserProfile struct {
+	*PublicUser
+	FollowerCount  int `json:"follower_count"`
+	FollowingCount int `json:"following_count"`
+}
+
+type FollowListResponse struct {
+	Users      []*UserProfile `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+type RelationshipResponse struct {
+	Following  bool `json:"following"`
+	FollowedBy bool `json:"followed_by"`
+	Requested  bool `json:"requested"`
+	Blocking   bool `json:"blocking"`
+	Muting     bool `json:"muting"`
+}
+
+func NewUserProfile(user *domain.User) *UserProfile {
+	if user == nil {
+		return nil
+	}
+	return &UserProfile{
+		PublicUser:     NewPublicUser(user),
+		FollowerCount:  user.FollowerCount,
+		FollowingCount: user.FollowingCount,
+	}
+}
+
+// NewFollowListResponse builds a page of the accounts on the other end of the
+// follows. The cursor is the follow time and the listed account's id.
+func NewFollowListResponse(follows []*domain.Follow, limit int) *FollowListResponse {
+	res := &FollowListResponse{Users: make([]*UserProfile, 0, len(follows))}
+	if len(follows) > limit {
+		last := follows[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.User.Id})
+		follows = follows[:limit]
+	}
+
+	for _, follow := range follows {
+		res.Users = append(res.Users, NewUserProfile(follow.User))
+	}
+	return res
+}
+
+func NewRelationshipResponse(relationship *domain.Relationship) *RelationshipResponse {
+	if relationship == nil {
+		return nil
+	}
+	return &RelationshipResponse{
+		Following:  relationship.Following,
+		FollowedBy: relationship.FollowedBy,
+		Requested:  relationship.Requested,
+		Blocking:   relationship.Blocking,
+		Muting:     relationship.Muting,
+	}
+}
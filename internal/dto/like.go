@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+)
+
+type LikeResponse struct {
+	Liked     bool  `json:"liked"`
+	LikeCount int64 `json:"like_count"`
+}
+
+type LikeListResponse struct {
+	Users      []*PublicUser `json:"users"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+}
+
+// NewLikeListResponse builds a page of the users who liked a post. The cursor is
+// the like time and the user's id.
+func NewLikeListResponse(likes []*domain.Like, limit int) *LikeListResponse {
+	res := &LikeListResponse{Users: make([]*PublicUser, 0, len(likes))}
+	if len(likes) > limit {
+		last := likes[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.UserId})
+		likes = likes[:limit]
+	}
+
+	for _, like := range likes {
+		res.Users = append(res.Users, NewPublicUser(like.User))
+	}
+	return res
+}
+
+// NewLikedPostListResponse builds a page of the posts a user liked. Unlike other
+// post lists it is ordered by like time, which the cursor carries.
+func NewLikedPostListResponse(likes []*domain.Like, limit int) *PostListResponse {
+	res := &PostListResponse{Posts: make([]*PostResponse, 0, len(likes))}
+	if len(likes) > limit {
+		last := likes[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.PostId})
+		likes = likes[:limit]
+	}
+
+	for _, like := range likes {
+		res.Posts = append(res.Posts, NewPostResponse(like.Post))
+	}
+	return res
+}
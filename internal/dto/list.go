@@ -0,0 +1,176 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"time"
+)
+
+// Lengths are counted in grapheme clusters, like post bodies.
+const (
+	ListNameMaxLength        = 25
+	ListDescriptionMaxLength = 100
+)
+
+type CreateListInput struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Private     bool   `json:"private"`
+}
+
+func (c *CreateListInput) Sanitize() {
+	c.Name = norm.NFC.String(strings.TrimSpace(c.Name))
+	c.Description = norm.NFC.String(strings.TrimSpace(c.Description))
+}
+
+func (c *CreateListInput) Validate() error {
+	return validateList(&c.Name, &c.Description)
+}
+
+func (c *CreateListInput) List(ownerId string) *domain.List {
+	return &domain.List{OwnerId: ownerId, Name: c.Name, Description: c.Description, Private: c.Private}
+}
+
+// UpdateListInput changes the fields that are set; an empty description clears
+// it.
+type UpdateListInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Private     *bool   `json:"private"`
+}
+
+func (u *UpdateListInput) Sanitize() {
+	for _, field := range []*string{u.Name, u.Description} {
+		if field != nil {
+			*field = norm.NFC.String(strings.TrimSpace(*field))
+		}
+	}
+}
+
+func (u *UpdateListInput) Validate() error {
+	return validateList(u.Name, u.Description)
+}
+
+func (u *UpdateListInput) ListUpdate() *domain.ListUpdate {
+	return &domain.ListUpdate{Name: u.Name, Description: u.Description, Private: u.Private}
+}
+
+// validateList checks the name and description of a list; nil ones are not
+// being set.
+func validateList(name, description *string) error {
+	if name != nil {
+		if *name == "" {
+			return fmt.Errorf("%w: name required", customErr.ErrValidation)
+		}
+
+		if length := uniseg.GraphemeClusterCount(*name); length > ListNameMaxLength {
+			return fmt.Errorf("%w: name too long, (%d) character at most, got (%d)", customErr.ErrValidation, ListNameMaxLength, length)
+		}
+
+		if hasControl(*name, false) {
+			return fmt.Errorf("%w: name must not contain control characters", customErr.ErrValidation)
+		}
+	}
+
+	if description != nil {
+		if length := uniseg.GraphemeClusterCount(*description); length > ListDescriptionMaxLength {
+			return fmt.Errorf("%w: description too long, (%d) character at most, got (%d)", customErr.ErrValidation, ListDescriptionMaxLength, length)
+		}
+
+		if hasControl(*description, true) {
+			return fmt.Errorf("%w: description must not contain control characters", customErr.ErrValidation)
+		}
+	}
+	return nil
+}
+
+type ListResponse struct {
+	Id              string      `json:"id"`
+	Owner           *PublicUser `json:"owner"`
+	Name            string      `json:"name"`
+	Description     string      `json:"description"`
+	Private         bool        `json:"private"`
+	MemberCount     int         `json:"member_count"`
+	SubscriberCount int         `json:"subscriber_count"`
+	Subscribed      bool        `json:"subscribed"`
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// ListsResponse is a page of lists.
+type ListsResponse struct {
+	Lists      []*ListResponse `json:"lists"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+func NewListResponse(list *domain.List) *ListResponse {
+	if list == nil {
+		return nil
+	}
+	return &ListResponse{
+		Id:              list.Id,
+		Owner:           NewPublicUser(list.Owner),
+		Name:            list.Name,
+		Description:     list.Description,
+		Private:         list.Private,
+		MemberCount:     list.MemberCount,
+		SubscriberCount: list.SubscriberCount,
+		Subscribed:      list.Subscribed,
+		CreatedAt:       list.CreatedAt,
+	}
+}
+
+// NewListsResponse builds a page of the lists of an owner. The cursor is the
+// creation time and the id of the list.
+func NewListsResponse(lists []*domain.List, limit int) *ListsResponse {
+	res := &ListsResponse{Lists: make([]*ListResponse, 0, len(lists))}
+	if len(lists) > limit {
+		last := lists[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Id})
+		lists = lists[:limit]
+	}
+
+	for _, list := range lists {
+		res.Lists = append(res.Lists, NewListResponse(list))
+	}
+	return res
+}
+
+// NewSubscribedListsResponse builds a page of the lists a user subscribed to,
+// ordered by subscription time, which the cursor carries.
+func NewSubscribedListsResponse(subscriptions []*domain.ListMembership, limit int) *ListsResponse {
+	res := &ListsResponse{Lists: make([]*ListResponse, 0, len(subscriptions))}
+	if len(subscriptions) > limit {
+		last := subscriptions[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.ListId})
+		subscriptions = subscriptions[:limit]
+	}
+
+	for _, subscription := range subscriptions {
+		res.Lists = append(res.Lists, NewListResponse(subscription.List))
+	}
+	return res
+}
+
+// NewListUserListResponse builds a page of the members or subscribers of a
+// list. The cursor is the time the user joined and the user's id.
+func NewListUserListResponse(memberships []*domain.ListMembership, limit int) *FollowListResponse {
+	res := &FollowListResponse{Users: make([]*UserProfile, 0, len(memberships))}
+	if len(memberships) > limit {
+		last := memberships[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.UserId})
+		memberships = memberships[:limit]
+	}
+
+	for _, membership := range memberships {
+		res.Users = append(res.Users, NewUserProfile(membership.User))
+	}
+	return res
+}
+
+type ListSubscriptionResponse struct {
+	Subscribed bool `json:"subscribed"`
+}
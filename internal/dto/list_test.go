@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCreateListInput(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    *CreateListInput
+		wantName string
+		wantErr  bool
+	}{
+		{name: "trimmed", input: &CreateListInput{Name: "  Gophers ", Description: " people who write Go "}, wantName: "Gophers"},
+		{name: "description optional", input: &CreateListInput{Name: "Gophers"}, wantName: "Gophers"},
+		{name: "name required", input: &CreateListInput{Name: " \t "}, wantErr: true},
+		{name: "name too long", input: &CreateListInput{Name: strings.Repeat("a", ListNameMaxLength+1)}, wantErr: true},
+		{name: "name counted in graphemes", input: &CreateListInput{Name: strings.Repeat("👍🏽", ListNameMaxLength)}, wantName: strings.Repeat("👍🏽", ListNameMaxLength)},
+		{name: "name with newline", input: &CreateListInput{Name: "a\nb"}, wantErr: true},
+		{name: "description too long", input: &CreateListInput{Name: "a", Description: strings.Repeat("a", ListDescriptionMaxLength+1)}, wantErr: true},
+		{name: "description control characters", input: &CreateListInput{Name: "a", Description: "a\u0007b"}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.input.Sanitize()
+			err := tc.input.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, customErr.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantName, tc.input.Name)
+		})
+	}
+}
+
+func TestUpdateListInput(t *testing.T) {
+	name, empty, private := " Gophers ", "", true
+
+	input := &UpdateListInput{Name: &name, Description: &empty}
+	input.Sanitize()
+	require.NoError(t, input.Validate())
+	require.Equal(t, "Gophers", *input.Name)
+
+	require.NoError(t, (&UpdateListInput{Private: &private}).Validate())
+	require.ErrorIs(t, (&UpdateListInput{Name: &empty}).Validate(), customErr.ErrValidation)
+}
@@ -0,0 +1,52 @@
+package dto
+
+import "github.com/saleh-ghazimoradi/X/internal/domain"
+
+type MediaVariantResponse struct {
+	URL         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Width       int    `json:"width"`
+	Height      int    `json:"height"`
+}
+
+// MediaResponse describes an image with its renditions by name: "original",
+// "small" and "thumb". Blurhash is a placeholder clients can render until the
+// image loaded.
+type MediaResponse struct {
+	Id          string                           `json:"id"`
+	ContentType string                           `json:"content_type"`
+	Width       int                              `json:"width"`
+	Height      int                              `json:"height"`
+	Blurhash    string                           `json:"blurhash"`
+	AltText     string                           `json:"alt_text"`
+	Variants    map[string]*MediaVariantResponse `json:"variants"`
+}
+
+func NewMediaResponse(media *domain.Media) *MediaResponse {
+	res := &MediaResponse{
+		Id:          media.Id,
+		ContentType: media.ContentType,
+		Width:       media.Width,
+		Height:      media.Height,
+		Blurhash:    media.Blurhash,
+		AltText:     media.AltText,
+		Variants:    make(map[string]*MediaVariantResponse, len(media.Variants)),
+	}
+	for _, variant := range media.Variants {
+		res.Variants[variant.Name] = &MediaVariantResponse{
+			URL:         variant.URL,
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+		}
+	}
+	return res
+}
+
+func NewMediaResponses(media []*domain.Media) []*MediaResponse {
+	res := make([]*MediaResponse, 0, len(media))
+	for _, m := range media {
+		res = append(res, NewMediaResponse(m))
+	}
+	return res
+}
@@ -0,0 +1,216 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// MessageBodyMaxLength is counted in grapheme clusters, like post bodies.
+	MessageBodyMaxLength = 10000
+	// MaxConversationParticipants includes the user starting the conversation.
+	MaxConversationParticipants = 10
+)
+
+// CreateConversationInput names the other participants by username. A single
+// participant makes a one-to-one conversation, more make a group.
+type CreateConversationInput struct {
+	Participants []string `json:"participants"`
+}
+
+func (c *CreateConversationInput) Sanitize() {
+	participants := make([]string, 0, len(c.Participants))
+	for _, username := range c.Participants {
+		username = strings.TrimPrefix(strings.TrimSpace(username), "@")
+		if !slices.Contains(participants, username) {
+			participants = append(participants, username)
+		}
+	}
+	c.Participants = participants
+}
+
+func (c *CreateConversationInput) Validate() error {
+	if len(c.Participants) == 0 {
+		return fmt.Errorf("%w: at least one participant required", customErr.ErrValidation)
+	}
+
+	if len(c.Participants) > MaxConversationParticipants-1 {
+		return fmt.Errorf("%w: a conversation has (%d) participants at most", customErr.ErrValidation, MaxConversationParticipants)
+	}
+
+	if slices.Contains(c.Participants, "") {
+		return fmt.Errorf("%w: participant username required", customErr.ErrValidation)
+	}
+	return nil
+}
+
+type SendMessageInput struct {
+	Body string `json:"body"`
+}
+
+func (s *SendMessageInput) Sanitize() {
+	s.Body = norm.NFC.String(strings.TrimSpace(s.Body))
+}
+
+func (s *SendMessageInput) Validate() error {
+	if s.Body == "" {
+		return fmt.Errorf("%w: message body required", customErr.ErrValidation)
+	}
+
+	if length := uniseg.GraphemeClusterCount(s.Body); length > MessageBodyMaxLength {
+		return fmt.Errorf("%w: message body too long, (%d) character at most, got (%d)", customErr.ErrValidation, MessageBodyMaxLength, length)
+	}
+	return nil
+}
+
+// MessageSettings decides who may start a conversation with the user.
+type MessageSettings struct {
+	WhoCanMessage string `json:"who_can_message"`
+}
+
+func (m *MessageSettings) Sanitize() {
+	m.WhoCanMessage = strings.ToLower(strings.TrimSpace(m.WhoCanMessage))
+}
+
+func (m *MessageSettings) Validate() error {
+	if !slices.Contains(domain.MessagePrivacies, domain.MessagePrivacy(m.WhoCanMessage)) {
+		return fmt.Errorf("%w: who_can_message must be one of everyone, followers or nobody", customErr.ErrValidation)
+	}
+	return nil
+}
+
+type MessageResponse struct {
+	Id             string      `json:"id"`
+	ConversationId string      `json:"conversation_id"`
+	Sender         *PublicUser `json:"sender"`
+	Body           string      `json:"body"`
+	CreatedAt      time.Time   `json:"created_at"`
+}
+
+type MessageListResponse struct {
+	Messages   []*MessageResponse `json:"messages"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// ParticipantResponse carries the participant's read receipt: the last message
+// they read and when.
+type ParticipantResponse struct {
+	User              *PublicUser `json:"user"`
+	LastReadMessageId *string     `json:"last_read_message_id,omitempty"`
+	ReadAt            *time.Time  `json:"read_at,omitempty"`
+}
+
+type ConversationResponse struct {
+	Id           string                 `json:"id"`
+	Group        bool                   `json:"group"`
+	Participants []*ParticipantResponse `json:"participants"`
+	LastMessage  *MessageResponse       `json:"last_message,omitempty"`
+	UnreadCount  int                    `json:"unread_count"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+type ConversationListResponse struct {
+	Conversations []*ConversationResponse `json:"conversations"`
+	NextCursor    string                  `json:"next_cursor,omitempty"`
+}
+
+// ReadReceiptResponse is streamed to the participants when one of them reads
+// the conversation.
+type ReadReceiptResponse struct {
+	ConversationId string      `json:"conversation_id"`
+	User           *PublicUser `json:"user"`
+	MessageId      string      `json:"message_id"`
+	ReadAt         time.Time   `json:"read_at"`
+}
+
+func NewMessageSettings(privacy domain.MessagePrivacy) *MessageSettings {
+	return &MessageSettings{WhoCanMessage: string(privacy)}
+}
+
+func NewMessageResponse(message *domain.Message) *MessageResponse {
+	if message == nil {
+		return nil
+	}
+	return &MessageResponse{
+		Id:             message.Id,
+		ConversationId: message.ConversationId,
+		Sender:         NewPublicUser(message.Sender),
+		Body:           message.Body,
+		CreatedAt:      message.CreatedAt,
+	}
+}
+
+// NewMessageListResponse builds a page of messages, newest first. The cursor is
+// the time the message was sent and its id.
+func NewMessageListResponse(messages []*domain.Message, limit int) *MessageListResponse {
+	res := &MessageListResponse{Messages: make([]*MessageResponse, 0, len(messages))}
+	if len(messages) > limit {
+		last := messages[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Id})
+		messages = messages[:limit]
+	}
+
+	for _, message := range messages {
+		res.Messages = append(res.Messages, NewMessageResponse(message))
+	}
+	return res
+}
+
+func NewConversationResponse(conversation *domain.Conversation) *ConversationResponse {
+	if conversation == nil {
+		return nil
+	}
+
+	res := &ConversationResponse{
+		Id:           conversation.Id,
+		Group:        conversation.IsGroup(),
+		Participants: make([]*ParticipantResponse, 0, len(conversation.Participants)),
+		LastMessage:  NewMessageResponse(conversation.LastMessage),
+		UnreadCount:  conversation.UnreadCount,
+		CreatedAt:    conversation.CreatedAt,
+		UpdatedAt:    conversation.UpdatedAt,
+	}
+	for _, participant := range conversation.Participants {
+		res.Participants = append(res.Participants, &ParticipantResponse{
+			User:              NewPublicUser(participant.User),
+			LastReadMessageId: participant.LastReadMessageId,
+			ReadAt:            participant.ReadAt,
+		})
+	}
+	return res
+}
+
+// NewConversationListResponse builds a page of conversations. The cursor is the
+// time of the conversation's latest activity and its id.
+func NewConversationListResponse(conversations []*domain.Conversation, limit int) *ConversationListResponse {
+	res := &ConversationListResponse{Conversations: make([]*ConversationResponse, 0, len(conversations))}
+	if len(conversations) > limit {
+		last := conversations[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.UpdatedAt, Id: last.Id})
+		conversations = conversations[:limit]
+	}
+
+	for _, conversation := range conversations {
+		res.Conversations = append(res.Conversations, NewConversationResponse(conversation))
+	}
+	return res
+}
+
+func NewReadReceiptResponse(receipt *domain.ReadReceipt, user *domain.User) *ReadReceiptResponse {
+	if receipt == nil {
+		return nil
+	}
+	return &ReadReceiptResponse{
+		ConversationId: receipt.ConversationId,
+		User:           NewPublicUser(user),
+		MessageId:      receipt.MessageId,
+		ReadAt:         receipt.ReadAt,
+	}
+}
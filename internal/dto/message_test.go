@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestCreateConversationInput(t *testing.T) {
+	testCases := []struct {
+		name         string
+		participants []string
+		want         []string
+		wantErr      bool
+	}{
+		{name: "usernames are deduplicated", participants: []string{" @alice", "alice", "bob"}, want: []string{"alice", "bob"}},
+		{name: "someone to talk to", participants: nil, wantErr: true},
+		{name: "blank username", participants: []string{"alice", " @ "}, wantErr: true},
+		{name: "too many", participants: strings.Split("a b c d e f g h i j", " "), wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := &CreateConversationInput{Participants: tc.participants}
+			input.Sanitize()
+			err := input.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, customErr.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, input.Participants)
+		})
+	}
+}
+
+func TestMessageSettings(t *testing.T) {
+	settings := &MessageSettings{WhoCanMessage: " Followers "}
+	settings.Sanitize()
+	require.NoError(t, settings.Validate())
+	require.Equal(t, "followers", settings.WhoCanMessage)
+
+	require.ErrorIs(t, (&MessageSettings{WhoCanMessage: "friends"}).Validate(), customErr.ErrValidation)
+}
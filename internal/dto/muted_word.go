@@ -0,0 +1,117 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MutedWordMaxLength is counted in grapheme clusters, like post bodies.
+const MutedWordMaxLength = 100
+
+// MuteWordInput mutes a word, a phrase or a hashtag. Without scopes the word is
+// muted everywhere; without expires_at it is muted until removed.
+type MuteWordInput struct {
+	Phrase    string     `json:"phrase"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// Sanitize lowercases the phrase and collapses its whitespace, so a phrase is
+// muted once however it is typed.
+func (m *MuteWordInput) Sanitize() {
+	m.Phrase = strings.ToLower(strings.Join(strings.Fields(norm.NFC.String(m.Phrase)), " "))
+
+	scopes := make([]string, 0, len(m.Scopes))
+	for _, scope := range m.Scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if len(scopes) == 0 {
+		for _, scope := range domain.MutedWordScopes {
+			scopes = append(scopes, string(scope))
+		}
+	}
+	slices.Sort(scopes)
+	m.Scopes = scopes
+}
+
+func (m *MuteWordInput) Validate() error {
+	if m.Phrase == "" {
+		return fmt.Errorf("%w: phrase required", customErr.ErrValidation)
+	}
+
+	if length := uniseg.GraphemeClusterCount(m.Phrase); length > MutedWordMaxLength {
+		return fmt.Errorf("%w: phrase too long, (%d) character at most, got (%d)", customErr.ErrValidation, MutedWordMaxLength, length)
+	}
+
+	if !strings.ContainsFunc(m.Phrase, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsNumber(r) }) {
+		return fmt.Errorf("%w: phrase must contain a letter or a digit", customErr.ErrValidation)
+	}
+
+	for _, scope := range m.Scopes {
+		if !slices.Contains(domain.MutedWordScopes, domain.MutedWordScope(scope)) {
+			return fmt.Errorf("%w: unknown scope %q", customErr.ErrValidation, scope)
+		}
+	}
+
+	if m.ExpiresAt != nil && !m.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("%w: expires_at must be in the future", customErr.ErrValidation)
+	}
+	return nil
+}
+
+// MutedWord builds the muted word the input describes for the user.
+func (m *MuteWordInput) MutedWord(userId string) *domain.MutedWord {
+	word := &domain.MutedWord{UserId: userId, Phrase: m.Phrase, ExpiresAt: m.ExpiresAt}
+	for _, scope := range m.Scopes {
+		word.Scopes = append(word.Scopes, domain.MutedWordScope(scope))
+	}
+	return word
+}
+
+type MutedWordResponse struct {
+	Id        string     `json:"id"`
+	Phrase    string     `json:"phrase"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type MutedWordListResponse struct {
+	MutedWords []*MutedWordResponse `json:"muted_words"`
+}
+
+func NewMutedWordResponse(word *domain.MutedWord) *MutedWordResponse {
+	if word == nil {
+		return nil
+	}
+
+	res := &MutedWordResponse{
+		Id:        word.Id,
+		Phrase:    word.Phrase,
+		Scopes:    make([]string, 0, len(word.Scopes)),
+		ExpiresAt: word.ExpiresAt,
+		CreatedAt: word.CreatedAt,
+	}
+	for _, scope := range word.Scopes {
+		res.Scopes = append(res.Scopes, string(scope))
+	}
+	return res
+}
+
+func NewMutedWordListResponse(words []*domain.MutedWord) *MutedWordListResponse {
+	res := &MutedWordListResponse{MutedWords: make([]*MutedWordResponse, 0, len(words))}
+	for _, word := range words {
+		res.MutedWords = append(res.MutedWords, NewMutedWordResponse(word))
+	}
+	return res
+}
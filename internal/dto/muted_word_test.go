@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMuteWordInput(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	testCases := []struct {
+		name       string
+		input      *MuteWordInput
+		wantPhrase string
+		wantScopes []string
+		wantErr    bool
+	}{
+		{name: "phrase is lowercased and collapsed", input: &MuteWordInput{Phrase: "  Game  OF\tThrones "}, wantPhrase: "game of thrones", wantScopes: []string{"home", "notifications", "replies"}},
+		{name: "unicode is lowercased", input: &MuteWordInput{Phrase: "ÇAĞRI", Scopes: []string{"Replies", "replies"}}, wantPhrase: "çağri", wantScopes: []string{"replies"}},
+		{name: "hashtags are kept", input: &MuteWordInput{Phrase: "#GoLang", Scopes: []string{"home"}}, wantPhrase: "#golang", wantScopes: []string{"home"}},
+		{name: "phrase required", input: &MuteWordInput{Phrase: "  "}, wantErr: true},
+		{name: "punctuation alone", input: &MuteWordInput{Phrase: "#!"}, wantErr: true},
+		{name: "too long", input: &MuteWordInput{Phrase: strings.Repeat("a", MutedWordMaxLength+1)}, wantErr: true},
+		{name: "unknown scope", input: &MuteWordInput{Phrase: "go", Scopes: []string{"search"}}, wantErr: true},
+		{name: "already expired", input: &MuteWordInput{Phrase: "go", ExpiresAt: &past}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.input.Sanitize()
+			err := tc.input.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, customErr.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantPhrase, tc.input.Phrase)
+			require.Equal(t, tc.wantScopes, tc.input.Scopes)
+		})
+	}
+}
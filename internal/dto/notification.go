@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+var notificationVerbs = map[domain.NotificationType]string{
+	domain.NotificationFollow:        "followed you",
+	domain.NotificationLike:          "liked your post",
+	domain.NotificationRepost:        "reposted your post",
+	domain.NotificationReply:         "replied to your post",
+	domain.NotificationMention:       "mentioned you",
+	domain.NotificationFollowRequest: "requested to follow you",
+	domain.NotificationPollClosed:    "poll has ended",
+}
+
+type NotificationResponse struct {
+	Id         string        `json:"id"`
+	Type       string        `json:"type"`
+	PostId     *string       `json:"post_id,omitempty"`
+	Actors     []*PublicUser `json:"actors"`
+	ActorCount int           `json:"actor_count"`
+	Summary    string        `json:"summary"`
+	Read       bool          `json:"read"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+type NotificationListResponse struct {
+	Notifications []*NotificationResponse `json:"notifications"`
+	NextCursor    string                  `json:"next_cursor,omitempty"`
+}
+
+type UnreadCountResponse struct {
+	Count int `json:"count"`
+}
+
+// NotificationPreferences lists the notification types the user muted.
+type NotificationPreferences struct {
+	Muted []string `json:"muted"`
+}
+
+func (n *NotificationPreferences) Sanitize() {
+	muted := make([]string, 0, len(n.Muted))
+	for _, t := range n.Muted {
+		t = strings.ToLower(strings.TrimSpace(t))
+		if !slices.Contains(muted, t) {
+			muted = append(muted, t)
+		}
+	}
+	slices.Sort(muted)
+	n.Muted = muted
+}
+
+func (n *NotificationPreferences) Validate() error {
+	for _, t := range n.Muted {
+		if !slices.Contains(domain.NotificationTypes, domain.NotificationType(t)) {
+			return fmt.Errorf("%w: unknown notification type %q", customErr.ErrValidation, t)
+		}
+	}
+	return nil
+}
+
+func NewNotificationPreferences(muted []domain.NotificationType) *NotificationPreferences {
+	res := &NotificationPreferences{Muted: make([]string, 0, len(muted))}
+	for _, t := range muted {
+		res.Muted = append(res.Muted, string(t))
+	}
+	return res
+}
+
+func NewNotificationResponse(notification *domain.Notification) *NotificationResponse {
+	if notification == nil {
+		return nil
+	}
+
+	res := &NotificationResponse{
+		Id:         notification.Id,
+		Type:       string(notification.Type),
+		PostId:     notification.PostId,
+		Actors:     make([]*PublicUser, 0, len(notification.Actors)),
+		ActorCount: notification.ActorCount,
+		Summary:    notificationSummary(notification),
+		Read:       notification.IsRead(),
+		CreatedAt:  notification.CreatedAt,
+		UpdatedAt:  notification.UpdatedAt,
+	}
+	for _, actor := range notification.Actors {
+		res.Actors = append(res.Actors, NewPublicUser(actor))
+	}
+	return res
+}
+
+// notificationSummary names the latest actor and counts the others, as in
+// "alice and 12 others liked your post". A closed poll names its author instead.
+func notificationSummary(notification *domain.Notification) string {
+	if notification.Type == domain.NotificationPollClosed {
+		return pollClosedSummary(notification)
+	}
+
+	verb := notificationVerbs[notification.Type]
+	if len(notification.Actors) == 0 {
+		if notification.ActorCount == 1 {
+			return "Someone " + verb
+		}
+		return fmt.Sprintf("%d people %s", notification.ActorCount, verb)
+	}
+
+	first := notification.Actors[0].Username
+	switch others := notification.ActorCount - 1; {
+	case others <= 0:
+		return fmt.Sprintf("%s %s", first, verb)
+	case others == 1 && len(notification.Actors) > 1:
+		return fmt.Sprintf("%s and %s %s", first, notification.Actors[1].Username, verb)
+	case others == 1:
+		return fmt.Sprintf("%s and 1 other %s", first, verb)
+	default:
+		return fmt.Sprintf("%s and %d others %s", first, others, verb)
+	}
+}
+
+// pollClosedSummary tells the author "Your poll has ended" and the voters
+// "alice's poll has ended"; the only actor of the notification is the author.
+func pollClosedSummary(notification *domain.Notification) string {
+	verb := notificationVerbs[notification.Type]
+	switch {
+	case len(notification.Actors) == 0:
+		return "A " + verb
+	case notification.Actors[0].Id == notification.UserId:
+		return "Your " + verb
+	default:
+		return fmt.Sprintf("%s's %s", notification.Actors[0].Username, verb)
+	}
+}
+
+// NewNotificationListResponse builds a page of notifications. The cursor is the
+// time the notification last changed and its id.
+func NewNotificationListResponse(notifications []*domain.Notification, limit int) *NotificationListResponse {
+	res := &NotificationListResponse{Notifications: make([]*NotificationResponse, 0, len(notifications))}
+	if len(notifications) > limit {
+		last := notifications[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Id})
+		notifications = notifications[:limit]
+	}
+
+	for _, notification := range notifications {
+		res.Notifications = append(res.Notifications, NewNotificationResponse(notification))
+	}
+	return res
+}
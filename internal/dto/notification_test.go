@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestNotificationSummary(t *testing.T) {
+	alice, bob := &domain.User{Username: "alice"}, &domain.User{Username: "bob"}
+
+	testCases := []struct {
+		name         string
+		notification *domain.Notification
+		want         string
+	}{
+		{
+			name:         "single actor",
+			notification: &domain.Notification{Type: domain.NotificationFollow, Actors: []*domain.User{alice}, ActorCount: 1},
+			want:         "alice followed you",
+		},
+		{
+			name:         "two actors are both named",
+			notification: &domain.Notification{Type: domain.NotificationLike, Actors: []*domain.User{alice, bob}, ActorCount: 2},
+			want:         "alice and bob liked your post",
+		},
+		{
+			name:         "one other not shown",
+			notification: &domain.Notification{Type: domain.NotificationRepost, Actors: []*domain.User{alice}, ActorCount: 2},
+			want:         "alice and 1 other reposted your post",
+		},
+		{
+			name:         "many actors",
+			notification: &domain.Notification{Type: domain.NotificationLike, Actors: []*domain.User{alice, bob}, ActorCount: 13},
+			want:         "alice and 12 others liked your post",
+		},
+		{
+			name:         "actors deactivated",
+			notification: &domain.Notification{Type: domain.NotificationLike, ActorCount: 3},
+			want:         "3 people liked your post",
+		},
+		{
+			name:         "poll closed for its author",
+			notification: &domain.Notification{UserId: "1", Type: domain.NotificationPollClosed, Actors: []*domain.User{{Id: "1", Username: "alice"}}, ActorCount: 1},
+			want:         "Your poll has ended",
+		},
+		{
+			name:         "poll closed for a voter",
+			notification: &domain.Notification{UserId: "2", Type: domain.NotificationPollClosed, Actors: []*domain.User{{Id: "1", Username: "alice"}}, ActorCount: 1},
+			want:         "alice's poll has ended",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, NewNotificationResponse(tc.notification).Summary)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package dto
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+var idRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// IsValidId reports whether id has the shape of the UUIDs used as primary keys,
+// so malformed ids can be answered with not found before reaching the database.
+func IsValidId(id string) bool {
+	return idRegexp.MatchString(id)
+}
+
+// EncodeCursor turns a list position into the opaque string handed to clients.
+func EncodeCursor(cursor *domain.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := cursor.Time.UTC().Format(time.RFC3339Nano) + "|" + cursor.Id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a cursor produced by EncodeCursor; an empty string means the first page.
+func DecodeCursor(cursor string) (*domain.Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", customErr.ErrValidation)
+	}
+
+	timestamp, id, ok := strings.Cut(string(raw), "|")
+	if !ok || !IsValidId(id) {
+		return nil, fmt.Errorf("%w: invalid cursor", customErr.ErrValidation)
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", customErr.ErrValidation)
+	}
+	return &domain.Cursor{Time: t, Id: id}, nil
+}
+
+type PageInput struct {
+	Cursor string `json:"cursor"`
+	Limit  int    `json:"limit"`
+}
+
+func (p *PageInput) Sanitize() {
+	p.Cursor = strings.TrimSpace(p.Cursor)
+	if p.Limit == 0 {
+		p.Limit = DefaultPageSize
+	}
+}
+
+func (p *PageInput) Validate() error {
+	if p.Limit < 1 || p.Limit > MaxPageSize {
+		return fmt.Errorf("%w: limit must be between 1 and %d", customErr.ErrValidation, MaxPageSize)
+	}
+
+	if _, err := DecodeCursor(p.Cursor); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DecodedCursor returns the position to resume from; call it after Validate.
+func (p *PageInput) DecodedCursor() *domain.Cursor {
+	cursor, _ := DecodeCursor(p.Cursor)
+	return cursor
+}
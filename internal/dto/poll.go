@@ -0,0 +1,134 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PollOptionMaxLength is counted in grapheme clusters, like post bodies.
+const PollOptionMaxLength = 25
+
+// A poll stays open between MinPollDuration and MaxPollDuration.
+const (
+	MinPollDuration = 5 * time.Minute
+	MaxPollDuration = 7 * 24 * time.Hour
+)
+
+// PollInput attaches a poll to the post being created. Options are listed in
+// the order they are shown and voted for by their position, starting at 0.
+type PollInput struct {
+	Options         []string `json:"options"`
+	DurationMinutes int      `json:"duration_minutes"`
+}
+
+// Sanitize collapses the whitespace of the options, so options differing only
+// by it are recognized as duplicates.
+func (p *PollInput) Sanitize() {
+	for i, option := range p.Options {
+		p.Options[i] = strings.Join(strings.Fields(norm.NFC.String(option)), " ")
+	}
+}
+
+func (p *PollInput) Validate() error {
+	if len(p.Options) < domain.MinPollOptions || len(p.Options) > domain.MaxPollOptions {
+		return fmt.Errorf("%w: a poll has between (%d) and (%d) options, got (%d)", customErr.ErrValidation, domain.MinPollOptions, domain.MaxPollOptions, len(p.Options))
+	}
+
+	for i, option := range p.Options {
+		if option == "" {
+			return fmt.Errorf("%w: poll option required", customErr.ErrValidation)
+		}
+
+		if length := uniseg.GraphemeClusterCount(option); length > PollOptionMaxLength {
+			return fmt.Errorf("%w: poll option too long, (%d) character at most, got (%d)", customErr.ErrValidation, PollOptionMaxLength, length)
+		}
+
+		if hasControl(option, false) {
+			return fmt.Errorf("%w: poll option must not contain control characters", customErr.ErrValidation)
+		}
+
+		if slices.ContainsFunc(p.Options[:i], func(other string) bool { return strings.EqualFold(other, option) }) {
+			return fmt.Errorf("%w: poll options must differ", customErr.ErrValidation)
+		}
+	}
+
+	if duration := p.Duration(); duration < MinPollDuration || duration > MaxPollDuration {
+		return fmt.Errorf("%w: a poll lasts between (%d) and (%d) minutes, got (%d)", customErr.ErrValidation,
+			int(MinPollDuration.Minutes()), int(MaxPollDuration.Minutes()), p.DurationMinutes)
+	}
+	return nil
+}
+
+func (p *PollInput) Duration() time.Duration {
+	return time.Duration(p.DurationMinutes) * time.Minute
+}
+
+// Poll builds the poll of a post created at now.
+func (p *PollInput) Poll(now time.Time) *domain.Poll {
+	poll := &domain.Poll{ClosesAt: now.Add(p.Duration())}
+	for i, option := range p.Options {
+		poll.Options = append(poll.Options, &domain.PollOption{Position: i, Text: option})
+	}
+	return poll
+}
+
+// VoteInput votes for the option of a poll at Position.
+type VoteInput struct {
+	Position *int `json:"position"`
+}
+
+func (v *VoteInput) Validate() error {
+	if v.Position == nil {
+		return fmt.Errorf("%w: position required", customErr.ErrValidation)
+	}
+
+	if *v.Position < 0 || *v.Position >= domain.MaxPollOptions {
+		return fmt.Errorf("%w: invalid position", customErr.ErrValidation)
+	}
+	return nil
+}
+
+type PollOptionResponse struct {
+	Position  int    `json:"position"`
+	Text      string `json:"text"`
+	VoteCount *int   `json:"vote_count,omitempty"`
+}
+
+// PollResponse carries vote counts only once the reader may see them: after
+// voting, once the poll closed, or to its author. Vote is the position the
+// reader voted for.
+type PollResponse struct {
+	Options   []*PollOptionResponse `json:"options"`
+	VoteCount *int                  `json:"vote_count,omitempty"`
+	Vote      *int                  `json:"vote,omitempty"`
+	ClosesAt  time.Time             `json:"closes_at"`
+	Closed    bool                  `json:"closed"`
+}
+
+func NewPollResponse(poll *domain.Poll) *PollResponse {
+	if poll == nil {
+		return nil
+	}
+
+	res := &PollResponse{
+		Options:   make([]*PollOptionResponse, 0, len(poll.Options)),
+		VoteCount: poll.VoteCount,
+		Vote:      poll.Vote,
+		ClosesAt:  poll.ClosesAt,
+		Closed:    poll.IsClosed(time.Now()),
+	}
+	for _, option := range poll.Options {
+		res.Options = append(res.Options, &PollOptionResponse{
+			Position:  option.Position,
+			Text:      option.Text,
+			VoteCount: option.VoteCount,
+		})
+	}
+	return res
+}
@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPollInput(t *testing.T) {
+	testCases := []struct {
+		name        string
+		input       *PollInput
+		wantOptions []string
+		wantErr     bool
+	}{
+		{name: "whitespace is collapsed", input: &PollInput{Options: []string{" Yes ", "Not\t really"}, DurationMinutes: 60}, wantOptions: []string{"Yes", "Not really"}},
+		{name: "four options", input: &PollInput{Options: []string{"a", "b", "c", "d"}, DurationMinutes: 60}, wantOptions: []string{"a", "b", "c", "d"}},
+		{name: "one option", input: &PollInput{Options: []string{"a"}, DurationMinutes: 60}, wantErr: true},
+		{name: "five options", input: &PollInput{Options: []string{"a", "b", "c", "d", "e"}, DurationMinutes: 60}, wantErr: true},
+		{name: "empty option", input: &PollInput{Options: []string{"a", " "}, DurationMinutes: 60}, wantErr: true},
+		{name: "option too long", input: &PollInput{Options: []string{"a", strings.Repeat("b", PollOptionMaxLength+1)}, DurationMinutes: 60}, wantErr: true},
+		{name: "options differing by case", input: &PollInput{Options: []string{"Yes", "yes"}, DurationMinutes: 60}, wantErr: true},
+		{name: "control characters", input: &PollInput{Options: []string{"a", "b\u0007"}, DurationMinutes: 60}, wantErr: true},
+		{name: "too short", input: &PollInput{Options: []string{"a", "b"}, DurationMinutes: 4}, wantErr: true},
+		{name: "too long", input: &PollInput{Options: []string{"a", "b"}, DurationMinutes: int(MaxPollDuration.Minutes()) + 1}, wantErr: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.input.Sanitize()
+			err := tc.input.Validate()
+			if tc.wantErr {
+				require.ErrorIs(t, err, customErr.ErrValidation)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.wantOptions, tc.input.Options)
+		})
+	}
+}
+
+func TestPollInput_Poll(t *testing.T) {
+	now := time.Now()
+	poll := (&PollInput{Options: []string{"Yes", "No"}, DurationMinutes: 90}).Poll(now)
+	require.Equal(t, now.Add(90*time.Minute), poll.ClosesAt)
+	require.Equal(t, []*domain.PollOption{{Position: 0, Text: "Yes"}, {Position: 1, Text: "No"}}, poll.Options)
+}
+
+func TestVoteInput(t *testing.T) {
+	valid, negative, past := 1, -1, domain.MaxPollOptions
+
+	require.NoError(t, (&VoteInput{Position: &valid}).Validate())
+	require.ErrorIs(t, (&VoteInput{}).Validate(), customErr.ErrValidation)
+	require.ErrorIs(t, (&VoteInput{Position: &negative}).Validate(), customErr.ErrValidation)
+	require.ErrorIs(t, (&VoteInput{Position: &past}).Validate(), customErr.ErrValidation)
+}
+
+func TestNewPollResponse(t *testing.T) {
+	count := 3
+
+	t.Run("hidden tallies are left out", func(t *testing.T) {
+		res := NewPollResponse(&domain.Poll{
+			Options:  []*domain.PollOption{{Position: 0, Text: "Yes"}, {Position: 1, Text: "No"}},
+			ClosesAt: time.Now().Add(time.Hour),
+		})
+		require.False(t, res.Closed)
+		require.Nil(t, res.VoteCount)
+		require.Nil(t, res.Options[0].VoteCount)
+	})
+
+	t.Run("a poll past its closing time is closed before it is finalized", func(t *testing.T) {
+		res := NewPollResponse(&domain.Poll{
+			Options:   []*domain.PollOption{{Position: 0, Text: "Yes", VoteCount: &count}},
+			VoteCount: &count,
+			ClosesAt:  time.Now().Add(-time.Minute),
+		})
+		require.True(t, res.Closed)
+		require.Equal(t, 3, *res.Options[0].VoteCount)
+	})
+}
@@ -0,0 +1,225 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"time"
+)
+
+// PostBodyMaxLength is counted in grapheme clusters, so an emoji built from several
+// code points, or a CJK character, counts as a single character.
+const PostBodyMaxLength = 280
+
+// AltTextMaxLength is counted in grapheme clusters like the post body.
+const AltTextMaxLength = 1000
+
+type CreatePostInput struct {
+	Body      string              `json:"body"`
+	ReplyToId *string             `json:"reply_to_id"`
+	QuoteId   *string             `json:"quote_id"`
+	Media     []*AttachMediaInput `json:"media"`
+	Poll      *PollInput          `json:"poll"`
+}
+
+// AttachMediaInput attaches an uploaded image to the post being created, with
+// the text describing it to readers who cannot see it.
+type AttachMediaInput struct {
+	Id      string `json:"id"`
+	AltText string `json:"alt_text"`
+}
+
+func (c *CreatePostInput) Sanitize() {
+	c.Body = norm.NFC.String(strings.TrimSpace(c.Body))
+	if c.ReplyToId != nil {
+		replyToId := strings.TrimSpace(*c.ReplyToId)
+		c.ReplyToId = &replyToId
+	}
+	if c.QuoteId != nil {
+		quoteId := strings.TrimSpace(*c.QuoteId)
+		c.QuoteId = &quoteId
+	}
+	for _, media := range c.Media {
+		if media != nil {
+			media.Id = strings.TrimSpace(media.Id)
+			media.AltText = norm.NFC.String(strings.TrimSpace(media.AltText))
+		}
+	}
+	if c.Poll != nil {
+		c.Poll.Sanitize()
+	}
+}
+
+// Validate requires a body unless the post carries media.
+func (c *CreatePostInput) Validate() error {
+	if c.Body == "" && len(c.Media) == 0 {
+		return fmt.Errorf("%w: post body required", customErr.ErrValidation)
+	}
+
+	if length := uniseg.GraphemeClusterCount(c.Body); length > PostBodyMaxLength {
+		return fmt.Errorf("%w: post body too long, (%d) character at most, got (%d)", customErr.ErrValidation, PostBodyMaxLength, length)
+	}
+
+	if c.ReplyToId != nil && !IsValidId(*c.ReplyToId) {
+		return fmt.Errorf("%w: invalid reply_to_id", customErr.ErrValidation)
+	}
+
+	if c.QuoteId != nil && !IsValidId(*c.QuoteId) {
+		return fmt.Errorf("%w: invalid quote_id", customErr.ErrValidation)
+	}
+
+	if len(c.Media) > domain.MaxPostMedia {
+		return fmt.Errorf("%w: too many media, (%d) at most, got (%d)", customErr.ErrValidation, domain.MaxPostMedia, len(c.Media))
+	}
+
+	seen := make(map[string]bool, len(c.Media))
+	for _, media := range c.Media {
+		if media == nil || !IsValidId(media.Id) {
+			return fmt.Errorf("%w: invalid media id", customErr.ErrValidation)
+		}
+
+		if seen[media.Id] {
+			return fmt.Errorf("%w: media attached twice", customErr.ErrValidation)
+		}
+		seen[media.Id] = true
+
+		if length := uniseg.GraphemeClusterCount(media.AltText); length > AltTextMaxLength {
+			return fmt.Errorf("%w: alt text too long, (%d) character at most, got (%d)", customErr.ErrValidation, AltTextMaxLength, length)
+		}
+
+		if hasControl(media.AltText, true) {
+			return fmt.Errorf("%w: alt text contains control characters", customErr.ErrValidation)
+		}
+	}
+
+	if c.Poll != nil {
+		return c.Poll.Validate()
+	}
+	return nil
+}
+
+// PostMedia returns the media to attach to the post, in the order given.
+func (c *CreatePostInput) PostMedia() []*domain.Media {
+	var res []*domain.Media
+	for i, media := range c.Media {
+		res = append(res, &domain.Media{Id: media.Id, Position: i, AltText: media.AltText})
+	}
+	return res
+}
+
+type PostResponse struct {
+	Id             string            `json:"id"`
+	Body           string            `json:"body"`
+	Author         *PublicUser       `json:"author"`
+	ReplyToId      *string           `json:"reply_to_id,omitempty"`
+	ConversationId string            `json:"conversation_id"`
+	Deleted        bool              `json:"deleted,omitempty"`
+	LikeCount      int64             `json:"like_count"`
+	Entities       []*EntityResponse `json:"entities"`
+	Media          []*MediaResponse  `json:"media"`
+	Poll           *PollResponse     `json:"poll,omitempty"`
+	Quote          *PostResponse     `json:"quote,omitempty"`
+	RepostedBy     *PublicUser       `json:"reposted_by,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+}
+
+type PostListResponse struct {
+	Posts      []*PostResponse `json:"posts"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// NewPostResponse renders a post; a deleted post is rendered as a tombstone
+// that keeps its place in the conversation but reveals neither body nor author.
+// A quote of a deleted post therefore carries a tombstone as its quote, which
+// clients show as "post unavailable". A post the reader may not see because of
+// a block is rendered the same way, so the block is not revealed.
+func NewPostResponse(post *domain.Post) *PostResponse {
+	if post == nil {
+		return nil
+	}
+
+	res := &PostResponse{
+		Id:             post.Id,
+		ReplyToId:      post.ParentId,
+		ConversationId: post.ConversationId,
+		CreatedAt:      post.CreatedAt,
+	}
+	if post.IsDeleted() || post.Unavailable {
+		res.Deleted = true
+		return res
+	}
+
+	res.Body = post.Body
+	res.Author = NewPublicUser(post.Author)
+	res.LikeCount = post.LikeCount
+	res.Entities = NewEntityResponses(post.Entities)
+	res.Media = NewMediaResponses(post.Media)
+	res.Poll = NewPollResponse(post.Poll)
+	res.Quote = NewPostResponse(post.Quote)
+	return res
+}
+
+// NewPostListResponse builds a page of posts. The repository is asked for one
+// post more than the page size; its presence means there is a next page.
+func NewPostListResponse(posts []*domain.Post, limit int) *PostListResponse {
+	res := &PostListResponse{Posts: make([]*PostResponse, 0, len(posts))}
+	if len(posts) > limit {
+		last := posts[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Id})
+		posts = posts[:limit]
+	}
+
+	for _, post := range posts {
+		res.Posts = append(res.Posts, NewPostResponse(post))
+	}
+	return res
+}
+
+// EntityResponse lets clients render links without parsing the body themselves.
+// Start and End are code point offsets into the body, End exclusive.
+type EntityResponse struct {
+	Type   string  `json:"type"`
+	Start  int     `json:"start"`
+	End    int     `json:"end"`
+	Text   string  `json:"text"`
+	UserId *string `json:"user_id,omitempty"`
+}
+
+func NewEntityResponses(entities []*domain.Entity) []*EntityResponse {
+	res := make([]*EntityResponse, 0, len(entities))
+	for _, entity := range entities {
+		res = append(res, &EntityResponse{
+			Type:   string(entity.Type),
+			Start:  entity.Start,
+			End:    entity.End,
+			Text:   entity.Text,
+			UserId: entity.UserId,
+		})
+	}
+	return res
+}
+
+// NewTimelineResponse builds a page of a timeline. Entries are ordered by the
+// time they entered the timeline, which for reposts is the repost time.
+func NewTimelineResponse(entries []*domain.TimelineEntry, limit int) *PostListResponse {
+	res := &PostListResponse{Posts: make([]*PostResponse, 0, len(entries))}
+	if len(entries) > limit {
+		last := entries[limit-1]
+		res.NextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Post.Id})
+		entries = entries[:limit]
+	}
+
+	for _, entry := range entries {
+		post := NewPostResponse(entry.Post)
+		post.RepostedBy = NewPublicUser(entry.RepostedBy)
+		res.Posts = append(res.Posts, post)
+	}
+	return res
+}
+
+type RepostResponse struct {
+	Reposted bool `json:"reposted"`
+}
@@ -0,0 +1,175 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreatePostInput_Sanitize(t *testing.T) {
+	input := CreatePostInput{Body: "  café  "}
+	input.Sanitize()
+	require.Equal(t, CreatePostInput{Body: "café"}, input)
+}
+
+func TestCreatePostInput_Validate(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input CreatePostInput
+		err   error
+	}{
+		{
+			name:  "valid",
+			input: CreatePostInput{Body: "hello world"},
+			err:   nil,
+		},
+		{
+			name:  "empty body",
+			input: CreatePostInput{Body: ""},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "exactly max length",
+			input: CreatePostInput{Body: strings.Repeat("a", PostBodyMaxLength)},
+			err:   nil,
+		},
+		{
+			name:  "too long",
+			input: CreatePostInput{Body: strings.Repeat("a", PostBodyMaxLength+1)},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "family emoji counts as one character",
+			input: CreatePostInput{Body: strings.Repeat("👨‍👩‍👧‍👦", PostBodyMaxLength)},
+			err:   nil,
+		},
+		{
+			name:  "flags count as one character",
+			input: CreatePostInput{Body: strings.Repeat("🇮🇷", PostBodyMaxLength+1)},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "cjk counts one per character",
+			input: CreatePostInput{Body: strings.Repeat("漢", PostBodyMaxLength)},
+			err:   nil,
+		},
+		{
+			name:  "media without body",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: mediaIds[0], AltText: "a cat\non a mat"}}},
+			err:   nil,
+		},
+		{
+			name:  "too many media",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: mediaIds[0]}, {Id: mediaIds[1]}, {Id: mediaIds[2]}, {Id: mediaIds[3]}, {Id: mediaIds[4]}}},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "media attached twice",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: mediaIds[0]}, {Id: mediaIds[0]}}},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "invalid media id",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: "1"}}},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "alt text too long",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: mediaIds[0], AltText: strings.Repeat("a", AltTextMaxLength+1)}}},
+			err:   customErr.ErrValidation,
+		},
+		{
+			name:  "alt text with control characters",
+			input: CreatePostInput{Media: []*AttachMediaInput{{Id: mediaIds[0], AltText: "a\tcat"}}},
+			err:   customErr.ErrValidation,
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(tt *testing.T) {
+			tt.Parallel()
+			err := tc.input.Validate()
+			if tc.err != nil {
+				require.ErrorIs(tt, err, tc.err)
+			} else {
+				require.NoError(tt, err)
+			}
+		})
+	}
+}
+
+var mediaIds = []string{
+	"6ba7b810-9dad-11d1-80b4-00c04fd430c1",
+	"6ba7b810-9dad-11d1-80b4-00c04fd430c2",
+	"6ba7b810-9dad-11d1-80b4-00c04fd430c3",
+	"6ba7b810-9dad-11d1-80b4-00c04fd430c4",
+	"6ba7b810-9dad-11d1-80b4-00c04fd430c5",
+}
+
+func TestCursor(t *testing.T) {
+	cursor := &domain.Cursor{Time: time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC), Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c8"}
+
+	decoded, err := DecodeCursor(EncodeCursor(cursor))
+	require.NoError(t, err)
+	require.Equal(t, cursor, decoded)
+
+	decoded, err = DecodeCursor("")
+	require.NoError(t, err)
+	require.Nil(t, decoded)
+
+	_, err = DecodeCursor("not a cursor")
+	require.ErrorIs(t, err, customErr.ErrValidation)
+}
+
+func TestPageInput_Validate(t *testing.T) {
+	page := PageInput{}
+	page.Sanitize()
+	require.Equal(t, DefaultPageSize, page.Limit)
+	require.NoError(t, page.Validate())
+
+	page = PageInput{Limit: MaxPageSize + 1}
+	require.ErrorIs(t, page.Validate(), customErr.ErrValidation)
+
+	page = PageInput{Limit: 10, Cursor: "%%%"}
+	require.ErrorIs(t, page.Validate(), customErr.ErrValidation)
+}
+
+func TestNewPostListResponse(t *testing.T) {
+	now := time.Now()
+	posts := []*domain.Post{
+		{Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c1", CreatedAt: now},
+		{Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c2", CreatedAt: now.Add(-time.Minute)},
+		{Id: "6ba7b810-9dad-11d1-80b4-00c04fd430c3", CreatedAt: now.Add(-2 * time.Minute)},
+	}
+
+	res := NewPostListResponse(posts, 2)
+	require.Len(t, res.Posts, 2)
+	cursor, err := DecodeCursor(res.NextCursor)
+	require.NoError(t, err)
+	require.Equal(t, posts[1].Id, cursor.Id)
+
+	res = NewPostListResponse(posts, 3)
+	require.Len(t, res.Posts, 3)
+	require.Empty(t, res.NextCursor)
+}
+
+func TestNewPostResponse_QuoteOfDeletedPost(t *testing.T) {
+	deletedAt := time.Now()
+	quoteId := "2"
+	res := NewPostResponse(&domain.Post{
+		Id:      "1",
+		Body:    "look at this",
+		Author:  &domain.User{Username: "bob"},
+		QuoteId: &quoteId,
+		Quote:   &domain.Post{Id: quoteId, Body: "gone", Author: &domain.User{Username: "alice"}, DeletedAt: &deletedAt},
+	})
+
+	require.Equal(t, "look at this", res.Body)
+	require.Equal(t, quoteId, res.Quote.Id)
+	require.True(t, res.Quote.Deleted)
+	require.Empty(t, res.Quote.Body)
+	require.Nil(t, res.Quote.Author)
+}
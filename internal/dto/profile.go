@@ -0,0 +1,154 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/rivo/uniseg"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"golang.org/x/text/unicode/norm"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// The profile text limits are counted in grapheme clusters, like post bodies;
+// URL limits are counted in bytes.
+const (
+	DisplayNameMaxLength = 50
+	BioMaxLength         = 160
+	LocationMaxLength    = 30
+	WebsiteMaxLength     = 100
+	ImageURLMaxLength    = 2048
+)
+
+// UpdateProfileInput changes the fields that are set; an empty string clears a
+// field. Protected switches the account between protected and public.
+type UpdateProfileInput struct {
+	DisplayName *string `json:"display_name"`
+	Bio         *string `json:"bio"`
+	Location    *string `json:"location"`
+	Website     *string `json:"website"`
+	AvatarURL   *string `json:"avatar_url"`
+	BannerURL   *string `json:"banner_url"`
+	Protected   *bool   `json:"protected"`
+}
+
+func (u *UpdateProfileInput) Sanitize() {
+	for _, field := range []*string{u.DisplayName, u.Bio, u.Location, u.Website, u.AvatarURL, u.BannerURL} {
+		if field != nil {
+			*field = norm.NFC.String(strings.TrimSpace(*field))
+		}
+	}
+}
+
+func (u *UpdateProfileInput) Validate() error {
+	texts := []struct {
+		name      string
+		value     *string
+		maxLength int
+		newlines  bool
+	}{
+		{name: "display_name", value: u.DisplayName, maxLength: DisplayNameMaxLength},
+		{name: "bio", value: u.Bio, maxLength: BioMaxLength, newlines: true},
+		{name: "location", value: u.Location, maxLength: LocationMaxLength},
+	}
+	for _, text := range texts {
+		if text.value == nil {
+			continue
+		}
+
+		if length := uniseg.GraphemeClusterCount(*text.value); length > text.maxLength {
+			return fmt.Errorf("%w: %s too long, (%d) character at most, got (%d)", customErr.ErrValidation, text.name, text.maxLength, length)
+		}
+
+		if hasControl(*text.value, text.newlines) {
+			return fmt.Errorf("%w: %s must not contain control characters", customErr.ErrValidation, text.name)
+		}
+	}
+
+	urls := []struct {
+		name      string
+		value     *string
+		maxLength int
+	}{
+		{name: "website", value: u.Website, maxLength: WebsiteMaxLength},
+		{name: "avatar_url", value: u.AvatarURL, maxLength: ImageURLMaxLength},
+		{name: "banner_url", value: u.BannerURL, maxLength: ImageURLMaxLength},
+	}
+	for _, link := range urls {
+		if link.value == nil || *link.value == "" {
+			continue
+		}
+
+		if len(*link.value) > link.maxLength {
+			return fmt.Errorf("%w: %s too long, (%d) bytes at most", customErr.ErrValidation, link.name, link.maxLength)
+		}
+
+		if hasControl(*link.value, false) || !isWebURL(*link.value) {
+			return fmt.Errorf("%w: %s must be an http or https URL", customErr.ErrValidation, link.name)
+		}
+	}
+	return nil
+}
+
+func (u *UpdateProfileInput) ProfileUpdate() *domain.ProfileUpdate {
+	return &domain.ProfileUpdate{
+		DisplayName: u.DisplayName,
+		Bio:         u.Bio,
+		Location:    u.Location,
+		Website:     u.Website,
+		AvatarURL:   u.AvatarURL,
+		BannerURL:   u.BannerURL,
+		Protected:   u.Protected,
+	}
+}
+
+// hasControl reports whether s contains a control character, line feeds aside
+// when newlines is set.
+func hasControl(s string, newlines bool) bool {
+	return strings.ContainsFunc(s, func(r rune) bool {
+		return unicode.IsControl(r) && !(newlines && r == '\n')
+	})
+}
+
+// isWebURL reports whether s is an absolute http or https URL with a host and
+// no credentials.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Hostname() != "" && u.User == nil
+}
+
+// ProfileResponse is a user's public profile with their post and follow counts.
+type ProfileResponse struct {
+	*PublicUser
+	DisplayName    string `json:"display_name"`
+	Bio            string `json:"bio"`
+	Location       string `json:"location"`
+	Website        string `json:"website"`
+	AvatarURL      string `json:"avatar_url"`
+	BannerURL      string `json:"banner_url"`
+	PostCount      int    `json:"post_count"`
+	FollowerCount  int    `json:"follower_count"`
+	FollowingCount int    `json:"following_count"`
+}
+
+func NewProfileResponse(user *domain.User) *ProfileResponse {
+	if user == nil {
+		return nil
+	}
+	return &ProfileResponse{
+		PublicUser:     NewPublicUser(user),
+		DisplayName:    user.DisplayName,
+		Bio:            user.Bio,
+		Location:       user.Location,
+		Website:        user.Website,
+		AvatarURL:      user.AvatarURL,
+		BannerURL:      user.BannerURL,
+		PostCount:      user.PostCount,
+		FollowerCount:  user.FollowerCount,
+		FollowingCount: user.FollowingCount,
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import (
+	"time"
+)
+
+// StreamEvent is one event of the real-time stream. Id is what clients send back
+// as the last event id to resume after reconnecting.
+type StreamEvent struct {
+	Id   string `json:"id"`
+	Type string `json:"type"`
+	Data any    `json:"data,omitempty"`
+}
+
+// StreamNotification announces a new notification; clients refresh the
+// notification list and the unread count from it.
+type StreamNotification struct {
+	Type      string      `json:"type"`
+	Actor     *PublicUser `json:"actor"`
+	PostId    *string     `json:"post_id,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
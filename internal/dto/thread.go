@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+)
+
+const (
+	DefaultThreadDepth = 3
+	MaxThreadDepth     = 10
+	// ThreadChildLimit bounds how many replies are inlined below each nested reply;
+	// the rest are fetched through the node's next cursor.
+	ThreadChildLimit = 3
+)
+
+type ThreadInput struct {
+	PageInput
+	Depth int `json:"depth"`
+}
+
+func (t *ThreadInput) Sanitize() {
+	t.PageInput.Sanitize()
+	if t.Depth == 0 {
+		t.Depth = DefaultThreadDepth
+	}
+}
+
+func (t *ThreadInput) Validate() error {
+	if t.Depth < 1 || t.Depth > MaxThreadDepth {
+		return fmt.Errorf("%w: depth must be between 1 and %d", customErr.ErrValidation, MaxThreadDepth)
+	}
+	return t.PageInput.Validate()
+}
+
+// ReplyNode is a reply with the first replies below it. NextCursor, when set,
+// continues the node's own replies through the replies endpoint of the node.
+type ReplyNode struct {
+	*PostResponse
+	Replies    []*ReplyNode `json:"replies"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type RepliesResponse struct {
+	Replies    []*ReplyNode `json:"replies"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+type ThreadResponse struct {
+	Ancestors  []*PostResponse `json:"ancestors"`
+	Post       *PostResponse   `json:"post"`
+	Replies    []*ReplyNode    `json:"replies"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+}
+
+// NewRepliesResponse assembles the flat rows returned for a reply tree. The
+// repository is asked for one extra row per parent so that the presence of a
+// next page can be told apart from an exactly full one.
+func NewRepliesResponse(parentId string, posts []*domain.Post, limit, childLimit int) *RepliesResponse {
+	children := make(map[string][]*domain.Post)
+	for _, post := range posts {
+		if post.ParentId != nil {
+			children[*post.ParentId] = append(children[*post.ParentId], post)
+		}
+	}
+
+	var build func(parentId string, limit int) ([]*ReplyNode, string)
+	build = func(parentId string, limit int) ([]*ReplyNode, string) {
+		replies := children[parentId]
+		var nextCursor string
+		if len(replies) > limit {
+			last := replies[limit-1]
+			nextCursor = EncodeCursor(&domain.Cursor{Time: last.CreatedAt, Id: last.Id})
+			replies = replies[:limit]
+		}
+
+		nodes := make([]*ReplyNode, 0, len(replies))
+		for _, reply := range replies {
+			node := &ReplyNode{PostResponse: NewPostResponse(reply)}
+			node.Replies, node.NextCursor = build(reply.Id, childLimit)
+			nodes = append(nodes, node)
+		}
+		return nodes, nextCursor
+	}
+
+	res := &RepliesResponse{}
+	res.Replies, res.NextCursor = build(parentId, limit)
+	return res
+}
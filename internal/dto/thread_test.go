@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestThreadInput_Validate(t *testing.T) {
+	input := &ThreadInput{}
+	input.Sanitize()
+	require.NoError(t, input.Validate())
+	require.Equal(t, DefaultThreadDepth, input.Depth)
+	require.Equal(t, DefaultPageSize, input.Limit)
+
+	for _, depth := range []int{-1, MaxThreadDepth + 1} {
+		input := &ThreadInput{Depth: depth}
+		input.Sanitize()
+		require.ErrorIs(t, input.Validate(), customErr.ErrValidation)
+	}
+}
+
+func TestNewPostResponse_Tombstone(t *testing.T) {
+	deletedAt := time.Now()
+	res := NewPostResponse(&domain.Post{
+		Id:             "1",
+		Body:           "secret",
+		ConversationId: "1",
+		Author:         &domain.User{Id: "u", Username: "bob"},
+		DeletedAt:      &deletedAt,
+	})
+
+	require.True(t, res.Deleted)
+	require.Empty(t, res.Body)
+	require.Nil(t, res.Author)
+	require.Equal(t, "1", res.ConversationId)
+}
+
+func TestNewRepliesResponse(t *testing.T) {
+	now := time.Now()
+	reply := func(id, parentId string, offset int) *domain.Post {
+		return &domain.Post{Id: id, ParentId: &parentId, CreatedAt: now.Add(time.Duration(offset) * time.Second)}
+	}
+
+	// Rows as the repository returns them: by level, then time, with one extra
+	// row per parent to signal a further page.
+	posts := []*domain.Post{
+		reply("a", "root", 1),
+		reply("b", "root", 2),
+		reply("c", "root", 3),
+		reply("a1", "a", 4),
+		reply("a2", "a", 5),
+		reply("b1", "b", 6),
+		reply("a11", "a1", 7),
+	}
+
+	res := NewRepliesResponse("root", posts, 2, 1)
+	require.Len(t, res.Replies, 2)
+	require.Equal(t, "a", res.Replies[0].Id)
+	require.Equal(t, "b", res.Replies[1].Id)
+
+	require.Equal(t, EncodeCursor(&domain.Cursor{Time: posts[1].CreatedAt, Id: "b"}), res.NextCursor)
+
+	a := res.Replies[0]
+	require.Len(t, a.Replies, 1)
+	require.Equal(t, "a1", a.Replies[0].Id)
+	require.NotEmpty(t, a.NextCursor)
+	require.Equal(t, "a11", a.Replies[0].Replies[0].Id)
+	require.Empty(t, a.Replies[0].NextCursor)
+
+	b := res.Replies[1]
+	require.Len(t, b.Replies, 1)
+	require.Empty(t, b.NextCursor)
+}
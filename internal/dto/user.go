@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+// The user views below are the only shapes in which a user may be serialized.
+// They deliberately copy fields one by one instead of embedding domain.User, so a
+// credential field added to the domain model can never reach a response.
+
+// PublicUser is what anyone may see about a user.
+type PublicUser struct {
+	Id        string    `json:"id"`
+	Username  string    `json:"username"`
+	Protected bool      `json:"protected,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SelfUser is what an authenticated user sees about their own account.
+type SelfUser struct {
+	Id        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AdminUser is what operators see when managing accounts.
+type AdminUser struct {
+	Id        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewPublicUser(user *domain.User) *PublicUser {
+	if user == nil {
+		return nil
+	}
+	return &PublicUser{
+		Id:        user.Id,
+		Username:  user.Username,
+		Protected: user.Protected,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func NewSelfUser(user *domain.User) *SelfUser {
+	if user == nil {
+		return nil
+	}
+	return &SelfUser{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
+func NewAdminUser(user *domain.User) *AdminUser {
+	if user == nil {
+		return nil
+	}
+	return &AdminUser{
+		Id:        user.Id,
+		Username:  user.Username,
+		Email:     user.Email,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+	}
+}
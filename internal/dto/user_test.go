@@ -0,0 +1,203 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var credentialKeys = []string{"password", "confirm_password", "password_hash", "hash"}
+
+var fullUser = &domain.User{
+	Id:        "123",
+	Username:  "bob",
+	Email:     "bob@gmail.com",
+	Password:  "$2a$04$dYM1yhZJ9X.dCedWx0YG2uNeCMURzBpHR887OFEp2L2BrwqAqz/4m",
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+
+var parentId = "2"
+
+// responses lists a populated value of every type handlers serialize.
+var responses = map[string]any{
+	"PublicUser":             NewPublicUser(fullUser),
+	"SelfUser":               NewSelfUser(fullUser),
+	"AdminUser":              NewAdminUser(fullUser),
+	"AuthenticationResponse": &AuthenticationResponse{AccessToken: "token", User: NewSelfUser(fullUser)},
+	"PostResponse": NewPostResponse(&domain.Post{Id: "1", Body: "hi @bob", Author: fullUser, Entities: []*domain.Entity{
+		{Type: domain.EntityMention, Start: 3, End: 7, Text: "bob", UserId: &fullUser.Id},
+	}}),
+	"MediaResponse": NewMediaResponse(&domain.Media{Id: "1", AltText: "a cat", MediaBlob: domain.MediaBlob{Hash: "ab", Variants: []*domain.MediaVariant{
+		{Name: "original", Key: "media/ab/original.png", URL: "/blobs/media/ab/original.png"},
+	}}}),
+	"PostListResponse": NewPostListResponse([]*domain.Post{{Id: "1", Author: fullUser}}, 1),
+	"ThreadResponse": &ThreadResponse{
+		Ancestors: []*PostResponse{NewPostResponse(&domain.Post{Id: "1", Author: fullUser})},
+		Post:      NewPostResponse(&domain.Post{Id: "2", Author: fullUser}),
+		Replies:   NewRepliesResponse("2", []*domain.Post{{Id: "3", ParentId: &parentId, Author: fullUser}}, 1, 1).Replies,
+	},
+	"UserProfile":          NewUserProfile(fullUser),
+	"ProfileResponse":      NewProfileResponse(fullUser),
+	"FollowListResponse":   NewFollowListResponse([]*domain.Follow{{User: fullUser}}, 1),
+	"RelationshipResponse": NewRelationshipResponse(&domain.Relationship{Following: true}),
+	"LikeResponse":         &LikeResponse{Liked: true, LikeCount: 1},
+	"LikeListResponse":     NewLikeListResponse([]*domain.Like{{User: fullUser}}, 1),
+	"BookmarkedPostListResponse": NewBookmarkedPostListResponse([]*domain.Bookmark{
+		{PostId: "1", Post: &domain.Post{Id: "1", Author: fullUser}},
+	}, 1),
+	"BookmarkResponse": &BookmarkResponse{Bookmarked: true, FolderId: &parentId},
+	"BookmarkFolderListResponse": NewBookmarkFolderListResponse([]*domain.BookmarkFolder{
+		{Id: "1", UserId: "123", Name: "Recipes"},
+	}),
+	"NotificationListResponse": NewNotificationListResponse([]*domain.Notification{
+		{Id: "1", Type: domain.NotificationLike, Actors: []*domain.User{fullUser}, ActorCount: 1},
+	}, 1),
+	"ConversationListResponse": NewConversationListResponse([]*domain.Conversation{{
+		Id:           "1",
+		Participants: []*domain.Participant{{User: fullUser}},
+		LastMessage:  &domain.Message{Id: "2", Sender: fullUser},
+	}}, 1),
+	"MessageListResponse": NewMessageListResponse([]*domain.Message{{Id: "2", Sender: fullUser}}, 1),
+	"ReadReceiptResponse": NewReadReceiptResponse(&domain.ReadReceipt{ConversationId: "1", MessageId: "2"}, fullUser),
+	"BlockListResponse":   NewBlockListResponse([]*domain.Block{{User: fullUser}}, 1),
+	"MutedWordListResponse": NewMutedWordListResponse([]*domain.MutedWord{
+		{Id: "1", Phrase: "spoiler", Scopes: domain.MutedWordScopes},
+	}),
+	"ListResponse":  NewListResponse(&domain.List{Id: "1", OwnerId: fullUser.Id, Owner: fullUser, Name: "Go"}),
+	"ListsResponse": NewListsResponse([]*domain.List{{Id: "1", Owner: fullUser, Name: "Go"}}, 1),
+	"ListUserListResponse": NewListUserListResponse([]*domain.ListMembership{
+		{ListId: "1", UserId: fullUser.Id, User: fullUser},
+	}, 1),
+	"ListSubscriptionResponse": &ListSubscriptionResponse{Subscribed: true},
+	"PollResponse":             NewPollResponse(&domain.Poll{PostId: "1", Options: []*domain.PollOption{{Text: "Yes"}, {Position: 1, Text: "No"}}}),
+	"domain.User":              fullUser,
+}
+
+func collectKeys(t *testing.T, value any, keys map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			keys[key] = struct{}{}
+			collectKeys(t, item, keys)
+		}
+	case []any:
+		for _, item := range v {
+			collectKeys(t, item, keys)
+		}
+	}
+}
+
+func TestResponses_DoNotExposeCredentials(t *testing.T) {
+	for name, response := range responses {
+		t.Run(name, func(t *testing.T) {
+			raw, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.NotContains(t, string(raw), fullUser.Password)
+
+			var generic any
+			require.NoError(t, json.Unmarshal(raw, &generic))
+			keys := map[string]struct{}{}
+			collectKeys(t, generic, keys)
+			for _, key := range credentialKeys {
+				require.NotContains(t, keys, key)
+			}
+		})
+	}
+}
+
+func TestUserViews(t *testing.T) {
+	require.Nil(t, NewPublicUser(nil))
+	require.Equal(t, fullUser.Username, NewPublicUser(fullUser).Username)
+	require.Equal(t, fullUser.Email, NewSelfUser(fullUser).Email)
+	require.Equal(t, fullUser.UpdatedAt, NewAdminUser(fullUser).UpdatedAt)
+}
+
+// TestResponseTypes_DoNotEmbedDomainUser inspects every struct declared in this
+// package. Input types (those with a Validate method) may carry passwords; every
+// other type is serialized to clients and must neither reference domain.User nor
+// declare a credential field.
+func TestResponseTypes_DoNotEmbedDomainUser(t *testing.T) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+
+	inputs := map[string]bool{}
+	structs := map[string]*ast.StructType{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				switch d := decl.(type) {
+				case *ast.FuncDecl:
+					if d.Recv != nil && d.Name.Name == "Validate" {
+						inputs[receiverName(d.Recv.List[0].Type)] = true
+					}
+				case *ast.GenDecl:
+					for _, spec := range d.Specs {
+						if ts, ok := spec.(*ast.TypeSpec); ok {
+							if st, ok := ts.Type.(*ast.StructType); ok {
+								structs[ts.Name.Name] = st
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for name, st := range structs {
+		if inputs[name] || !ast.IsExported(name) {
+			continue
+		}
+		for _, field := range st.Fields.List {
+			typ := types(field.Type)
+			require.NotContains(t, typ, "domain.User", "%s must use a user view instead of domain.User", name)
+
+			if field.Tag == nil {
+				continue
+			}
+			tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("json")
+			key := strings.Split(tag, ",")[0]
+			for _, credential := range credentialKeys {
+				require.NotEqual(t, credential, key, "%s exposes a credential field", name)
+			}
+		}
+	}
+}
+
+func receiverName(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	if ident, ok := expr.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+func types(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return types(e.X)
+	case *ast.ArrayType:
+		return types(e.Elt)
+	case *ast.MapType:
+		return types(e.Key) + " " + types(e.Value)
+	case *ast.SelectorExpr:
+		return types(e.X) + "." + e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	default:
+		return ""
+	}
+}
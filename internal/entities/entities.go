@@ -0,0 +1,140 @@
+// Package entities finds mentions, hashtags and URLs in post text.
+//
+// Offsets count Unicode code points, not bytes, and End is exclusive, so
+// []rune(text)[Start:End] is the entity's text including its @ or # sign.
+package entities
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxMentionLength bounds the username part of a mention.
+	MaxMentionLength = 50
+	// MaxHashtagLength bounds the tag part of a hashtag.
+	MaxHashtagLength = 100
+)
+
+// Extract returns the entities of text ordered by position. URLs are found first
+// and what looks like a mention or hashtag inside a URL is not an entity.
+func Extract(text string) []*domain.Entity {
+	runes := []rune(text)
+	var entities []*domain.Entity
+
+	for i := 0; i < len(runes); {
+		if !boundary(runes, i) {
+			i++
+			continue
+		}
+
+		var entity *domain.Entity
+		switch {
+		case hasURLPrefix(runes[i:]):
+			entity = extractURL(runes, i)
+		case runes[i] == '@' || runes[i] == '＠':
+			entity = extractMention(runes, i)
+		case runes[i] == '#' || runes[i] == '＃':
+			entity = extractHashtag(runes, i)
+		}
+
+		if entity == nil {
+			i++
+			continue
+		}
+		entities = append(entities, entity)
+		i = entity.End
+	}
+	return entities
+}
+
+// boundary reports whether an entity may start at i: entities must not be glued
+// to a preceding word, as in e-mail addresses or "C#".
+func boundary(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev := runes[i-1]
+	return !isWord(prev) && prev != '@' && prev != '＠' && prev != '#' && prev != '＃' && prev != '/'
+}
+
+func isWord(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.M, r)
+}
+
+func isMentionRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+func extractMention(runes []rune, start int) *domain.Entity {
+	end := start + 1
+	for end < len(runes) && isMentionRune(runes[end]) {
+		end++
+	}
+
+	length := end - start - 1
+	if length == 0 || length > MaxMentionLength {
+		return nil
+	}
+	// "@bob@example.com" is neither a mention nor part of one.
+	if end < len(runes) && (runes[end] == '@' || runes[end] == '＠') {
+		return nil
+	}
+	return &domain.Entity{Type: domain.EntityMention, Start: start, End: end, Text: string(runes[start+1 : end])}
+}
+
+func extractHashtag(runes []rune, start int) *domain.Entity {
+	end := start + 1
+	letters := 0
+	for end < len(runes) && isWord(runes[end]) {
+		if unicode.IsLetter(runes[end]) || unicode.Is(unicode.M, runes[end]) {
+			letters++
+		}
+		end++
+	}
+
+	length := end - start - 1
+	// Tags made of digits only, like "#1", are numbering rather than hashtags.
+	if letters == 0 || length > MaxHashtagLength {
+		return nil
+	}
+	return &domain.Entity{Type: domain.EntityHashtag, Start: start, End: end, Text: string(runes[start+1 : end])}
+}
+
+func hasURLPrefix(runes []rune) bool {
+	for _, prefix := range []string{"https://", "http://"} {
+		if len(runes) > len(prefix) && strings.EqualFold(string(runes[:len(prefix)]), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func extractURL(runes []rune, start int) *domain.Entity {
+	end := start
+	for end < len(runes) && !unicode.IsSpace(runes[end]) {
+		end++
+	}
+
+	// Trailing punctuation usually belongs to the sentence, and a closing
+	// parenthesis only belongs to the URL when the URL opened one.
+	for end > start {
+		last := runes[end-1]
+		if strings.ContainsRune(".,:;!?'\"…", last) {
+			end--
+			continue
+		}
+		if last == ')' && strings.Count(string(runes[start:end]), "(") < strings.Count(string(runes[start:end]), ")") {
+			end--
+			continue
+		}
+		break
+	}
+
+	text := string(runes[start:end])
+	if i := strings.Index(text, "://"); i < 0 || len(text) == i+3 {
+		return nil
+	}
+	return &domain.Entity{Type: domain.EntityURL, Start: start, End: end, Text: text}
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	testCases := []struct {
+		name string
+		text string
+		want []domain.Entity
+	}{
+		{
+			name: "mention and hashtag",
+			text: "hi @bob, see #golang",
+			want: []domain.Entity{
+				{Type: domain.EntityMention, Start: 3, End: 7, Text: "bob"},
+				{Type: domain.EntityHashtag, Start: 13, End: 20, Text: "golang"},
+			},
+		},
+		{
+			name: "offsets count code points",
+			text: "👋🏽 @bob",
+			want: []domain.Entity{
+				{Type: domain.EntityMention, Start: 3, End: 7, Text: "bob"},
+			},
+		},
+		{
+			name: "unicode hashtags",
+			text: "#日本語 #café #ＧＯ ＃東京",
+			want: []domain.Entity{
+				{Type: domain.EntityHashtag, Start: 0, End: 4, Text: "日本語"},
+				{Type: domain.EntityHashtag, Start: 5, End: 10, Text: "café"},
+				{Type: domain.EntityHashtag, Start: 11, End: 14, Text: "ＧＯ"},
+				{Type: domain.EntityHashtag, Start: 15, End: 18, Text: "東京"},
+			},
+		},
+		{
+			name: "url with trailing punctuation",
+			text: "read https://example.com/a?b=c#frag. now",
+			want: []domain.Entity{
+				{Type: domain.EntityURL, Start: 5, End: 35, Text: "https://example.com/a?b=c#frag"},
+			},
+		},
+		{
+			name: "url in parentheses",
+			text: "(see https://en.wikipedia.org/wiki/Go_(language))",
+			want: []domain.Entity{
+				{Type: domain.EntityURL, Start: 5, End: 48, Text: "https://en.wikipedia.org/wiki/Go_(language)"},
+			},
+		},
+		{
+			name: "not entities",
+			text: "mail bob@example.com, C# #1 @ # http:// a@@b",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := Extract(tc.text)
+			require.Len(t, got, len(tc.want))
+			for i, entity := range got {
+				require.Equal(t, tc.want[i], *entity)
+				require.Contains(t, string([]rune(tc.text)[entity.Start:entity.End]), entity.Text)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type AuthHandler struct {
+	authService service.AuthService
+	logger      *slog.Logger
+}
+
+func (a *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var input dto.AuthenticationInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, a.logger, err)
+		return
+	}
+
+	res, err := a.authService.Register(r.Context(), &input)
+	if err != nil {
+		writeError(w, r, a.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func (a *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var input dto.Login
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, a.logger, err)
+		return
+	}
+
+	res, err := a.authService.Login(r.Context(), &input)
+	if err != nil {
+		writeError(w, r, a.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewAuthHandler(authService service.AuthService, logger *slog.Logger) *AuthHandler {
+	return &AuthHandler{
+		authService: authService,
+		logger:      logger,
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type BlockHandler struct {
+	blockService service.BlockService
+	logger       *slog.Logger
+}
+
+func (b *BlockHandler) Block(w http.ResponseWriter, r *http.Request) {
+	b.toggle(w, r, b.blockService.Block)
+}
+
+func (b *BlockHandler) Unblock(w http.ResponseWriter, r *http.Request) {
+	b.toggle(w, r, b.blockService.Unblock)
+}
+
+func (b *BlockHandler) Mute(w http.ResponseWriter, r *http.Request) {
+	b.toggle(w, r, b.blockService.Mute)
+}
+
+func (b *BlockHandler) Unmute(w http.ResponseWriter, r *http.Request) {
+	b.toggle(w, r, b.blockService.Unmute)
+}
+
+func (b *BlockHandler) toggle(w http.ResponseWriter, r *http.Request, toggle func(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)) {
+	res, err := toggle(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BlockHandler) Blocked(w http.ResponseWriter, r *http.Request) {
+	b.list(w, r, b.blockService.ListBlocked)
+}
+
+func (b *BlockHandler) Muted(w http.ResponseWriter, r *http.Request) {
+	b.list(w, r, b.blockService.ListMuted)
+}
+
+func (b *BlockHandler) list(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userId string, input *dto.PageInput) (*dto.BlockListResponse, error)) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	res, err := list(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewBlockHandler(blockService service.BlockService, logger *slog.Logger) *BlockHandler {
+	return &BlockHandler{
+		blockService: blockService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type BookmarkHandler struct {
+	bookmarkService service.BookmarkService
+	logger          *slog.Logger
+}
+
+// Bookmark takes an optional body naming the folder to file the bookmark in.
+func (b *BookmarkHandler) Bookmark(w http.ResponseWriter, r *http.Request) {
+	var input dto.BookmarkInput
+	if r.ContentLength != 0 {
+		if err := readJSON(w, r, &input); err != nil {
+			writeError(w, r, b.logger, err)
+			return
+		}
+	}
+
+	res, err := b.bookmarkService.Bookmark(r.Context(), userId(r), r.PathValue("id"), &input)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) Unbookmark(w http.ResponseWriter, r *http.Request) {
+	res, err := b.bookmarkService.Unbookmark(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	res, err := b.bookmarkService.List(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) ListFolder(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	res, err := b.bookmarkService.ListFolder(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	var input dto.BookmarkFolderInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	res, err := b.bookmarkService.CreateFolder(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func (b *BookmarkHandler) ListFolders(w http.ResponseWriter, r *http.Request) {
+	res, err := b.bookmarkService.ListFolders(r.Context(), userId(r))
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) RenameFolder(w http.ResponseWriter, r *http.Request) {
+	var input dto.BookmarkFolderInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	res, err := b.bookmarkService.RenameFolder(r.Context(), userId(r), r.PathValue("id"), &input)
+	if err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (b *BookmarkHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	if err := b.bookmarkService.DeleteFolder(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, b.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func NewBookmarkHandler(bookmarkService service.BookmarkService, logger *slog.Logger) *BookmarkHandler {
+	return &BookmarkHandler{
+		bookmarkService: bookmarkService,
+		logger:          logger,
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type FollowHandler struct {
+	followService service.FollowService
+	logger        *slog.Logger
+}
+
+func (f *FollowHandler) Follow(w http.ResponseWriter, r *http.Request) {
+	res, err := f.followService.Follow(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Unfollow(w http.ResponseWriter, r *http.Request) {
+	res, err := f.followService.Unfollow(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Relationship(w http.ResponseWriter, r *http.Request) {
+	res, err := f.followService.GetRelationship(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Requests(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	res, err := f.followService.ListRequests(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	res, err := f.followService.Approve(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	res, err := f.followService.Reject(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *FollowHandler) Followers(w http.ResponseWriter, r *http.Request) {
+	f.list(w, r, f.followService.ListFollowers)
+}
+
+func (f *FollowHandler) Following(w http.ResponseWriter, r *http.Request) {
+	f.list(w, r, f.followService.ListFollowing)
+}
+
+func (f *FollowHandler) Mutuals(w http.ResponseWriter, r *http.Request) {
+	f.list(w, r, f.followService.ListMutuals)
+}
+
+func (f *FollowHandler) list(w http.ResponseWriter, r *http.Request, list func(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error)) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	res, err := list(r.Context(), userId(r), r.PathValue("username"), page)
+	if err != nil {
+		writeError(w, r, f.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewFollowHandler(followService service.FollowService, logger *slog.Logger) *FollowHandler {
+	return &FollowHandler{
+		followService: followService,
+		logger:        logger,
+	}
+}
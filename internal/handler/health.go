@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/health"
+	"net/http"
+)
+
+type HealthHandler struct {
+	readiness *health.Readiness
+	registry  *health.Registry
+	build     health.Build
+}
+
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Reason string            `json:"reason,omitempty"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+type healthResponse struct {
+	health.Report
+	Ready bool         `json:"ready"`
+	Build health.Build `json:"build"`
+}
+
+// Liveness only reports that the process is able to serve requests; it never
+// looks at dependencies so a database outage does not get the instance restarted.
+func (h *HealthHandler) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, readinessResponse{Status: "alive"})
+}
+
+// Readiness reports whether startup has completed and every critical check passes.
+func (h *HealthHandler) Readiness(w http.ResponseWriter, r *http.Request) {
+	ready, reason := h.readiness.State()
+	if !ready {
+		writeJSON(w, http.StatusServiceUnavailable, readinessResponse{Status: "not ready", Reason: reason})
+		return
+	}
+
+	report := h.registry.Run(r.Context(), true)
+	checks := make(map[string]string, len(report.Checks))
+	for name, result := range report.Checks {
+		checks[name] = string(result.Status)
+	}
+
+	if report.Status != health.StatusUp {
+		writeJSON(w, http.StatusServiceUnavailable, readinessResponse{Status: "not ready", Reason: "critical check failing", Checks: checks})
+		return
+	}
+	writeJSON(w, http.StatusOK, readinessResponse{Status: "ready", Checks: checks})
+}
+
+// Health runs every registered check and reports their details along with build info.
+func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
+	ready, _ := h.readiness.State()
+	res := healthResponse{
+		Report: h.registry.Run(r.Context(), false),
+		Ready:  ready,
+		Build:  h.build,
+	}
+
+	status := http.StatusOK
+	if res.Status != health.StatusUp {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, res)
+}
+
+func NewHealthHandler(readiness *health.Readiness, registry *health.Registry) *HealthHandler {
+	return &HealthHandler{
+		readiness: readiness,
+		registry:  registry,
+		build:     health.ReadBuild(),
+	}
+}
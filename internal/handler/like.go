@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type LikeHandler struct {
+	likeService service.LikeService
+	logger      *slog.Logger
+}
+
+func (l *LikeHandler) Like(w http.ResponseWriter, r *http.Request) {
+	res, err := l.likeService.Like(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *LikeHandler) Unlike(w http.ResponseWriter, r *http.Request) {
+	res, err := l.likeService.Unlike(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *LikeHandler) LikedBy(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.likeService.ListLikedBy(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *LikeHandler) LikedPosts(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.likeService.ListLikedPosts(r.Context(), userId(r), r.PathValue("username"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewLikeHandler(likeService service.LikeService, logger *slog.Logger) *LikeHandler {
+	return &LikeHandler{
+		likeService: likeService,
+		logger:      logger,
+	}
+}
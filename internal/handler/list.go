@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type ListHandler struct {
+	listService service.ListService
+	logger      *slog.Logger
+}
+
+func (l *ListHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateListInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.Create(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func (l *ListHandler) Get(w http.ResponseWriter, r *http.Request) {
+	res, err := l.listService.Get(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Update(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdateListInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.Update(r.Context(), userId(r), r.PathValue("id"), &input)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := l.listService.Delete(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (l *ListHandler) Owned(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.ListOwned(r.Context(), userId(r), r.PathValue("username"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Subscribed(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.ListSubscribed(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	res, err := l.listService.AddMember(r.Context(), userId(r), r.PathValue("id"), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	res, err := l.listService.RemoveMember(r.Context(), userId(r), r.PathValue("id"), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Members(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.ListMembers(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	res, err := l.listService.Subscribe(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	res, err := l.listService.Unsubscribe(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Subscribers(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.ListSubscribers(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (l *ListHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	res, err := l.listService.Timeline(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, l.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewListHandler(listService service.ListService, logger *slog.Logger) *ListHandler {
+	return &ListHandler{
+		listService: listService,
+		logger:      logger,
+	}
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+)
+
+type MediaHandler struct {
+	mediaService service.MediaService
+	logger       *slog.Logger
+}
+
+// Upload takes the image either as the raw request body or as the "file" field
+// of a multipart form. The service enforces the size limit and sniffs the type,
+// so the Content-Type the client sent for the image is not trusted.
+func (m *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	body, err := uploadBody(r)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.mediaService.Upload(r.Context(), userId(r), body)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func uploadBody(r *http.Request) (io.Reader, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return r.Body, nil
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed multipart body", customErr.ErrValidation)
+	}
+	for {
+		part, err := reader.NextPart()
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil, fmt.Errorf("%w: file field required", customErr.ErrValidation)
+		case err != nil:
+			return nil, fmt.Errorf("%w: malformed multipart body", customErr.ErrValidation)
+		case part.FormName() == "file":
+			return part, nil
+		}
+	}
+}
+
+func NewMediaHandler(mediaService service.MediaService, logger *slog.Logger) *MediaHandler {
+	return &MediaHandler{
+		mediaService: mediaService,
+		logger:       logger,
+	}
+}
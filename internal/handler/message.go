@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type MessageHandler struct {
+	messageService service.MessageService
+	logger         *slog.Logger
+}
+
+// CreateConversation answers 200 rather than 201, as starting a one-to-one
+// conversation again returns the existing one.
+func (m *MessageHandler) CreateConversation(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreateConversationInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.messageService.CreateConversation(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MessageHandler) ListConversations(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.messageService.ListConversations(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MessageHandler) GetConversation(w http.ResponseWriter, r *http.Request) {
+	res, err := m.messageService.GetConversation(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MessageHandler) DeleteConversation(w http.ResponseWriter, r *http.Request) {
+	if err := m.messageService.DeleteConversation(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *MessageHandler) Send(w http.ResponseWriter, r *http.Request) {
+	var input dto.SendMessageInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.messageService.Send(r.Context(), userId(r), r.PathValue("id"), &input)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+// ListMessages pages backward through the history, newest message first.
+func (m *MessageHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.messageService.ListMessages(r.Context(), userId(r), r.PathValue("id"), page)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MessageHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	if err := m.messageService.MarkRead(r.Context(), userId(r), r.PathValue("id"), r.PathValue("message_id")); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (m *MessageHandler) Settings(w http.ResponseWriter, r *http.Request) {
+	res, err := m.messageService.GetSettings(r.Context(), userId(r))
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MessageHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var input dto.MessageSettings
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.messageService.UpdateSettings(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewMessageHandler(messageService service.MessageService, logger *slog.Logger) *MessageHandler {
+	return &MessageHandler{
+		messageService: messageService,
+		logger:         logger,
+	}
+}
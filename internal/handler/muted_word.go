@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type MutedWordHandler struct {
+	mutedWordService service.MutedWordService
+	logger           *slog.Logger
+}
+
+func (m *MutedWordHandler) Mute(w http.ResponseWriter, r *http.Request) {
+	var input dto.MuteWordInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	res, err := m.mutedWordService.Mute(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MutedWordHandler) List(w http.ResponseWriter, r *http.Request) {
+	res, err := m.mutedWordService.List(r.Context(), userId(r))
+	if err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (m *MutedWordHandler) Unmute(w http.ResponseWriter, r *http.Request) {
+	if err := m.mutedWordService.Unmute(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, m.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func NewMutedWordHandler(mutedWordService service.MutedWordService, logger *slog.Logger) *MutedWordHandler {
+	return &MutedWordHandler{
+		mutedWordService: mutedWordService,
+		logger:           logger,
+	}
+}
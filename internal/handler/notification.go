@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type NotificationHandler struct {
+	notificationService service.NotificationService
+	logger              *slog.Logger
+}
+
+func (n *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	res, err := n.notificationService.List(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (n *NotificationHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	res, err := n.notificationService.UnreadCount(r.Context(), userId(r))
+	if err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (n *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	if err := n.notificationService.MarkRead(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (n *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	if err := n.notificationService.MarkAllRead(r.Context(), userId(r)); err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (n *NotificationHandler) Preferences(w http.ResponseWriter, r *http.Request) {
+	res, err := n.notificationService.GetPreferences(r.Context(), userId(r))
+	if err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (n *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var input dto.NotificationPreferences
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	res, err := n.notificationService.UpdatePreferences(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, n.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewNotificationHandler(notificationService service.NotificationService, logger *slog.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type PollHandler struct {
+	pollService service.PollService
+	logger      *slog.Logger
+}
+
+func (p *PollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	var input dto.VoteInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	res, err := p.pollService.Vote(r.Context(), userId(r), r.PathValue("id"), &input)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewPollHandler(pollService service.PollService, logger *slog.Logger) *PollHandler {
+	return &PollHandler{
+		pollService: pollService,
+		logger:      logger,
+	}
+}
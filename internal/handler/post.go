@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type PostHandler struct {
+	postService service.PostService
+	logger      *slog.Logger
+}
+
+func (p *PostHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input dto.CreatePostInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	res, err := p.postService.Create(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, res)
+}
+
+func (p *PostHandler) Get(w http.ResponseWriter, r *http.Request) {
+	res, err := p.postService.GetById(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (p *PostHandler) ListByAuthor(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	res, err := p.postService.ListByAuthor(r.Context(), userId(r), r.PathValue("username"), page)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (p *PostHandler) Thread(w http.ResponseWriter, r *http.Request) {
+	input, err := readThread(r)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	res, err := p.postService.GetThread(r.Context(), userId(r), r.PathValue("id"), input)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (p *PostHandler) Replies(w http.ResponseWriter, r *http.Request) {
+	input, err := readThread(r)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	res, err := p.postService.ListReplies(r.Context(), userId(r), r.PathValue("id"), input)
+	if err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (p *PostHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	if err := p.postService.Delete(r.Context(), userId(r), r.PathValue("id")); err != nil {
+		writeError(w, r, p.logger, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func NewPostHandler(postService service.PostService, logger *slog.Logger) *PostHandler {
+	return &PostHandler{
+		postService: postService,
+		logger:      logger,
+	}
+}
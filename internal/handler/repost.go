@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type RepostHandler struct {
+	repostService service.RepostService
+	logger        *slog.Logger
+}
+
+func (rp *RepostHandler) Repost(w http.ResponseWriter, r *http.Request) {
+	res, err := rp.repostService.Repost(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, rp.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (rp *RepostHandler) Unrepost(w http.ResponseWriter, r *http.Request) {
+	res, err := rp.repostService.Unrepost(r.Context(), userId(r), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, rp.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewRepostHandler(repostService service.RepostService, logger *slog.Logger) *RepostHandler {
+	return &RepostHandler{
+		repostService: repostService,
+		logger:        logger,
+	}
+}
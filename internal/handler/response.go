@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/requestctx"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+)
+
+const maxBodyBytes = 1 << 20
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v == nil {
+		return
+	}
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError maps domain errors onto HTTP status codes. Unexpected errors are
+// logged and reported as a generic internal server error.
+func writeError(w http.ResponseWriter, r *http.Request, logger *slog.Logger, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, customErr.ErrValidation), errors.Is(err, customErr.ErrSelfFollow):
+		status = http.StatusBadRequest
+	case errors.Is(err, customErr.ErrBadCredential), errors.Is(err, customErr.ErrUnauthorized):
+		status = http.StatusUnauthorized
+	case errors.Is(err, customErr.ErrForbidden):
+		status = http.StatusForbidden
+	case errors.Is(err, customErr.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, customErr.ErrUserDeleted):
+		status = http.StatusGone
+	case errors.Is(err, customErr.ErrUserNameTaken), errors.Is(err, customErr.ErrEmailTaken), errors.Is(err, customErr.ErrFolderTaken),
+		errors.Is(err, customErr.ErrAlreadyVoted):
+		status = http.StatusConflict
+	}
+
+	if status == http.StatusInternalServerError {
+		logger.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "err", err.Error())
+		writeJSON(w, status, errorResponse{Error: http.StatusText(status)})
+		return
+	}
+
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, dst any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		return fmt.Errorf("%w: invalid request body: %v", customErr.ErrValidation, err)
+	}
+
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: request body must contain a single JSON object", customErr.ErrValidation)
+	}
+	return nil
+}
+
+// readPage reads the cursor and limit query parameters.
+func readPage(r *http.Request) (*dto.PageInput, error) {
+	page := &dto.PageInput{Cursor: r.URL.Query().Get("cursor")}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: limit must be a number", customErr.ErrValidation)
+		}
+		page.Limit = n
+	}
+	return page, nil
+}
+
+func readThread(r *http.Request) (*dto.ThreadInput, error) {
+	page, err := readPage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &dto.ThreadInput{PageInput: *page}
+	if depth := r.URL.Query().Get("depth"); depth != "" {
+		n, err := strconv.Atoi(depth)
+		if err != nil {
+			return nil, fmt.Errorf("%w: depth must be a number", customErr.ErrValidation)
+		}
+		input.Depth = n
+	}
+	return input, nil
+}
+
+// userId returns the authenticated user; routes using it must be wrapped with middleware.Authenticate.
+func userId(r *http.Request) string {
+	id, _ := requestctx.UserID(r.Context())
+	return id
+}
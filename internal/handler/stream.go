@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type StreamHandler struct {
+	streamService  service.StreamService
+	writeTimeout   time.Duration
+	allowedOrigins []string
+	logger         *slog.Logger
+}
+
+type StreamHandlerOptions func(*StreamHandler)
+
+// WithStreamWriteTimeout sets how long a client may take to accept an event
+// before it is disconnected as too slow.
+func WithStreamWriteTimeout(timeout time.Duration) StreamHandlerOptions {
+	return func(s *StreamHandler) {
+		s.writeTimeout = timeout
+	}
+}
+
+// WithStreamAllowedOrigins lists the host patterns browsers may open WebSockets
+// from besides the API's own host.
+func WithStreamAllowedOrigins(origins []string) StreamHandlerOptions {
+	return func(s *StreamHandler) {
+		s.allowedOrigins = origins
+	}
+}
+
+// Events streams over Server-Sent Events. EventSource sends the Last-Event-ID
+// header by itself when reconnecting.
+func (s *StreamHandler) Events(w http.ResponseWriter, r *http.Request) {
+	lastEventId := r.Header.Get("Last-Event-ID")
+	if lastEventId == "" {
+		lastEventId = r.URL.Query().Get("last_event_id")
+	}
+
+	subscription, err := s.streamService.Subscribe(r.Context(), userId(r), lastEventId)
+	if err != nil {
+		writeError(w, r, s.logger, err)
+		return
+	}
+	defer subscription.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	writer := &sseWriter{w: w, controller: http.NewResponseController(w), timeout: s.writeTimeout}
+	if err := writer.flush(); err != nil {
+		return
+	}
+	s.serve(r.Context(), subscription, writer)
+}
+
+// WebSocket streams over a WebSocket; clients resume with the last_event_id
+// query parameter.
+func (s *StreamHandler) WebSocket(w http.ResponseWriter, r *http.Request) {
+	subscription, err := s.streamService.Subscribe(r.Context(), userId(r), r.URL.Query().Get("last_event_id"))
+	if err != nil {
+		writeError(w, r, s.logger, err)
+		return
+	}
+	defer subscription.Close()
+
+	// The server's read and write timeouts are deadlines on the connection that
+	// would otherwise outlive the upgrade.
+	controller := http.NewResponseController(w)
+	_ = controller.SetReadDeadline(time.Time{})
+	_ = controller.SetWriteDeadline(time.Time{})
+
+	conn, err := websocket.Accept(w, r, &websocket.AcceptOptions{OriginPatterns: s.allowedOrigins})
+	if err != nil {
+		return
+	}
+	defer conn.CloseNow()
+
+	// Clients only listen; reading in the background answers pings and notices
+	// the client going away, which cancels ctx.
+	ctx := conn.CloseRead(context.WithoutCancel(r.Context()))
+	s.serve(ctx, subscription, &wsWriter{conn: conn, timeout: s.writeTimeout})
+	conn.Close(websocket.StatusGoingAway, "stream closed")
+}
+
+func (s *StreamHandler) serve(ctx context.Context, subscription *service.Subscription, writer service.StreamWriter) {
+	if err := subscription.Serve(ctx, writer); err != nil && ctx.Err() == nil {
+		s.logger.WarnContext(ctx, "stream closed", "err", err.Error())
+	}
+}
+
+type sseWriter struct {
+	w          http.ResponseWriter
+	controller *http.ResponseController
+	timeout    time.Duration
+}
+
+func (s *sseWriter) WriteEvent(_ context.Context, event *dto.StreamEvent) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+
+	if err := s.controller.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(s.w, "id: %s\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// Heartbeat writes a comment, which keeps proxies from closing an idle stream.
+func (s *sseWriter) Heartbeat(context.Context) error {
+	if err := s.controller.SetWriteDeadline(time.Now().Add(s.timeout)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(s.w, ": ping\n\n"); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+func (s *sseWriter) flush() error {
+	return s.controller.Flush()
+}
+
+type wsWriter struct {
+	conn    *websocket.Conn
+	timeout time.Duration
+}
+
+func (w *wsWriter) WriteEvent(ctx context.Context, event *dto.StreamEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	return wsjson.Write(ctx, w.conn, event)
+}
+
+func (w *wsWriter) Heartbeat(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+	return w.conn.Ping(ctx)
+}
+
+func NewStreamHandler(streamService service.StreamService, logger *slog.Logger, opts ...StreamHandlerOptions) *StreamHandler {
+	s := &StreamHandler{
+		streamService: streamService,
+		writeTimeout:  10 * time.Second,
+		logger:        logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type TimelineHandler struct {
+	timelineService service.TimelineService
+	logger          *slog.Logger
+}
+
+func (t *TimelineHandler) Home(w http.ResponseWriter, r *http.Request) {
+	page, err := readPage(r)
+	if err != nil {
+		writeError(w, r, t.logger, err)
+		return
+	}
+
+	res, err := t.timelineService.Home(r.Context(), userId(r), page)
+	if err != nil {
+		writeError(w, r, t.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewTimelineHandler(timelineService service.TimelineService, logger *slog.Logger) *TimelineHandler {
+	return &TimelineHandler{
+		timelineService: timelineService,
+		logger:          logger,
+	}
+}
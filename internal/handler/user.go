@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"log/slog"
+	"net/http"
+)
+
+type UserHandler struct {
+	userService service.UserService
+	logger      *slog.Logger
+}
+
+func (u *UserHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	res, err := u.userService.GetProfile(r.Context(), userId(r), r.PathValue("username"))
+	if err != nil {
+		writeError(w, r, u.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (u *UserHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	var input dto.UpdateProfileInput
+	if err := readJSON(w, r, &input); err != nil {
+		writeError(w, r, u.logger, err)
+		return
+	}
+
+	res, err := u.userService.UpdateProfile(r.Context(), userId(r), &input)
+	if err != nil {
+		writeError(w, r, u.logger, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func NewUserHandler(userService service.UserService, logger *slog.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		logger:      logger,
+	}
+}
@@ -0,0 +1,38 @@
+package health
+
+import (
+	"runtime/debug"
+)
+
+// Version is set at build time with -ldflags "-X github.com/saleh-ghazimoradi/X/internal/health.Version=...".
+var Version = "dev"
+
+type Build struct {
+	Version   string `json:"version"`
+	Revision  string `json:"revision,omitempty"`
+	Time      string `json:"time,omitempty"`
+	Modified  bool   `json:"modified,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+func ReadBuild() Build {
+	build := Build{Version: Version}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return build
+	}
+
+	build.GoVersion = info.GoVersion
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			build.Revision = setting.Value
+		case "vcs.time":
+			build.Time = setting.Value
+		case "vcs.modified":
+			build.Modified = setting.Value == "true"
+		}
+	}
+	return build
+}
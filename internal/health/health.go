@@ -0,0 +1,148 @@
+package health
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Checker reports the state of a single dependency. The returned details are
+// included in the detailed health report; a non-nil error marks the check down.
+type Checker interface {
+	Check(ctx context.Context) (any, error)
+}
+
+type CheckerFunc func(ctx context.Context) (any, error)
+
+func (f CheckerFunc) Check(ctx context.Context) (any, error) {
+	return f(ctx)
+}
+
+type Result struct {
+	Status   Status `json:"status"`
+	Critical bool   `json:"critical"`
+	Duration string `json:"duration"`
+	Details  any    `json:"details,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status Status            `json:"status"`
+	Checks map[string]Result `json:"checks"`
+}
+
+type check struct {
+	checker  Checker
+	critical bool
+}
+
+// Registry holds the checks of every subsystem. Subsystems register their own
+// checks; critical ones decide readiness while the rest are only reported.
+type Registry struct {
+	mu      sync.RWMutex
+	checks  map[string]check
+	timeout time.Duration
+}
+
+func (r *Registry) Register(name string, checker Checker, critical bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check{checker: checker, critical: critical}
+}
+
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, name)
+}
+
+// Names returns the registered check names in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run executes the registered checks concurrently, each bounded by the registry
+// timeout. When criticalOnly is set the non-critical checks are skipped. The
+// report is down as soon as any critical check fails.
+func (r *Registry) Run(ctx context.Context, criticalOnly bool) Report {
+	r.mu.RLock()
+	checks := make(map[string]check, len(r.checks))
+	for name, c := range r.checks {
+		if criticalOnly && !c.critical {
+			continue
+		}
+		checks[name] = c
+	}
+	r.mu.RUnlock()
+
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(checks)),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for name, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := r.run(ctx, c)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status == StatusDown && c.critical {
+				report.Status = StatusDown
+			}
+		}()
+	}
+	wg.Wait()
+
+	return report
+}
+
+func (r *Registry) run(ctx context.Context, c check) Result {
+	if r.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	details, err := c.checker.Check(ctx)
+	result := Result{
+		Status:   StatusUp,
+		Critical: c.critical,
+		Duration: time.Since(start).String(),
+		Details:  details,
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func NewRegistry(timeout time.Duration) *Registry {
+	return &Registry{
+		checks:  make(map[string]check),
+		timeout: timeout,
+	}
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func up(details any) Checker {
+	return CheckerFunc(func(ctx context.Context) (any, error) {
+		return details, nil
+	})
+}
+
+func down(err error) Checker {
+	return CheckerFunc(func(ctx context.Context) (any, error) {
+		return nil, err
+	})
+}
+
+func TestRegistry_Run(t *testing.T) {
+	t.Run("all up", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(time.Second)
+		registry.Register("postgresql", up("stats"), true)
+		registry.Register("mailer", up(nil), false)
+
+		report := registry.Run(context.Background(), false)
+		require.Equal(t, StatusUp, report.Status)
+		require.Len(t, report.Checks, 2)
+		require.Equal(t, "stats", report.Checks["postgresql"].Details)
+	})
+
+	t.Run("non critical failure keeps report up", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(time.Second)
+		registry.Register("postgresql", up(nil), true)
+		registry.Register("mailer", down(errors.New("smtp unreachable")), false)
+
+		report := registry.Run(context.Background(), false)
+		require.Equal(t, StatusUp, report.Status)
+		require.Equal(t, StatusDown, report.Checks["mailer"].Status)
+		require.Equal(t, "smtp unreachable", report.Checks["mailer"].Error)
+	})
+
+	t.Run("critical failure marks report down", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(time.Second)
+		registry.Register("postgresql", down(errors.New("refused")), true)
+
+		report := registry.Run(context.Background(), false)
+		require.Equal(t, StatusDown, report.Status)
+	})
+
+	t.Run("critical only skips other checks", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(time.Second)
+		registry.Register("postgresql", up(nil), true)
+		registry.Register("mailer", up(nil), false)
+
+		report := registry.Run(context.Background(), true)
+		require.Len(t, report.Checks, 1)
+		require.Contains(t, report.Checks, "postgresql")
+	})
+
+	t.Run("checks are bounded by the timeout", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(10 * time.Millisecond)
+		registry.Register("slow", CheckerFunc(func(ctx context.Context) (any, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}), true)
+
+		report := registry.Run(context.Background(), false)
+		require.Equal(t, StatusDown, report.Status)
+	})
+
+	t.Run("unregister", func(t *testing.T) {
+		t.Parallel()
+		registry := NewRegistry(time.Second)
+		registry.Register("cache", up(nil), false)
+		registry.Register("postgresql", up(nil), true)
+		registry.Unregister("cache")
+		require.Equal(t, []string{"postgresql"}, registry.Names())
+	})
+}
+
+func versioner(version uint, dirty bool) MigrationVersioner {
+	return func(context.Context) (uint, bool, error) {
+		return version, dirty, nil
+	}
+}
+
+func TestMigrationChecker(t *testing.T) {
+	details, err := MigrationChecker(versioner(3, false)).Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, MigrationDetails{Version: 3}, details)
+
+	_, err = MigrationChecker(versioner(3, true)).Check(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,30 @@
+package health
+
+import (
+	"context"
+	"errors"
+)
+
+// MigrationVersioner reads the applied schema version and whether it is dirty.
+type MigrationVersioner func(ctx context.Context) (uint, bool, error)
+
+type MigrationDetails struct {
+	Version uint `json:"version"`
+	Dirty   bool `json:"dirty"`
+}
+
+// MigrationChecker reports the applied schema version and fails on a dirty schema.
+func MigrationChecker(versioner MigrationVersioner) Checker {
+	return CheckerFunc(func(ctx context.Context) (any, error) {
+		version, dirty, err := versioner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		details := MigrationDetails{Version: version, Dirty: dirty}
+		if dirty {
+			return details, errors.New("schema is dirty")
+		}
+		return details, nil
+	})
+}
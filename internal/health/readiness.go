@@ -0,0 +1,40 @@
+package health
+
+import (
+	"sync"
+)
+
+// Readiness tracks whether the instance can take traffic. It starts out not ready
+// and is flipped once startup dependencies are available.
+type Readiness struct {
+	mu     sync.RWMutex
+	ready  bool
+	reason string
+}
+
+func (r *Readiness) SetReady() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = true
+	r.reason = ""
+}
+
+func (r *Readiness) SetNotReady(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ready = false
+	r.reason = reason
+}
+
+// State reports whether the instance is ready and, if not, why.
+func (r *Readiness) State() (bool, string) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ready, r.reason
+}
+
+func NewReadiness(reason string) *Readiness {
+	return &Readiness{
+		reason: reason,
+	}
+}
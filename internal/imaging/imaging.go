@@ -0,0 +1,210 @@
+// Package imaging turns uploaded images into the renditions that are stored and
+// served: a clean original without metadata plus smaller variants, and a
+// blurhash placeholder clients show while the image loads.
+//
+// Originals are decoded and encoded again, which drops EXIF, XMP and every
+// other embedded metadata; the EXIF orientation of a JPEG is applied to the
+// pixels first so the image keeps facing the right way. GIFs are re-encoded
+// with all their frames, which keeps the animation but leaves out comments and
+// application extensions other than the loop count, where XMP is stored.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"golang.org/x/image/draw"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	// MaxPixels bounds the decoded size of an image, so a small file cannot
+	// expand into gigabytes of pixels.
+	MaxPixels = 40_000_000
+
+	jpegQuality = 90
+)
+
+// ErrUnsupported is returned for data that is not an image this package
+// accepts.
+var ErrUnsupported = errors.New("unsupported image")
+
+// sizes are the variants made besides the original, by name and the length of
+// their longest side. Images are never scaled up.
+var sizes = []struct {
+	name    string
+	longest int
+}{
+	{name: "small", longest: 680},
+	{name: "thumb", longest: 150},
+}
+
+// Variant is an encoded rendition of an image.
+type Variant struct {
+	Name        string
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Image is a processed upload. Variants starts with the original, named
+// "original", followed by the smaller variants.
+type Image struct {
+	ContentType string
+	Width       int
+	Height      int
+	Blurhash    string
+	Variants    []*Variant
+}
+
+// Sniff returns the content type of data from its leading bytes, whatever the
+// client claimed, and whether it is accepted.
+func Sniff(data []byte) (string, bool) {
+	switch contentType := http.DetectContentType(data); contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return contentType, true
+	default:
+		return contentType, false
+	}
+}
+
+// Process decodes data and renders its original and variants.
+func Process(data []byte) (*Image, error) {
+	contentType, ok := Sniff(data)
+	if !ok {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and GIF images are accepted, got %s", ErrUnsupported, contentType)
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: image of %dx%d pixels, %d pixels at most", ErrUnsupported, config.Width, config.Height, MaxPixels)
+	}
+
+	var (
+		img      image.Image
+		original *Variant
+	)
+	switch contentType {
+	case "image/jpeg":
+		decoded, err := jpeg.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+		img = orient(decoded, jpegOrientation(data))
+		if original, err = encode("original", contentType, img); err != nil {
+			return nil, err
+		}
+	case "image/png":
+		decoded, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+		img = decoded
+		if original, err = encode("original", contentType, img); err != nil {
+			return nil, err
+		}
+	case "image/gif":
+		decoded, err := gif.DecodeAll(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+		if pixels := framePixels(decoded); pixels > MaxPixels {
+			return nil, fmt.Errorf("%w: animation of %d pixels, %d pixels at most", ErrUnsupported, pixels, MaxPixels)
+		}
+		// The variants are made of the first frame.
+		img = decoded.Image[0]
+		if original, err = encodeGIF(decoded); err != nil {
+			return nil, err
+		}
+	}
+
+	res := &Image{
+		ContentType: contentType,
+		Width:       original.Width,
+		Height:      original.Height,
+		Variants:    []*Variant{original},
+	}
+
+	// Variants of a GIF are still images of its first frame, in PNG so they keep
+	// their transparency.
+	variantType := contentType
+	if variantType == "image/gif" {
+		variantType = "image/png"
+	}
+	for _, size := range sizes {
+		variant, err := encode(size.name, variantType, fit(img, size.longest))
+		if err != nil {
+			return nil, err
+		}
+		res.Variants = append(res.Variants, variant)
+	}
+
+	res.Blurhash = Blurhash(fit(img, 32), 4, 3)
+	return res, nil
+}
+
+// fit scales img down so its longest side is at most longest pixels.
+func fit(img image.Image, longest int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= longest && height <= longest {
+		return img
+	}
+
+	if width >= height {
+		width, height = longest, max(1, height*longest/width)
+	} else {
+		width, height = max(1, width*longest/height), longest
+	}
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+func encode(name, contentType string, img image.Image) (*Variant, error) {
+	var (
+		buf bytes.Buffer
+		ext string
+		err error
+	)
+	switch contentType {
+	case "image/jpeg":
+		ext = ".jpg"
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		contentType, ext = "image/png", ".png"
+		err = png.Encode(&buf, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s variant: %w", name, err)
+	}
+
+	bounds := img.Bounds()
+	return &Variant{Name: name, ContentType: contentType, Ext: ext, Width: bounds.Dx(), Height: bounds.Dy(), Data: buf.Bytes()}, nil
+}
+
+// framePixels returns the number of pixels of all the frames of an animation.
+func framePixels(anim *gif.GIF) int {
+	pixels := 0
+	for _, frame := range anim.Image {
+		pixels += frame.Bounds().Dx() * frame.Bounds().Dy()
+	}
+	return pixels
+}
+
+func encodeGIF(anim *gif.GIF) (*Variant, error) {
+	var buf bytes.Buffer
+	if err := gif.EncodeAll(&buf, anim); err != nil {
+		return nil, fmt.Errorf("error encoding original variant: %w", err)
+	}
+	return &Variant{Name: "original", ContentType: "image/gif", Ext: ".gif", Width: anim.Config.Width, Height: anim.Config.Height, Data: buf.Bytes()}, nil
+}
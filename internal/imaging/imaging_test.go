@@ -0,0 +1,199 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/stretchr/testify/require"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+// gradient returns an image whose top left corner is red and whose other
+// pixels are blue, so its orientation can be told after processing.
+func gradient(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.NRGBA{B: 255, A: 255})
+		}
+	}
+	for y := 0; y < height/4; y++ {
+		for x := 0; x < width/4; x++ {
+			img.Set(x, y, color.NRGBA{R: 255, A: 255})
+		}
+	}
+	return img
+}
+
+// withExif inserts an EXIF segment holding the orientation and a GPS marker
+// right after the JPEG start of image.
+func withExif(t *testing.T, data []byte, orientation uint16) []byte {
+	t.Helper()
+	var tiff bytes.Buffer
+	tiff.WriteString("MM")
+	binary.Write(&tiff, binary.BigEndian, uint16(42))
+	binary.Write(&tiff, binary.BigEndian, uint32(8))
+	binary.Write(&tiff, binary.BigEndian, uint16(1))
+	binary.Write(&tiff, binary.BigEndian, []uint16{exifOrientationTag, 3})
+	binary.Write(&tiff, binary.BigEndian, uint32(1))
+	binary.Write(&tiff, binary.BigEndian, []uint16{orientation, 0})
+	binary.Write(&tiff, binary.BigEndian, uint32(0))
+	tiff.WriteString("GPS 35.6892N 51.3890E")
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	header := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(segment)+2))
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, header...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestProcess_JPEG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, gradient(800, 400), nil))
+	data := withExif(t, buf.Bytes(), 6)
+	require.Equal(t, 6, jpegOrientation(data))
+
+	res, err := Process(data)
+	require.NoError(t, err)
+	require.Equal(t, "image/jpeg", res.ContentType)
+	require.Equal(t, 400, res.Width, "turned upright")
+	require.Equal(t, 800, res.Height)
+	require.Len(t, res.Blurhash, 28)
+
+	original := res.Variants[0]
+	require.False(t, bytes.Contains(original.Data, []byte("Exif")), "metadata stripped")
+	require.False(t, bytes.Contains(original.Data, []byte("GPS")))
+	require.Equal(t, 1, jpegOrientation(original.Data))
+
+	// The red corner was top left before turning clockwise, so it is top right.
+	img, err := jpeg.Decode(bytes.NewReader(original.Data))
+	require.NoError(t, err)
+	r, _, b, _ := img.At(390, 10).RGBA()
+	require.Greater(t, r, b)
+	r, _, b, _ = img.At(10, 10).RGBA()
+	require.Greater(t, b, r)
+
+	var sizes []string
+	for _, variant := range res.Variants {
+		sizes = append(sizes, variant.Name)
+		require.Equal(t, "image/jpeg", variant.ContentType)
+	}
+	require.Equal(t, []string{"original", "small", "thumb"}, sizes)
+	require.Equal(t, [2]int{340, 680}, [2]int{res.Variants[1].Width, res.Variants[1].Height})
+	require.Equal(t, [2]int{75, 150}, [2]int{res.Variants[2].Width, res.Variants[2].Height})
+}
+
+func TestProcess_PNG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, gradient(100, 50)))
+
+	res, err := Process(buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, "image/png", res.ContentType)
+	for _, variant := range res.Variants {
+		require.Equal(t, [2]int{100, 50}, [2]int{variant.Width, variant.Height}, "never scaled up")
+	}
+}
+
+// withGIFMetadata inserts a comment and an XMP application extension right
+// before the GIF trailer.
+func withGIFMetadata(data []byte) []byte {
+	var ext bytes.Buffer
+	ext.Write([]byte{0x21, 0xFE})
+	comment := "GPS 35.6892N 51.3890E"
+	ext.WriteByte(byte(len(comment)))
+	ext.WriteString(comment)
+	ext.WriteByte(0)
+
+	ext.Write([]byte{0x21, 0xFF, 11})
+	ext.WriteString("XMP DataXMP")
+	xmp := `<x:xmpmeta xmlns:x="adobe:ns:meta/">Exif</x:xmpmeta>`
+	ext.WriteByte(byte(len(xmp)))
+	ext.WriteString(xmp)
+	ext.WriteByte(0)
+
+	out := append([]byte{}, data[:len(data)-1]...)
+	out = append(out, ext.Bytes()...)
+	return append(out, data[len(data)-1:]...)
+}
+
+func TestProcess_GIF(t *testing.T) {
+	palette := color.Palette{color.Black, color.White}
+	frame := image.NewPaletted(image.Rect(0, 0, 300, 200), palette)
+	var buf bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&buf, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}))
+	data := withGIFMetadata(buf.Bytes())
+	require.True(t, bytes.Contains(data, []byte("xmpmeta")))
+
+	res, err := Process(data)
+	require.NoError(t, err)
+	require.Equal(t, "image/png", res.Variants[1].ContentType)
+	require.Equal(t, 150, res.Variants[2].Width)
+
+	original := res.Variants[0]
+	require.Equal(t, [2]int{300, 200}, [2]int{original.Width, original.Height})
+	require.False(t, bytes.Contains(original.Data, []byte("XMP")), "metadata stripped")
+	require.False(t, bytes.Contains(original.Data, []byte("xmpmeta")))
+	require.False(t, bytes.Contains(original.Data, []byte("GPS")))
+
+	anim, err := gif.DecodeAll(bytes.NewReader(original.Data))
+	require.NoError(t, err)
+	require.Len(t, anim.Image, 2, "animation kept")
+	require.Equal(t, []int{10, 10}, anim.Delay)
+}
+
+func TestProcess_Rejected(t *testing.T) {
+	testCases := map[string][]byte{
+		"text":      []byte("<html>not an image</html>"),
+		"truncated": {0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F'},
+	}
+
+	// A PNG header claiming more pixels than allowed.
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewGray(image.Rect(0, 0, 1, 1))))
+	huge := buf.Bytes()
+	binary.BigEndian.PutUint32(huge[16:], 100_000)
+	binary.BigEndian.PutUint32(huge[20:], 100_000)
+	binary.BigEndian.PutUint32(huge[29:], crc32.ChecksumIEEE(huge[12:29]))
+	testCases["too many pixels"] = huge
+
+	// An animation whose frames add up to more pixels than allowed.
+	frame := image.NewPaletted(image.Rect(0, 0, 5000, 5000), color.Palette{color.Black, color.White})
+	var anim bytes.Buffer
+	require.NoError(t, gif.EncodeAll(&anim, &gif.GIF{Image: []*image.Paletted{frame, frame}, Delay: []int{10, 10}}))
+	testCases["too many frame pixels"] = anim.Bytes()
+
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Process(data)
+			require.ErrorIs(t, err, ErrUnsupported)
+		})
+	}
+}
+
+func TestBlurhash_SolidColor(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	for i := range 64 {
+		img.Set(i%8, i/8, color.NRGBA{R: 255, A: 255})
+	}
+
+	hash := Blurhash(img, 4, 3)
+	require.Len(t, hash, 28)
+	require.Equal(t, "L", hash[:1], "4x3 components")
+
+	// The DC component encodes the average color.
+	dc := 0
+	for _, c := range hash[2:6] {
+		dc = dc*83 + strings.IndexRune(base83Chars, c)
+	}
+	require.Equal(t, 0xFF0000, dc)
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/requestctx"
+	"go.opentelemetry.io/otel/trace"
+	"log/slog"
+)
+
+// contextHandler adds the request, user and trace IDs carried by the context to
+// every record logged through the *Context methods.
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if requestID, ok := requestctx.RequestID(ctx); ok {
+		record.AddAttrs(slog.String("request_id", requestID))
+	}
+
+	if userID, ok := requestctx.UserID(ctx); ok {
+		record.AddAttrs(slog.String("user_id", userID))
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		record.AddAttrs(
+			slog.String("trace_id", spanContext.TraceID().String()),
+			slog.String("span_id", spanContext.SpanID().String()),
+		)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
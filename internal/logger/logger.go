@@ -0,0 +1,100 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+type Logger struct {
+	Level      string
+	Format     string
+	Output     io.Writer
+	HashEmails bool
+	RedactKeys []string
+}
+
+type Options func(*Logger)
+
+func WithLevel(level string) Options {
+	return func(l *Logger) {
+		l.Level = level
+	}
+}
+
+func WithFormat(format string) Options {
+	return func(l *Logger) {
+		l.Format = format
+	}
+}
+
+func WithOutput(output io.Writer) Options {
+	return func(l *Logger) {
+		l.Output = output
+	}
+}
+
+// WithHashEmails replaces email addresses with a stable hash instead of logging them in clear.
+func WithHashEmails(hash bool) Options {
+	return func(l *Logger) {
+		l.HashEmails = hash
+	}
+}
+
+// WithRedactKeys adds attribute or field names whose values must never be logged,
+// on top of the built-in credential names.
+func WithRedactKeys(keys ...string) Options {
+	return func(l *Logger) {
+		l.RedactKeys = append(l.RedactKeys, keys...)
+	}
+}
+
+func (l *Logger) level() (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(l.Level)); err != nil {
+		return level, fmt.Errorf("invalid log level %q: %w", l.Level, err)
+	}
+	return level, nil
+}
+
+// New builds a slog.Logger whose records carry the request, user and trace IDs
+// found in the context and never contain credentials.
+func New(opts ...Options) (*slog.Logger, error) {
+	l := &Logger{
+		Level:  "info",
+		Format: FormatJSON,
+		Output: os.Stdout,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	level, err := l.level()
+	if err != nil {
+		return nil, err
+	}
+
+	handlerOpts := &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: newRedactor(l.HashEmails, l.RedactKeys).replaceAttr,
+	}
+
+	var handler slog.Handler
+	switch strings.ToLower(l.Format) {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(l.Output, handlerOpts)
+	case FormatText:
+		handler = slog.NewTextHandler(l.Output, handlerOpts)
+	default:
+		return nil, fmt.Errorf("invalid log format %q", l.Format)
+	}
+
+	return slog.New(&contextHandler{Handler: handler}), nil
+}
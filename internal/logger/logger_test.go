@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/requestctx"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(WithLevel("verbose"))
+	require.Error(t, err)
+
+	_, err = New(WithFormat("xml"))
+	require.Error(t, err)
+
+	_, err = New(WithLevel("debug"), WithFormat("text"))
+	require.NoError(t, err)
+}
+
+func TestLogger_ContextIDs(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(WithOutput(&buf))
+	require.NoError(t, err)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  spanID,
+	}))
+	ctx = requestctx.WithRequestID(ctx, "req-1")
+	ctx = requestctx.WithUserID(ctx, "user-1")
+
+	log.InfoContext(ctx, "hello")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "req-1", record["request_id"])
+	require.Equal(t, "user-1", record["user_id"])
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", record["trace_id"])
+	require.Equal(t, "00f067aa0ba902b7", record["span_id"])
+}
+
+func TestLogger_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(WithOutput(&buf), WithHashEmails(true))
+	require.NoError(t, err)
+
+	log.Info("register",
+		"input", &dto.AuthenticationInput{
+			Email:           "bob@gmail.com",
+			Username:        "bob",
+			Password:        "password",
+			ConfirmPassword: "password",
+		},
+		"users", []domain.User{{Id: "1", Email: "bob@gmail.com", Password: "$2a$hash"}},
+		"response", dto.AuthenticationResponse{AccessToken: "secret-token"},
+		"password", "password",
+		"email", "bob@gmail.com",
+	)
+
+	line := buf.String()
+	require.NotContains(t, line, "bob@gmail.com")
+	require.NotContains(t, line, `"password":"password"`)
+	require.NotContains(t, line, "$2a$hash")
+	require.NotContains(t, line, "secret-token")
+	require.Equal(t, 4, strings.Count(line, redacted))
+	require.Equal(t, 3, strings.Count(line, hashEmail("bob@gmail.com")))
+	require.Contains(t, line, `"username":"bob"`)
+}
+
+func TestLogger_EmailsInClear(t *testing.T) {
+	var buf bytes.Buffer
+	log, err := New(WithOutput(&buf))
+	require.NoError(t, err)
+
+	log.Info("login failed", "email", "bob@gmail.com")
+	require.Contains(t, buf.String(), "bob@gmail.com")
+}
@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var sensitiveKeys = []string{
+	"password",
+	"confirm_password",
+	"token",
+	"access_token",
+	"refresh_token",
+	"authorization",
+	"secret",
+	"api_key",
+}
+
+type redactor struct {
+	keys       map[string]struct{}
+	hashEmails bool
+}
+
+func (r *redactor) sensitive(key string) bool {
+	_, ok := r.keys[strings.ToLower(key)]
+	return ok
+}
+
+func (r *redactor) email(key string) bool {
+	return r.hashEmails && strings.EqualFold(key, "email")
+}
+
+func (r *redactor) replaceAttr(_ []string, attr slog.Attr) slog.Attr {
+	switch {
+	case r.sensitive(attr.Key):
+		return slog.String(attr.Key, redacted)
+	case r.email(attr.Key) && attr.Value.Kind() == slog.KindString:
+		return slog.String(attr.Key, hashEmail(attr.Value.String()))
+	case attr.Value.Kind() == slog.KindAny:
+		if value, ok := r.redactValue(attr.Value.Any()); ok {
+			return slog.Any(attr.Key, value)
+		}
+	}
+	return attr
+}
+
+// redactValue scrubs structs, maps and slices such as domain.User or dto inputs.
+// They are converted through their JSON form so the field names matched are the
+// same ones that would otherwise end up in the log line.
+func (r *redactor) redactValue(value any) (any, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	if _, ok := value.(error); ok {
+		return nil, false
+	}
+
+	switch reflect.Indirect(reflect.ValueOf(value)).Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+	default:
+		return nil, false
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return redacted, true
+	}
+
+	var generic any
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return redacted, true
+	}
+	return r.walk(generic), true
+}
+
+func (r *redactor) walk(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			switch {
+			case r.sensitive(key):
+				v[key] = redacted
+			case r.email(key):
+				if s, ok := item.(string); ok {
+					v[key] = hashEmail(s)
+				}
+			default:
+				v[key] = r.walk(item)
+			}
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = r.walk(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func hashEmail(email string) string {
+	if email == "" {
+		return email
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(email)))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+func newRedactor(hashEmails bool, extra []string) *redactor {
+	keys := make(map[string]struct{}, len(sensitiveKeys)+len(extra))
+	for _, key := range slices.Concat(sensitiveKeys, extra) {
+		keys[strings.ToLower(key)] = struct{}{}
+	}
+	return &redactor{
+		keys:       keys,
+		hashEmails: hashEmails,
+	}
+}
@@ -0,0 +1,32 @@
+package metrics
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+)
+
+type authService struct {
+	next    service.AuthService
+	metrics *Metrics
+}
+
+func (a *authService) Register(ctx context.Context, input *dto.AuthenticationInput) (*dto.AuthenticationResponse, error) {
+	res, err := a.next.Register(ctx, input)
+	a.metrics.ObserveAuth("register", err)
+	return res, err
+}
+
+func (a *authService) Login(ctx context.Context, input *dto.Login) (*dto.AuthenticationResponse, error) {
+	res, err := a.next.Login(ctx, input)
+	a.metrics.ObserveAuth("login", err)
+	return res, err
+}
+
+// NewAuthService decorates next so that every registration and login outcome is counted.
+func NewAuthService(next service.AuthService, metrics *Metrics) service.AuthService {
+	return &authService{
+		next:    next,
+		metrics: metrics,
+	}
+}
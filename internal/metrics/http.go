@@ -0,0 +1,27 @@
+package metrics
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Middleware records request counts and latencies. Requests are labelled with the
+// ServeMux pattern that matched rather than the raw path to keep cardinality bounded,
+// which requires the mux to be wrapped with middleware.CaptureRoute.
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		m.httpInFlight.Inc()
+		defer m.httpInFlight.Dec()
+
+		r, route := middleware.TrackRoute(r)
+		rec := middleware.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		status := strconv.Itoa(rec.Status)
+		m.httpRequests.WithLabelValues(r.Method, route(), status).Inc()
+		m.httpDuration.WithLabelValues(r.Method, route(), status).Observe(time.Since(start).Seconds())
+	})
+}
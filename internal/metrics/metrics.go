@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"net/http"
+	"time"
+)
+
+const namespace = "x"
+
+type Metrics struct {
+	registry           *prometheus.Registry
+	httpRequests       *prometheus.CounterVec
+	httpDuration       *prometheus.HistogramVec
+	httpInFlight       prometheus.Gauge
+	authEvents         *prometheus.CounterVec
+	passwordHash       prometheus.Histogram
+	repositoryDuration *prometheus.HistogramVec
+}
+
+// RegisterDB exposes the connection pool statistics of db labelled with name.
+func (m *Metrics) RegisterDB(name string, db *sql.DB) error {
+	return m.registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Register adds a collector owned by another subsystem.
+func (m *Metrics) Register(collector prometheus.Collector) error {
+	return m.registry.Register(collector)
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObservePasswordHash(duration time.Duration) {
+	m.passwordHash.Observe(duration.Seconds())
+}
+
+func (m *Metrics) ObserveRepository(repository, method string, start time.Time, err error) {
+	m.repositoryDuration.WithLabelValues(repository, method, outcome(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveAuth counts a registration or login by outcome. Accounts are never
+// locked out after failed logins, so there is no lockout outcome: repeated
+// failures are all counted as bad_credential.
+func (m *Metrics) ObserveAuth(event string, err error) {
+	m.authEvents.WithLabelValues(event, outcome(err)).Inc()
+}
+
+// outcome turns an error into a low-cardinality label value.
+func outcome(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, customErr.ErrValidation):
+		return "validation_error"
+	case errors.Is(err, customErr.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, customErr.ErrUserNameTaken):
+		return "username_taken"
+	case errors.Is(err, customErr.ErrEmailTaken):
+		return "email_taken"
+	case errors.Is(err, customErr.ErrBadCredential):
+		return "bad_credential"
+	case errors.Is(err, customErr.ErrUserDeleted):
+		return "user_deleted"
+	default:
+		return "error"
+	}
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "Number of HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		httpInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_in_flight",
+			Help:      "Number of HTTP requests currently being served.",
+		}),
+		authEvents: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "events_total",
+			Help:      "Authentication attempts by event and outcome.",
+		}, []string{"event", "outcome"}),
+		passwordHash: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "password_hash_duration_seconds",
+			Help:      "Time spent hashing and comparing passwords with bcrypt.",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1},
+		}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "repository",
+			Name:      "query_duration_seconds",
+			Help:      "Repository method latency by repository, method and outcome.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 3},
+		}, []string{"repository", "method", "outcome"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.httpInFlight,
+		m.authEvents,
+		m.passwordHash,
+		m.repositoryDuration,
+	)
+	return m
+}
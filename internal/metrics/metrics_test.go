@@ -0,0 +1,47 @@
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	m := New()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/users/{username}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	handler := m.Middleware(middleware.CaptureRoute(mux))
+
+	for _, path := range []string{"/v1/users/bob", "/v1/users/alice", "/nope"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	require.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues(http.MethodGet, "GET /v1/users/{username}", "404")))
+	require.Equal(t, 1.0, testutil.ToFloat64(m.httpRequests.WithLabelValues(http.MethodGet, "unmatched", "404")))
+}
+
+func TestMetrics_UserRepository(t *testing.T) {
+	m := New()
+	userRepository := &mocks.UserRepositoryMock{}
+	userRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, customErr.ErrNotFound)
+
+	_, err := NewUserRepository(userRepository, m).GetByEmail(context.Background(), "bob@gmail.com")
+	require.ErrorIs(t, err, customErr.ErrNotFound)
+	require.Equal(t, 1, testutil.CollectAndCount(m.repositoryDuration, "x_repository_query_duration_seconds"))
+	userRepository.AssertExpectations(t)
+}
+
+func TestOutcome(t *testing.T) {
+	require.Equal(t, "success", outcome(nil))
+	require.Equal(t, "username_taken", outcome(customErr.ErrUserNameTaken))
+	require.Equal(t, "bad_credential", outcome(customErr.ErrBadCredential))
+}
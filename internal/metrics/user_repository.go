@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"time"
+)
+
+type userRepository struct {
+	next    repository.UserRepository
+	metrics *Metrics
+}
+
+func (u *userRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.Create(ctx, user)
+	u.metrics.ObserveRepository("user", "Create", start, err)
+	return user, err
+}
+
+func (u *userRepository) GetById(ctx context.Context, id string) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.GetById(ctx, id)
+	u.metrics.ObserveRepository("user", "GetById", start, err)
+	return user, err
+}
+
+func (u *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.GetByUsername(ctx, username)
+	u.metrics.ObserveRepository("user", "GetByUsername", start, err)
+	return user, err
+}
+
+func (u *userRepository) GetProfile(ctx context.Context, viewerId, username string) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.GetProfile(ctx, viewerId, username)
+	u.metrics.ObserveRepository("user", "GetProfile", start, err)
+	return user, err
+}
+
+func (u *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.GetByEmail(ctx, email)
+	u.metrics.ObserveRepository("user", "GetByEmail", start, err)
+	return user, err
+}
+
+func (u *userRepository) UpdateProfile(ctx context.Context, id string, update *domain.ProfileUpdate) (*domain.User, error) {
+	start := time.Now()
+	user, err := u.next.UpdateProfile(ctx, id, update)
+	u.metrics.ObserveRepository("user", "UpdateProfile", start, err)
+	return user, err
+}
+
+// NewUserRepository decorates next so that every method call is timed.
+func NewUserRepository(next repository.UserRepository, metrics *Metrics) repository.UserRepository {
+	return &userRepository{
+		next:    next,
+		metrics: metrics,
+	}
+}
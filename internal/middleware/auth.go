@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/requestctx"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+	"net/http"
+	"strings"
+)
+
+// Authenticate rejects requests without a valid bearer access token and stores
+// the id of the authenticated user in the request context.
+func Authenticate(tokenService service.TokenService) func(http.Handler) http.Handler {
+	return authenticate(tokenService, false)
+}
+
+// AuthenticateQuery is Authenticate for clients that cannot set headers, such as
+// EventSource and browser WebSockets: the access token may also be passed in the
+// access_token query parameter.
+func AuthenticateQuery(tokenService service.TokenService) func(http.Handler) http.Handler {
+	return authenticate(tokenService, true)
+}
+
+func authenticate(tokenService service.TokenService, query bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok && query {
+				scheme, token, ok = "Bearer", r.URL.Query().Get("access_token"), true
+			}
+			if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				unauthorized(w)
+				return
+			}
+
+			userId, err := tokenService.ParseAccessToken(strings.TrimSpace(token))
+			if err != nil {
+				unauthorized(w)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(requestctx.WithUserID(r.Context(), userId)))
+		})
+	}
+}
+
+// RequireToken rejects requests whose bearer token is not the given static
+// token, such as scrapers of internal endpoints.
+func RequireToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			scheme, presented, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(presented)), []byte(token)) != 1 {
+				unauthorized(w)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer realm="x"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": customErr.ErrUnauthorized.Error()})
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Logger writes one access log record per request.
+func Logger(logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			r, route := TrackRoute(r)
+			rec := NewStatusRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			level := slog.LevelInfo
+			if rec.Status >= http.StatusInternalServerError {
+				level = slog.LevelError
+			}
+			logger.Log(r.Context(), level, "http request",
+				"method", r.Method,
+				"route", route(),
+				"path", r.URL.Path,
+				"status", rec.Status,
+				"duration", time.Since(start),
+				"remote_addr", r.RemoteAddr,
+			)
+		})
+	}
+}
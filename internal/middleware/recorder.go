@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+)
+
+// StatusRecorder captures the status code written by the wrapped handler.
+type StatusRecorder struct {
+	http.ResponseWriter
+	Status int
+}
+
+func (s *StatusRecorder) WriteHeader(status int) {
+	s.Status = status
+	s.ResponseWriter.WriteHeader(status)
+}
+
+func (s *StatusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *StatusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+func NewStatusRecorder(w http.ResponseWriter) *StatusRecorder {
+	if rec, ok := w.(*StatusRecorder); ok {
+		return rec
+	}
+	return &StatusRecorder{ResponseWriter: w, Status: http.StatusOK}
+}
+
+type routeKey struct{}
+
+// TrackRoute prepares r so that the ServeMux pattern matched further down the chain
+// can be read back by the calling middleware once the request has been served.
+// It is needed because the mux only sets the pattern on its own copy of the request.
+func TrackRoute(r *http.Request) (*http.Request, func() string) {
+	holder, ok := r.Context().Value(routeKey{}).(*string)
+	if !ok {
+		holder = new(string)
+		r = r.WithContext(context.WithValue(r.Context(), routeKey{}, holder))
+	}
+
+	return r, func() string {
+		if *holder == "" {
+			return "unmatched"
+		}
+		return *holder
+	}
+}
+
+// CaptureRoute must wrap the ServeMux directly; it publishes the matched pattern
+// to the middlewares that called TrackRoute.
+func CaptureRoute(mux http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mux.ServeHTTP(w, r)
+		if holder, ok := r.Context().Value(routeKey{}).(*string); ok {
+			*holder = r.Pattern
+		}
+	})
+}
@@ -174,6 +174,74 @@ func (_c *UserRepositoryMock_GetByEmail_Call) RunAndReturn(run func(ctx context.
 	return _c
 }
 
+// GetById provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) GetById(ctx context.Context, id string) (*domain.User, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetById")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *domain.User); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_GetById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetById'
+type UserRepositoryMock_GetById_Call struct {
+	*mock.Call
+}
+
+// GetById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *UserRepositoryMock_Expecter) GetById(ctx interface{}, id interface{}) *UserRepositoryMock_GetById_Call {
+	return &UserRepositoryMock_GetById_Call{Call: _e.mock.On("GetById", ctx, id)}
+}
+
+func (_c *UserRepositoryMock_GetById_Call) Run(run func(ctx context.Context, id string)) *UserRepositoryMock_GetById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetById_Call) Return(user *domain.User, err error) *UserRepositoryMock_GetById_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetById_Call) RunAndReturn(run func(ctx context.Context, id string) (*domain.User, error)) *UserRepositoryMock_GetById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByUsername provides a mock function for the type UserRepositoryMock
 func (_mock *UserRepositoryMock) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
 	ret := _mock.Called(ctx, username)
@@ -241,3 +309,151 @@ func (_c *UserRepositoryMock_GetByUsername_Call) RunAndReturn(run func(ctx conte
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetProfile provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) GetProfile(ctx context.Context, viewerId string, username string) (*domain.User, error) {
+	ret := _mock.Called(ctx, viewerId, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProfile")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*domain.User, error)); ok {
+		return returnFunc(ctx, viewerId, username)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *domain.User); ok {
+		r0 = returnFunc(ctx, viewerId, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, viewerId, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_GetProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProfile'
+type UserRepositoryMock_GetProfile_Call struct {
+	*mock.Call
+}
+
+// GetProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - viewerId string
+//   - username string
+func (_e *UserRepositoryMock_Expecter) GetProfile(ctx interface{}, viewerId interface{}, username interface{}) *UserRepositoryMock_GetProfile_Call {
+	return &UserRepositoryMock_GetProfile_Call{Call: _e.mock.On("GetProfile", ctx, viewerId, username)}
+}
+
+func (_c *UserRepositoryMock_GetProfile_Call) Run(run func(ctx context.Context, viewerId string, username string)) *UserRepositoryMock_GetProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetProfile_Call) Return(user *domain.User, err error) *UserRepositoryMock_GetProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_GetProfile_Call) RunAndReturn(run func(ctx context.Context, viewerId string, username string) (*domain.User, error)) *UserRepositoryMock_GetProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateProfile provides a mock function for the type UserRepositoryMock
+func (_mock *UserRepositoryMock) UpdateProfile(ctx context.Context, id string, update *domain.ProfileUpdate) (*domain.User, error) {
+	ret := _mock.Called(ctx, id, update)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateProfile")
+	}
+
+	var r0 *domain.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.ProfileUpdate) (*domain.User, error)); ok {
+		return returnFunc(ctx, id, update)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *domain.ProfileUpdate) *domain.User); ok {
+		r0 = returnFunc(ctx, id, update)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *domain.ProfileUpdate) error); ok {
+		r1 = returnFunc(ctx, id, update)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepositoryMock_UpdateProfile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProfile'
+type UserRepositoryMock_UpdateProfile_Call struct {
+	*mock.Call
+}
+
+// UpdateProfile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - update *domain.ProfileUpdate
+func (_e *UserRepositoryMock_Expecter) UpdateProfile(ctx interface{}, id interface{}, update interface{}) *UserRepositoryMock_UpdateProfile_Call {
+	return &UserRepositoryMock_UpdateProfile_Call{Call: _e.mock.On("UpdateProfile", ctx, id, update)}
+}
+
+func (_c *UserRepositoryMock_UpdateProfile_Call) Run(run func(ctx context.Context, id string, update *domain.ProfileUpdate)) *UserRepositoryMock_UpdateProfile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *domain.ProfileUpdate
+		if args[2] != nil {
+			arg2 = args[2].(*domain.ProfileUpdate)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepositoryMock_UpdateProfile_Call) Return(user *domain.User, err error) *UserRepositoryMock_UpdateProfile_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *UserRepositoryMock_UpdateProfile_Call) RunAndReturn(run func(ctx context.Context, id string, update *domain.ProfileUpdate) (*domain.User, error)) *UserRepositoryMock_UpdateProfile_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type BlockRepository interface {
+	Block(ctx context.Context, userId, targetId string) (bool, error)
+	Unblock(ctx context.Context, userId, targetId string) (bool, error)
+	Mute(ctx context.Context, userId, targetId string) (bool, error)
+	Unmute(ctx context.Context, userId, targetId string) (bool, error)
+	Blocked(ctx context.Context, userId, otherId string) (bool, error)
+	ListBlocked(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error)
+	ListMuted(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error)
+}
+
+type blockRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// Block records the block and, in the same transaction, removes the follows
+// between both accounts with their counts, each account from the lists and the
+// notifications of the other and the bookmarks the blocked account has of the
+// user's posts. It reports whether the block was new; blocking twice is a no-op.
+func (b *blockRepository) Block(ctx context.Context, userId, targetId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := b.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// Locking both users serializes the block with follows between them.
+	if err := lockUsers(ctx, tx, userId, targetId); err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO blocks (blocker_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userId, targetId)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if created == 0 {
+		return false, tx.Commit()
+	}
+
+	if err := removeFollows(ctx, tx, userId, targetId); err != nil {
+		return false, err
+	}
+
+	if err := removeListMemberships(ctx, tx, userId, targetId); err != nil {
+		return false, err
+	}
+
+	actors := `WITH removed AS (
+			DELETE FROM notification_actors na USING notifications n
+			WHERE na.notification_id = n.id
+			AND ((n.user_id = $1 AND na.actor_id = $2) OR (n.user_id = $2 AND na.actor_id = $1))
+			RETURNING na.notification_id
+		)
+		UPDATE notifications SET actor_count = actor_count - r.count
+		FROM (SELECT notification_id, COUNT(*) AS count FROM removed GROUP BY notification_id) r
+		WHERE notifications.id = r.notification_id`
+	if _, err := tx.ExecContext(ctx, actors, userId, targetId); err != nil {
+		return false, err
+	}
+
+	bookmarks := `DELETE FROM bookmarks b USING posts p WHERE b.post_id = p.id AND b.user_id = $2 AND p.author_id = $1`
+	if _, err := tx.ExecContext(ctx, bookmarks, userId, targetId); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// removeFollows deletes the follows between two users in both directions and
+// adjusts the counts of the ones that existed. Pending requests to follow are
+// dropped as well.
+func removeFollows(ctx context.Context, tx *sql.Tx, userId, otherId string) error {
+	requests := `DELETE FROM follow_requests
+		WHERE (requester_id = $1 AND target_id = $2) OR (requester_id = $2 AND target_id = $1)`
+	if _, err := tx.ExecContext(ctx, requests, userId, otherId); err != nil {
+		return err
+	}
+
+	query := `DELETE FROM follows
+		WHERE (follower_id = $1 AND followee_id = $2) OR (follower_id = $2 AND followee_id = $1)
+		RETURNING follower_id, followee_id`
+	rows, err := tx.QueryContext(ctx, query, userId, otherId)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var removed [][2]string
+	for rows.Next() {
+		var edge [2]string
+		if err := rows.Scan(&edge[0], &edge[1]); err != nil {
+			return err
+		}
+		removed = append(removed, edge)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, edge := range removed {
+		if err := adjustFollowCounts(ctx, tx, edge[0], edge[1], -1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unblock removes the block. It reports whether there was one; follows removed
+// by the block are not restored.
+func (b *blockRepository) Unblock(ctx context.Context, userId, targetId string) (bool, error) {
+	return b.exec(ctx, `DELETE FROM blocks WHERE blocker_id = $1 AND blocked_id = $2`, userId, targetId)
+}
+
+// Mute records the mute. It reports whether the mute was new.
+func (b *blockRepository) Mute(ctx context.Context, userId, targetId string) (bool, error) {
+	return b.exec(ctx, `INSERT INTO mutes (user_id, muted_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userId, targetId)
+}
+
+func (b *blockRepository) Unmute(ctx context.Context, userId, targetId string) (bool, error) {
+	return b.exec(ctx, `DELETE FROM mutes WHERE user_id = $1 AND muted_id = $2`, userId, targetId)
+}
+
+func (b *blockRepository) exec(ctx context.Context, query, userId, targetId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := b.dbWrite.ExecContext(ctx, query, userId, targetId)
+	if err != nil {
+		return false, err
+	}
+
+	changed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return changed > 0, nil
+}
+
+// Blocked reports whether either user blocked the other.
+func (b *blockRepository) Blocked(ctx context.Context, userId, otherId string) (bool, error) {
+	query := `SELECT NOT ` + notBlocked("$1::uuid", "$2::uuid")
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var blocked bool
+	if err := b.dbRead.QueryRowContext(ctx, query, userId, otherId).Scan(&blocked); err != nil {
+		return false, err
+	}
+	return blocked, nil
+}
+
+// ListBlocked returns the accounts the user blocked, most recent first.
+func (b *blockRepository) ListBlocked(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error) {
+	query := `SELECT b.blocker_id, b.blocked_id, b.created_at, ` + followUserColumns + `
+		FROM blocks b JOIN users u ON u.id = b.blocked_id
+		WHERE b.blocker_id = $1 AND u.deleted_at IS NULL
+		AND ($2::timestamptz IS NULL OR (b.created_at, b.blocked_id) < ($2, $3::uuid))
+		ORDER BY b.created_at DESC, b.blocked_id DESC
+		LIMIT $4`
+	return b.listBlocks(ctx, query, userId, cursor, limit)
+}
+
+// ListMuted returns the accounts the user muted, most recent first.
+func (b *blockRepository) ListMuted(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error) {
+	query := `SELECT m.user_id, m.muted_id, m.created_at, ` + followUserColumns + `
+		FROM mutes m JOIN users u ON u.id = m.muted_id
+		WHERE m.user_id = $1 AND u.deleted_at IS NULL
+		AND ($2::timestamptz IS NULL OR (m.created_at, m.muted_id) < ($2, $3::uuid))
+		ORDER BY m.created_at DESC, m.muted_id DESC
+		LIMIT $4`
+	return b.listBlocks(ctx, query, userId, cursor, limit)
+}
+
+// listBlocks runs a keyset paginated listing of blocks or mutes. The query must
+// select the user id, target id and created_at followed by followUserColumns
+// and take the user id, cursor time, cursor id and limit as $1 to $4.
+func (b *blockRepository) listBlocks(ctx context.Context, query, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := b.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var blocks []*domain.Block
+	for rows.Next() {
+		block := &domain.Block{User: &domain.User{}}
+		if err := rows.Scan(
+			&block.UserId,
+			&block.TargetId,
+			&block.CreatedAt,
+			&block.User.Id,
+			&block.User.Username,
+			&block.User.Protected,
+			&block.User.FollowerCount,
+			&block.User.FollowingCount,
+			&block.User.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, rows.Err()
+}
+
+func NewBlockRepository(dbWrite, dbRead *sql.DB) BlockRepository {
+	return &blockRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
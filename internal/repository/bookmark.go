@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type BookmarkRepository interface {
+	Bookmark(ctx context.Context, bookmark *domain.Bookmark) (bool, error)
+	Unbookmark(ctx context.Context, userId, postId string) (bool, error)
+	List(ctx context.Context, userId string, folderId *string, cursor *domain.Cursor, limit int) ([]*domain.Bookmark, error)
+	CreateFolder(ctx context.Context, folder *domain.BookmarkFolder) (*domain.BookmarkFolder, error)
+	GetFolder(ctx context.Context, userId, id string) (*domain.BookmarkFolder, error)
+	ListFolders(ctx context.Context, userId string) ([]*domain.BookmarkFolder, error)
+	RenameFolder(ctx context.Context, userId, id, name string) (*domain.BookmarkFolder, error)
+	DeleteFolder(ctx context.Context, userId, id string) error
+}
+
+type bookmarkRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// Bookmark saves the post for the user, or files it in another folder if it is
+// already saved. It reports whether the bookmark is new. A folder that is not
+// the user's cannot be found.
+func (b *bookmarkRepository) Bookmark(ctx context.Context, bookmark *domain.Bookmark) (bool, error) {
+	query := `INSERT INTO bookmarks (user_id, post_id, folder_id) SELECT $1::uuid, $2::uuid, $3::uuid
+		WHERE $3::uuid IS NULL OR EXISTS (SELECT 1 FROM bookmark_folders WHERE id = $3 AND user_id = $1)
+		ON CONFLICT (user_id, post_id) DO UPDATE SET folder_id = EXCLUDED.folder_id
+		RETURNING created_at, xmax = 0`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var created bool
+	if err := b.dbWrite.QueryRowContext(ctx, query, bookmark.UserId, bookmark.PostId, bookmark.FolderId).Scan(&bookmark.CreatedAt, &created); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, customErr.ErrNotFound
+		}
+		return false, err
+	}
+	return created, nil
+}
+
+// Unbookmark reports whether the post was saved; removing a bookmark twice is a
+// no-op.
+func (b *bookmarkRepository) Unbookmark(ctx context.Context, userId, postId string) (bool, error) {
+	query := `DELETE FROM bookmarks WHERE user_id = $1 AND post_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := b.dbWrite.ExecContext(ctx, query, userId, postId)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+// List returns the posts the user saved, only those filed in folderId when it
+// is set, most recent bookmark first. Posts the user may no longer see, because
+// of a block or because their author is protected, are left out but kept, so
+// they come back should the user see them again.
+func (b *bookmarkRepository) List(ctx context.Context, userId string, folderId *string, cursor *domain.Cursor, limit int) ([]*domain.Bookmark, error) {
+	query := `SELECT b.user_id, b.folder_id, b.created_at, ` + postColumns + `
+		FROM bookmarks b
+		JOIN posts p ON p.id = b.post_id
+		` + postJoins("$1::uuid") + `
+		WHERE b.user_id = $1 AND ($5::uuid IS NULL OR b.folder_id = $5)
+		AND p.deleted_at IS NULL
+		AND ` + notBlocked("$1::uuid", "p.author_id") + ` AND ` + notProtected("$1::uuid", "p.author_id") + `
+		AND ($2::timestamptz IS NULL OR (b.created_at, b.post_id) < ($2, $3::uuid))
+		ORDER BY b.created_at DESC, b.post_id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := b.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit, folderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookmarks []*domain.Bookmark
+	for rows.Next() {
+		bookmark := &domain.Bookmark{}
+		post, err := scanPost(prefixScanner{rows: rows, prefix: []any{&bookmark.UserId, &bookmark.FolderId, &bookmark.CreatedAt}})
+		if err != nil {
+			return nil, err
+		}
+		bookmark.PostId, bookmark.Post = post.Id, post
+		bookmarks = append(bookmarks, bookmark)
+	}
+	return bookmarks, rows.Err()
+}
+
+// CreateFolder adds a folder unless the user already has domain.MaxBookmarkFolders,
+// which is a validation error. Folder names are unique per user regardless of
+// case.
+func (b *bookmarkRepository) CreateFolder(ctx context.Context, folder *domain.BookmarkFolder) (*domain.BookmarkFolder, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := b.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the user serializes concurrent creations against the limit.
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, folder.UserId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO bookmark_folders (user_id, name) SELECT $1, $2
+		WHERE (SELECT COUNT(*) FROM bookmark_folders WHERE user_id = $1) < $3
+		RETURNING id, created_at`
+	if err := tx.QueryRowContext(ctx, query, folder.UserId, folder.Name, domain.MaxBookmarkFolders).Scan(&folder.Id, &folder.CreatedAt); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, fmt.Errorf("%w: (%d) folders at most", customErr.ErrValidation, domain.MaxBookmarkFolders)
+		case isUniqueViolation(err):
+			return nil, customErr.ErrFolderTaken
+		default:
+			return nil, err
+		}
+	}
+	return folder, tx.Commit()
+}
+
+func (b *bookmarkRepository) GetFolder(ctx context.Context, userId, id string) (*domain.BookmarkFolder, error) {
+	query := `SELECT id, user_id, name, created_at FROM bookmark_folders WHERE id = $1 AND user_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var folder domain.BookmarkFolder
+	if err := b.dbRead.QueryRowContext(ctx, query, id, userId).Scan(&folder.Id, &folder.UserId, &folder.Name, &folder.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &folder, nil
+}
+
+// ListFolders returns the user's folders by name.
+func (b *bookmarkRepository) ListFolders(ctx context.Context, userId string) ([]*domain.BookmarkFolder, error) {
+	query := `SELECT id, user_id, name, created_at FROM bookmark_folders WHERE user_id = $1 ORDER BY LOWER(name), id`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := b.dbRead.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var folders []*domain.BookmarkFolder
+	for rows.Next() {
+		var folder domain.BookmarkFolder
+		if err := rows.Scan(&folder.Id, &folder.UserId, &folder.Name, &folder.CreatedAt); err != nil {
+			return nil, err
+		}
+		folders = append(folders, &folder)
+	}
+	return folders, rows.Err()
+}
+
+func (b *bookmarkRepository) RenameFolder(ctx context.Context, userId, id, name string) (*domain.BookmarkFolder, error) {
+	query := `UPDATE bookmark_folders SET name = $3 WHERE id = $1 AND user_id = $2 RETURNING id, user_id, name, created_at`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var folder domain.BookmarkFolder
+	if err := b.dbWrite.QueryRowContext(ctx, query, id, userId, name).Scan(&folder.Id, &folder.UserId, &folder.Name, &folder.CreatedAt); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		case isUniqueViolation(err):
+			return nil, customErr.ErrFolderTaken
+		default:
+			return nil, err
+		}
+	}
+	return &folder, nil
+}
+
+// DeleteFolder removes the folder; its bookmarks are kept, unfiled.
+func (b *bookmarkRepository) DeleteFolder(ctx context.Context, userId, id string) error {
+	query := `DELETE FROM bookmark_folders WHERE id = $1 AND user_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := b.dbWrite.ExecContext(ctx, query, id, userId)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code.Name() == "unique_violation"
+}
+
+func NewBookmarkRepository(dbWrite, dbRead *sql.DB) BookmarkRepository {
+	return &bookmarkRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestBookmark_Lifecycle(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	bookmarks := NewBookmarkRepository(db, db)
+	blocks := NewBlockRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol")
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	post := func(authorId string) *domain.Post {
+		p, err := posts.Create(ctx, &domain.Post{AuthorId: authorId, Body: "post"})
+		require.NoError(t, err)
+		return p
+	}
+	bobPost, carolPost, deleted := post(bob), post(carol), post(bob)
+
+	listed := func(folderId *string) []string {
+		t.Helper()
+		list, err := bookmarks.List(ctx, alice, folderId, nil, 10)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(list))
+		for _, bookmark := range list {
+			ids = append(ids, bookmark.PostId)
+		}
+		return ids
+	}
+
+	folder, err := bookmarks.CreateFolder(ctx, &domain.BookmarkFolder{UserId: alice, Name: "Later"})
+	require.NoError(t, err)
+
+	t.Run("folder names are unique regardless of case", func(t *testing.T) {
+		_, err := bookmarks.CreateFolder(ctx, &domain.BookmarkFolder{UserId: alice, Name: "later"})
+		require.ErrorIs(t, err, customErr.ErrFolderTaken)
+
+		_, err = bookmarks.CreateFolder(ctx, &domain.BookmarkFolder{UserId: bob, Name: "Later"})
+		require.NoError(t, err)
+	})
+
+	t.Run("bookmarks are filed and listed", func(t *testing.T) {
+		for _, bookmark := range []*domain.Bookmark{
+			{UserId: alice, PostId: bobPost.Id},
+			{UserId: alice, PostId: carolPost.Id, FolderId: &folder.Id},
+			{UserId: alice, PostId: deleted.Id},
+		} {
+			created, err := bookmarks.Bookmark(ctx, bookmark)
+			require.NoError(t, err)
+			require.True(t, created)
+		}
+
+		created, err := bookmarks.Bookmark(ctx, &domain.Bookmark{UserId: alice, PostId: bobPost.Id, FolderId: &folder.Id})
+		require.NoError(t, err)
+		require.False(t, created)
+
+		require.Equal(t, []string{deleted.Id, carolPost.Id, bobPost.Id}, listed(nil))
+		require.ElementsMatch(t, []string{carolPost.Id, bobPost.Id}, listed(&folder.Id))
+	})
+
+	t.Run("folders of other users cannot be used", func(t *testing.T) {
+		_, err := bookmarks.Bookmark(ctx, &domain.Bookmark{UserId: bob, PostId: carolPost.Id, FolderId: &folder.Id})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		require.ErrorIs(t, bookmarks.DeleteFolder(ctx, bob, folder.Id), customErr.ErrNotFound)
+	})
+
+	t.Run("deleted posts are removed", func(t *testing.T) {
+		require.NoError(t, posts.Delete(ctx, deleted.Id))
+
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM bookmarks WHERE post_id = $1`, deleted.Id).Scan(&count))
+		require.Zero(t, count)
+	})
+
+	t.Run("a block by the author removes the bookmarks", func(t *testing.T) {
+		_, err := blocks.Block(ctx, bob, alice)
+		require.NoError(t, err)
+		require.Equal(t, []string{carolPost.Id}, listed(nil))
+
+		_, err = blocks.Unblock(ctx, bob, alice)
+		require.NoError(t, err)
+		require.Equal(t, []string{carolPost.Id}, listed(nil))
+	})
+
+	t.Run("deleting a folder keeps its bookmarks", func(t *testing.T) {
+		require.NoError(t, bookmarks.DeleteFolder(ctx, alice, folder.Id))
+		require.Equal(t, []string{carolPost.Id}, listed(nil))
+	})
+}
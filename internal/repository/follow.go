@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type FollowRepository interface {
+	Follow(ctx context.Context, followerId, followeeId string) (domain.FollowOutcome, error)
+	Unfollow(ctx context.Context, followerId, followeeId string) (bool, error)
+	ListFollowers(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error)
+	ListFollowing(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error)
+	ListMutuals(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error)
+	GetRelationship(ctx context.Context, userId, otherId string) (*domain.Relationship, error)
+	Approve(ctx context.Context, userId, requesterId string) (bool, error)
+	Reject(ctx context.Context, userId, requesterId string) (bool, error)
+	ApproveAll(ctx context.Context, userId string) ([]string, error)
+	ListRequests(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error)
+}
+
+type followRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+const followUserColumns = `u.id, u.username, u.protected, u.follower_count, u.following_count, u.created_at`
+
+// visibleFollows hides the listed accounts the viewer $5 blocked or was blocked
+// by, and the whole list when that is the case of the user $1 or when the user
+// is protected from the viewer.
+var visibleFollows = notBlocked("$5::uuid", "$1::uuid") + ` AND ` + notProtected("$5::uuid", "$1::uuid") + ` AND ` + notBlocked("$5::uuid", "u.id")
+
+// lockUsers locks both ends of an edge in id order, so that concurrent follows in
+// opposite directions cannot deadlock, and reports whether either is deleted.
+func lockUsers(ctx context.Context, tx *sql.Tx, followerId, followeeId string) error {
+	deleted, err := lockUserPair(ctx, tx, followerId, followeeId)
+	if err != nil {
+		return err
+	}
+	if deleted {
+		return customErr.ErrUserDeleted
+	}
+	return nil
+}
+
+// lockUserPair locks both ends of an edge like lockUsers, and reports whether
+// either is deleted instead of failing.
+func lockUserPair(ctx context.Context, tx *sql.Tx, followerId, followeeId string) (bool, error) {
+	query := `SELECT id, deleted_at IS NOT NULL FROM users WHERE id IN ($1, $2) ORDER BY id FOR UPDATE`
+	rows, err := tx.QueryContext(ctx, query, followerId, followeeId)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	var (
+		found   int
+		deleted bool
+	)
+	for rows.Next() {
+		var (
+			id        string
+			isDeleted bool
+		)
+		if err := rows.Scan(&id, &isDeleted); err != nil {
+			return false, err
+		}
+		deleted = deleted || isDeleted
+		found++
+	}
+	if err := rows.Err(); err != nil {
+		return false, err
+	}
+	if found != 2 {
+		return false, customErr.ErrNotFound
+	}
+	return deleted, nil
+}
+
+// Follow adds the edge and bumps both counters in one transaction, or only
+// requests to follow when the account is protected. Whether it is protected is
+// read once both users are locked, so that it cannot change before the edge or
+// the request is written. Following or requesting twice, or across a block,
+// leaves things unchanged.
+func (f *followRepository) Follow(ctx context.Context, followerId, followeeId string) (domain.FollowOutcome, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := f.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	// Locking both users serializes the follow with the account switching to
+	// public, which approves the pending requests.
+	if err := lockUsers(ctx, tx, followerId, followeeId); err != nil {
+		return "", err
+	}
+
+	var protected bool
+	if err := tx.QueryRowContext(ctx, `SELECT protected FROM users WHERE id = $1`, followeeId).Scan(&protected); err != nil {
+		return "", err
+	}
+
+	if protected {
+		query := `INSERT INTO follow_requests (requester_id, target_id) SELECT $1::uuid, $2::uuid
+			WHERE ` + notBlocked("$1::uuid", "$2::uuid") + `
+			AND NOT EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2)
+			ON CONFLICT DO NOTHING`
+		res, err := tx.ExecContext(ctx, query, followerId, followeeId)
+		if err != nil {
+			return "", err
+		}
+
+		requested, err := res.RowsAffected()
+		if err != nil {
+			return "", err
+		}
+		if requested == 0 {
+			return domain.FollowUnchanged, tx.Commit()
+		}
+		return domain.FollowRequested, tx.Commit()
+	}
+
+	query := `INSERT INTO follows (follower_id, followee_id) SELECT $1::uuid, $2::uuid
+		WHERE ` + notBlocked("$1::uuid", "$2::uuid") + `
+		ON CONFLICT DO NOTHING`
+	res, err := tx.ExecContext(ctx, query, followerId, followeeId)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if created == 0 {
+		return domain.FollowUnchanged, tx.Commit()
+	}
+
+	if err := adjustFollowCounts(ctx, tx, followerId, followeeId, 1); err != nil {
+		return "", err
+	}
+	return domain.FollowCreated, tx.Commit()
+}
+
+// Unfollow removes the edge and its counts in one transaction, and withdraws a
+// pending request to follow. It reports whether an edge existed; unfollowing
+// twice is a no-op. Deactivated accounts can be unfollowed too.
+func (f *followRepository) Unfollow(ctx context.Context, followerId, followeeId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := f.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := lockUserPair(ctx, tx, followerId, followeeId); err != nil {
+		return false, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2`, followerId, followeeId); err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM follows WHERE follower_id = $1 AND followee_id = $2`, followerId, followeeId)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if deleted == 0 {
+		return false, tx.Commit()
+	}
+
+	if err := adjustFollowCounts(ctx, tx, followerId, followeeId, -1); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func adjustFollowCounts(ctx context.Context, tx *sql.Tx, followerId, followeeId string, delta int) error {
+	query := `UPDATE users SET
+			following_count = following_count + CASE WHEN id = $1 THEN $3::int ELSE 0 END,
+			follower_count = follower_count + CASE WHEN id = $2 THEN $3::int ELSE 0 END
+		WHERE id IN ($1, $2)`
+	_, err := tx.ExecContext(ctx, query, followerId, followeeId, delta)
+	return err
+}
+
+// listFollows runs a keyset paginated listing of follow edges, newest first. The
+// query must select the edge's created_at followed by followUserColumns and take
+// the user id, cursor time, cursor id, limit and viewer id as $1 to $5.
+func (f *followRepository) listFollows(ctx context.Context, query, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := f.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var follows []*domain.Follow
+	for rows.Next() {
+		follow := &domain.Follow{User: &domain.User{}}
+		if err := rows.Scan(
+			&follow.FollowerId,
+			&follow.FolloweeId,
+			&follow.CreatedAt,
+			&follow.User.Id,
+			&follow.User.Username,
+			&follow.User.Protected,
+			&follow.User.FollowerCount,
+			&follow.User.FollowingCount,
+			&follow.User.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		follows = append(follows, follow)
+	}
+	return follows, rows.Err()
+}
+
+// ListFollowers returns the accounts following the user, most recent first.
+func (f *followRepository) ListFollowers(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error) {
+	query := `SELECT f.follower_id, f.followee_id, f.created_at, ` + followUserColumns + `
+		FROM follows f JOIN users u ON u.id = f.follower_id
+		WHERE f.followee_id = $1 AND u.deleted_at IS NULL AND ` + visibleFollows + `
+		AND ($2::timestamptz IS NULL OR (f.created_at, f.follower_id) < ($2, $3::uuid))
+		ORDER BY f.created_at DESC, f.follower_id DESC
+		LIMIT $4`
+	return f.listFollows(ctx, query, viewerId, userId, cursor, limit)
+}
+
+// ListFollowing returns the accounts the user follows, most recent first.
+func (f *followRepository) ListFollowing(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error) {
+	query := `SELECT f.follower_id, f.followee_id, f.created_at, ` + followUserColumns + `
+		FROM follows f JOIN users u ON u.id = f.followee_id
+		WHERE f.follower_id = $1 AND u.deleted_at IS NULL AND ` + visibleFollows + `
+		AND ($2::timestamptz IS NULL OR (f.created_at, f.followee_id) < ($2, $3::uuid))
+		ORDER BY f.created_at DESC, f.followee_id DESC
+		LIMIT $4`
+	return f.listFollows(ctx, query, viewerId, userId, cursor, limit)
+}
+
+// ListMutuals returns the accounts that the user follows and that follow the user
+// back, ordered by when the user followed them.
+func (f *followRepository) ListMutuals(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error) {
+	query := `SELECT f.follower_id, f.followee_id, f.created_at, ` + followUserColumns + `
+		FROM follows f
+		JOIN follows b ON b.follower_id = f.followee_id AND b.followee_id = f.follower_id
+		JOIN users u ON u.id = f.followee_id
+		WHERE f.follower_id = $1 AND u.deleted_at IS NULL AND ` + visibleFollows + `
+		AND ($2::timestamptz IS NULL OR (f.created_at, f.followee_id) < ($2, $3::uuid))
+		ORDER BY f.created_at DESC, f.followee_id DESC
+		LIMIT $4`
+	return f.listFollows(ctx, query, viewerId, userId, cursor, limit)
+}
+
+func (f *followRepository) GetRelationship(ctx context.Context, userId, otherId string) (*domain.Relationship, error) {
+	query := `SELECT
+			EXISTS (SELECT 1 FROM follows WHERE follower_id = $1 AND followee_id = $2),
+			EXISTS (SELECT 1 FROM follows WHERE follower_id = $2 AND followee_id = $1),
+			EXISTS (SELECT 1 FROM follow_requests WHERE requester_id = $1 AND target_id = $2),
+			EXISTS (SELECT 1 FROM blocks WHERE blocker_id = $1 AND blocked_id = $2),
+			EXISTS (SELECT 1 FROM mutes WHERE user_id = $1 AND muted_id = $2)`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var relationship domain.Relationship
+	if err := f.dbRead.QueryRowContext(ctx, query, userId, otherId).Scan(&relationship.Following, &relationship.FollowedBy, &relationship.Requested, &relationship.Blocking, &relationship.Muting); err != nil {
+		return nil, err
+	}
+	return &relationship, nil
+}
+
+// Approve turns the pending request into a follow with its counts. It reports
+// whether there was a request.
+func (f *followRepository) Approve(ctx context.Context, userId, requesterId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := f.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if err := lockUsers(ctx, tx, requesterId, userId); err != nil {
+		return false, err
+	}
+
+	approved, err := approveRequests(ctx, tx, userId, []string{requesterId})
+	if err != nil {
+		return false, err
+	}
+	return len(approved) > 0, tx.Commit()
+}
+
+// Reject drops the pending request and reports whether there was one. The
+// requester is not told.
+func (f *followRepository) Reject(ctx context.Context, userId, requesterId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := f.dbWrite.ExecContext(ctx, `DELETE FROM follow_requests WHERE requester_id = $1 AND target_id = $2`, requesterId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+// ApproveAll approves every pending request of a user that is no longer
+// protected and returns the ids of the new followers. The user and the
+// requesters are locked in id order, like lockUsers does, before any counter is
+// touched. No request can be added once the user is public, so the requesters
+// read beforehand are all there is.
+func (f *followRepository) ApproveAll(ctx context.Context, userId string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := f.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	requesterIds, err := queryIds(ctx, tx, `SELECT requester_id FROM follow_requests WHERE target_id = $1`, userId)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := queryIds(ctx, tx, `SELECT id FROM users WHERE id = ANY($1::uuid[]) ORDER BY id FOR UPDATE`, pq.Array(append(requesterIds, userId))); err != nil {
+		return nil, err
+	}
+
+	var protected bool
+	if err := tx.QueryRowContext(ctx, `SELECT protected FROM users WHERE id = $1`, userId).Scan(&protected); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+	if protected || len(requesterIds) == 0 {
+		return nil, tx.Commit()
+	}
+
+	approved, err := approveRequests(ctx, tx, userId, requesterIds)
+	if err != nil {
+		return nil, err
+	}
+	return approved, tx.Commit()
+}
+
+// approveRequests turns the requests of requesterIds to follow the user into
+// follows and returns the requesters. Requests of deleted accounts are dropped
+// without a follow. The caller locks the user and the requesters.
+func approveRequests(ctx context.Context, tx *sql.Tx, userId string, requesterIds []string) ([]string, error) {
+	query := `WITH approved AS (
+			DELETE FROM follow_requests r
+			WHERE r.target_id = $1 AND r.requester_id = ANY($2::uuid[])
+			RETURNING r.requester_id
+		), created AS (
+			INSERT INTO follows (follower_id, followee_id)
+			SELECT a.requester_id, $1 FROM approved a
+			JOIN users u ON u.id = a.requester_id AND u.deleted_at IS NULL
+			ON CONFLICT DO NOTHING
+			RETURNING follower_id
+		), counted AS (
+			UPDATE users SET following_count = following_count + 1
+			FROM created WHERE users.id = created.follower_id
+		)
+		SELECT follower_id FROM created`
+	rows, err := tx.QueryContext(ctx, query, userId, pq.Array(requesterIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var approved []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		approved = append(approved, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if len(approved) > 0 {
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET follower_count = follower_count + $2 WHERE id = $1`, userId, len(approved)); err != nil {
+			return nil, err
+		}
+	}
+	return approved, nil
+}
+
+// ListRequests returns the pending requests to follow the user, most recent
+// first.
+func (f *followRepository) ListRequests(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error) {
+	query := `SELECT r.requester_id, r.target_id, r.created_at, ` + followUserColumns + `
+		FROM follow_requests r JOIN users u ON u.id = r.requester_id
+		WHERE r.target_id = $1 AND u.deleted_at IS NULL AND ` + notBlocked("$5::uuid", "u.id") + `
+		AND ($2::timestamptz IS NULL OR (r.created_at, r.requester_id) < ($2, $3::uuid))
+		ORDER BY r.created_at DESC, r.requester_id DESC
+		LIMIT $4`
+	return f.listFollows(ctx, query, userId, userId, cursor, limit)
+}
+
+func NewFollowRepository(dbWrite, dbRead *sql.DB) FollowRepository {
+	return &followRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestFollow_UnfollowDeactivated has bob unfollow alice after she deactivated
+// her account, and checks the edge and both counts are gone.
+func TestFollow_UnfollowDeactivated(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	follows := NewFollowRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+
+	_, err := follows.Follow(ctx, bob, alice)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `UPDATE users SET deleted_at = NOW() WHERE id = $1`, alice)
+	require.NoError(t, err)
+
+	deleted, err := follows.Unfollow(ctx, bob, alice)
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	counts := func(userId string) (followers, following int) {
+		t.Helper()
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT follower_count, following_count FROM users WHERE id = $1`, userId).Scan(&followers, &following))
+		return followers, following
+	}
+	followers, _ := counts(alice)
+	require.Zero(t, followers)
+	_, following := counts(bob)
+	require.Zero(t, following)
+
+	deleted, err = follows.Unfollow(ctx, bob, alice)
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
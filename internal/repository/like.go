@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"math/rand/v2"
+	"time"
+)
+
+// likeCounterShards is the number of counter rows a post's like count is spread over.
+const likeCounterShards = 16
+
+type LikeRepository interface {
+	Like(ctx context.Context, userId, postId string) (bool, error)
+	Unlike(ctx context.Context, userId, postId string) (bool, error)
+	CountLikes(ctx context.Context, postId string) (int64, error)
+	ListLikedBy(ctx context.Context, viewerId, postId string, cursor *domain.Cursor, limit int) ([]*domain.Like, error)
+	ListLikedPosts(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Like, error)
+}
+
+type likeRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// Like records the like and increments a random counter shard of the post in one
+// transaction. It reports whether the like was new; liking twice is a no-op.
+func (l *likeRepository) Like(ctx context.Context, userId, postId string) (bool, error) {
+	return l.toggle(ctx, `INSERT INTO likes (user_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, userId, postId, 1)
+}
+
+// Unlike removes the like and decrements a random counter shard of the post in
+// one transaction. It reports whether there was a like; unliking twice is a no-op.
+func (l *likeRepository) Unlike(ctx context.Context, userId, postId string) (bool, error) {
+	return l.toggle(ctx, `DELETE FROM likes WHERE user_id = $1 AND post_id = $2`, userId, postId, -1)
+}
+
+func (l *likeRepository) toggle(ctx context.Context, query, userId, postId string, delta int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, userId, postId)
+	if err != nil {
+		return false, err
+	}
+
+	changed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if changed == 0 {
+		return false, tx.Commit()
+	}
+
+	counter := `INSERT INTO post_like_counts (post_id, shard, count) VALUES ($1, $2, $3)
+		ON CONFLICT (post_id, shard) DO UPDATE SET count = post_like_counts.count + EXCLUDED.count`
+	if _, err := tx.ExecContext(ctx, counter, postId, rand.IntN(likeCounterShards), delta); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+func (l *likeRepository) CountLikes(ctx context.Context, postId string) (int64, error) {
+	query := `SELECT COALESCE(SUM(count), 0) FROM post_like_counts WHERE post_id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var count int64
+	if err := l.dbRead.QueryRowContext(ctx, query, postId).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ListLikedBy returns the users who liked the post, most recent like first,
+// leaving out those the viewer blocked or was blocked by.
+func (l *likeRepository) ListLikedBy(ctx context.Context, viewerId, postId string, cursor *domain.Cursor, limit int) ([]*domain.Like, error) {
+	query := `SELECT l.user_id, l.post_id, l.created_at, u.id, u.username, u.created_at
+		FROM likes l JOIN users u ON u.id = l.user_id
+		WHERE l.post_id = $1 AND u.deleted_at IS NULL AND ` + notBlocked("$5::uuid", "l.user_id") + `
+		AND ($2::timestamptz IS NULL OR (l.created_at, l.user_id) < ($2, $3::uuid))
+		ORDER BY l.created_at DESC, l.user_id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, postId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var likes []*domain.Like
+	for rows.Next() {
+		like := &domain.Like{User: &domain.User{}}
+		if err := rows.Scan(&like.UserId, &like.PostId, &like.CreatedAt, &like.User.Id, &like.User.Username, &like.User.CreatedAt); err != nil {
+			return nil, err
+		}
+		likes = append(likes, like)
+	}
+	return likes, rows.Err()
+}
+
+// ListLikedPosts returns the posts the user liked that still exist, most recent
+// like first. Posts of accounts the viewer blocked or was blocked by are left
+// out, and so is everything when that is the case of the user.
+func (l *likeRepository) ListLikedPosts(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Like, error) {
+	query := `SELECT l.user_id, l.created_at, ` + postColumns + `
+		FROM likes l
+		JOIN posts p ON p.id = l.post_id
+		` + postJoins("$5::uuid") + `
+		WHERE l.user_id = $1 AND p.deleted_at IS NULL
+		AND ` + notBlocked("$5::uuid", "l.user_id") + ` AND ` + notBlocked("$5::uuid", "p.author_id") + `
+		AND ` + notProtected("$5::uuid", "l.user_id") + ` AND ` + notProtected("$5::uuid", "p.author_id") + `
+		AND ($2::timestamptz IS NULL OR (l.created_at, l.post_id) < ($2, $3::uuid))
+		ORDER BY l.created_at DESC, l.post_id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var likes []*domain.Like
+	for rows.Next() {
+		like := &domain.Like{}
+		post, err := scanPost(prefixScanner{rows: rows, prefix: []any{&like.UserId, &like.CreatedAt}})
+		if err != nil {
+			return nil, err
+		}
+		like.PostId, like.Post = post.Id, post
+		likes = append(likes, like)
+	}
+	return likes, rows.Err()
+}
+
+func NewLikeRepository(dbWrite, dbRead *sql.DB) LikeRepository {
+	return &likeRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
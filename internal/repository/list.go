@@ -0,0 +1,564 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type ListRepository interface {
+	Create(ctx context.Context, list *domain.List) (*domain.List, error)
+	GetById(ctx context.Context, viewerId, id string) (*domain.List, error)
+	Update(ctx context.Context, ownerId, id string, update *domain.ListUpdate) (*domain.List, error)
+	Delete(ctx context.Context, ownerId, id string) error
+	ListOwned(ctx context.Context, viewerId, ownerId string, cursor *domain.Cursor, limit int) ([]*domain.List, error)
+	ListSubscribed(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error)
+	AddMember(ctx context.Context, listId, userId string) (bool, error)
+	RemoveMember(ctx context.Context, listId, userId string) (bool, error)
+	ListMembers(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error)
+	Subscribe(ctx context.Context, listId, userId string) (bool, error)
+	Unsubscribe(ctx context.Context, listId, userId string) (bool, error)
+	ListSubscribers(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error)
+	Timeline(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.Post, error)
+}
+
+type listRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// listColumns selects a list aliased l with its owner aliased o, and whether
+// the viewer is subscribed to it; scanList reads them back.
+func listColumns(viewer string) string {
+	return `l.id, l.owner_id, l.name, l.description, l.private, l.member_count, l.subscriber_count, l.created_at, l.updated_at,
+		o.id, o.username, o.protected, o.created_at,
+		EXISTS (SELECT 1 FROM list_subscribers ls WHERE ls.list_id = l.id AND ls.user_id = ` + viewer + `)`
+}
+
+// visibleList hides private lists from everyone but their owner, and lists of
+// deactivated owners or of owners the viewer blocked or was blocked by.
+func visibleList(viewer string) string {
+	return `o.deleted_at IS NULL AND (NOT l.private OR l.owner_id = ` + viewer + `) AND ` + notBlocked(viewer, "l.owner_id")
+}
+
+func scanList(scanner interface{ Scan(dest ...any) error }) (*domain.List, error) {
+	list := domain.List{Owner: &domain.User{}}
+	if err := scanner.Scan(
+		&list.Id,
+		&list.OwnerId,
+		&list.Name,
+		&list.Description,
+		&list.Private,
+		&list.MemberCount,
+		&list.SubscriberCount,
+		&list.CreatedAt,
+		&list.UpdatedAt,
+		&list.Owner.Id,
+		&list.Owner.Username,
+		&list.Owner.Protected,
+		&list.Owner.CreatedAt,
+		&list.Subscribed,
+	); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Create adds the list unless its owner already has domain.MaxOwnedLists, which
+// is a validation error.
+func (l *listRepository) Create(ctx context.Context, list *domain.List) (*domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Locking the owner serializes concurrent creations against the limit.
+	if _, err := tx.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, list.OwnerId); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO lists (owner_id, name, description, private) SELECT $1, $2, $3, $4
+		WHERE (SELECT COUNT(*) FROM lists WHERE owner_id = $1) < $5
+		RETURNING id, created_at, updated_at`
+	if err := tx.QueryRowContext(ctx, query, list.OwnerId, list.Name, list.Description, list.Private, domain.MaxOwnedLists).Scan(&list.Id, &list.CreatedAt, &list.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: (%d) lists at most", customErr.ErrValidation, domain.MaxOwnedLists)
+		}
+		return nil, err
+	}
+	return list, tx.Commit()
+}
+
+// GetById returns the list if the viewer may see it.
+func (l *listRepository) GetById(ctx context.Context, viewerId, id string) (*domain.List, error) {
+	query := `SELECT ` + listColumns("$2::uuid") + `
+		FROM lists l JOIN users o ON o.id = l.owner_id
+		WHERE l.id = $1 AND ` + visibleList("$2::uuid")
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	list, err := scanList(l.dbRead.QueryRowContext(ctx, query, id, viewerId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+// Update changes the given fields of a list of the owner. Making a list private
+// drops its subscribers, who may no longer see it.
+func (l *listRepository) Update(ctx context.Context, ownerId, id string, update *domain.ListUpdate) (*domain.List, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `UPDATE lists SET
+			name = COALESCE($3, name),
+			description = COALESCE($4, description),
+			private = COALESCE($5, private),
+			updated_at = NOW()
+		WHERE id = $1 AND owner_id = $2
+		RETURNING private`
+	var private bool
+	if err := tx.QueryRowContext(ctx, query, id, ownerId, update.Name, update.Description, update.Private).Scan(&private); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if private {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM list_subscribers WHERE list_id = $1`, id); err != nil {
+			return nil, err
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE lists SET subscriber_count = 0 WHERE id = $1`, id); err != nil {
+			return nil, err
+		}
+	}
+
+	read := `SELECT ` + listColumns("$2::uuid") + ` FROM lists l JOIN users o ON o.id = l.owner_id WHERE l.id = $1`
+	list, err := scanList(tx.QueryRowContext(ctx, read, id, ownerId))
+	if err != nil {
+		return nil, err
+	}
+	return list, tx.Commit()
+}
+
+func (l *listRepository) Delete(ctx context.Context, ownerId, id string) error {
+	query := `DELETE FROM lists WHERE id = $1 AND owner_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := l.dbWrite.ExecContext(ctx, query, id, ownerId)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+// ListOwned returns the lists of the owner the viewer may see, most recent
+// first.
+func (l *listRepository) ListOwned(ctx context.Context, viewerId, ownerId string, cursor *domain.Cursor, limit int) ([]*domain.List, error) {
+	query := `SELECT ` + listColumns("$5::uuid") + `
+		FROM lists l JOIN users o ON o.id = l.owner_id
+		WHERE l.owner_id = $1 AND ` + visibleList("$5::uuid") + `
+		AND ($2::timestamptz IS NULL OR (l.created_at, l.id) < ($2, $3::uuid))
+		ORDER BY l.created_at DESC, l.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, ownerId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lists []*domain.List
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, list)
+	}
+	return lists, rows.Err()
+}
+
+// ListSubscribed returns the lists the user subscribed to and may still see,
+// most recent subscription first.
+func (l *listRepository) ListSubscribed(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error) {
+	query := `SELECT s.list_id, s.user_id, s.created_at, ` + listColumns("$1::uuid") + `
+		FROM list_subscribers s
+		JOIN lists l ON l.id = s.list_id
+		JOIN users o ON o.id = l.owner_id
+		WHERE s.user_id = $1 AND ` + visibleList("$1::uuid") + `
+		AND ($2::timestamptz IS NULL OR (s.created_at, s.list_id) < ($2, $3::uuid))
+		ORDER BY s.created_at DESC, s.list_id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*domain.ListMembership
+	for rows.Next() {
+		subscription := &domain.ListMembership{}
+		list, err := scanList(prefixScanner{rows: rows, prefix: []any{&subscription.ListId, &subscription.UserId, &subscription.CreatedAt}})
+		if err != nil {
+			return nil, err
+		}
+		subscription.List = list
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// lockList locks the owner and the user joining the list, in the order
+// lockUsers uses, then the list itself. Blocks lock both users before removing
+// memberships, so a user cannot join a list across a block being made.
+func lockList(ctx context.Context, tx *sql.Tx, listId, userId string) (*domain.List, error) {
+	var ownerId string
+	if err := tx.QueryRowContext(ctx, `SELECT owner_id FROM lists WHERE id = $1`, listId).Scan(&ownerId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+
+	// Owners may add themselves to their lists.
+	if ownerId == userId {
+		if _, err := tx.ExecContext(ctx, `SELECT 1 FROM users WHERE id = $1 FOR UPDATE`, ownerId); err != nil {
+			return nil, err
+		}
+	} else if err := lockUsers(ctx, tx, ownerId, userId); err != nil {
+		return nil, err
+	}
+
+	list := domain.List{Id: listId}
+	query := `SELECT owner_id, private, member_count FROM lists WHERE id = $1 FOR UPDATE`
+	if err := tx.QueryRowContext(ctx, query, listId).Scan(&list.OwnerId, &list.Private, &list.MemberCount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return &list, nil
+}
+
+// AddMember reports whether the user is a new member; adding a member twice is
+// a no-op. A full list is a validation error, and a user who blocked the owner
+// or was blocked by them cannot be added.
+func (l *listRepository) AddMember(ctx context.Context, listId, userId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	list, err := lockList(ctx, tx, listId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	var member bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM list_members WHERE list_id = $1 AND user_id = $2)`, listId, userId).Scan(&member); err != nil {
+		return false, err
+	}
+	if member {
+		return false, tx.Commit()
+	}
+
+	if list.MemberCount >= domain.MaxListMembers {
+		return false, fmt.Errorf("%w: a list has (%d) members at most", customErr.ErrValidation, domain.MaxListMembers)
+	}
+
+	query := `INSERT INTO list_members (list_id, user_id) SELECT $1, $2::uuid
+		WHERE ` + notBlocked("$3::uuid", "$2::uuid")
+	res, err := tx.ExecContext(ctx, query, listId, userId, list.OwnerId)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if created == 0 {
+		return false, fmt.Errorf("%w: the account cannot be added to the list", customErr.ErrForbidden)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE lists SET member_count = member_count + 1 WHERE id = $1`, listId); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// RemoveMember reports whether the user was a member; removing a member twice is
+// a no-op.
+func (l *listRepository) RemoveMember(ctx context.Context, listId, userId string) (bool, error) {
+	return l.leave(ctx, `DELETE FROM list_members WHERE list_id = $1 AND user_id = $2`, `UPDATE lists SET member_count = member_count - 1 WHERE id = $1`, listId, userId)
+}
+
+// Subscribe reports whether the subscription is new; subscribing twice is a
+// no-op, and so is subscribing to a private list or across a block.
+func (l *listRepository) Subscribe(ctx context.Context, listId, userId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	list, err := lockList(ctx, tx, listId, userId)
+	if err != nil {
+		return false, err
+	}
+	if list.Private || list.OwnerId == userId {
+		return false, tx.Commit()
+	}
+
+	query := `INSERT INTO list_subscribers (list_id, user_id) SELECT $1, $2::uuid
+		WHERE ` + notBlocked("$2::uuid", "$3::uuid") + `
+		ON CONFLICT DO NOTHING`
+	res, err := tx.ExecContext(ctx, query, listId, userId, list.OwnerId)
+	if err != nil {
+		return false, err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if created == 0 {
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE lists SET subscriber_count = subscriber_count + 1 WHERE id = $1`, listId); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// Unsubscribe reports whether the user was subscribed; unsubscribing twice is a
+// no-op.
+func (l *listRepository) Unsubscribe(ctx context.Context, listId, userId string) (bool, error) {
+	return l.leave(ctx, `DELETE FROM list_subscribers WHERE list_id = $1 AND user_id = $2`, `UPDATE lists SET subscriber_count = subscriber_count - 1 WHERE id = $1`, listId, userId)
+}
+
+// leave deletes a membership with query and, if there was one, adjusts the
+// count of the list with counter, in one transaction.
+func (l *listRepository) leave(ctx context.Context, query, counter, listId, userId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := l.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, query, listId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if deleted == 0 {
+		return false, tx.Commit()
+	}
+
+	if _, err := tx.ExecContext(ctx, counter, listId); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// ListMembers returns the members of the list, most recently added first,
+// leaving out those the viewer blocked or was blocked by.
+func (l *listRepository) ListMembers(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error) {
+	query := `SELECT m.list_id, m.user_id, m.created_at, ` + followUserColumns + `
+		FROM list_members m JOIN users u ON u.id = m.user_id
+		WHERE m.list_id = $1 AND u.deleted_at IS NULL AND ` + notBlocked("$5::uuid", "u.id") + `
+		AND ($2::timestamptz IS NULL OR (m.created_at, m.user_id) < ($2, $3::uuid))
+		ORDER BY m.created_at DESC, m.user_id DESC
+		LIMIT $4`
+	return l.listMemberships(ctx, query, viewerId, listId, cursor, limit)
+}
+
+// ListSubscribers returns the subscribers of the list, most recent first,
+// leaving out those the viewer blocked or was blocked by.
+func (l *listRepository) ListSubscribers(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error) {
+	query := `SELECT s.list_id, s.user_id, s.created_at, ` + followUserColumns + `
+		FROM list_subscribers s JOIN users u ON u.id = s.user_id
+		WHERE s.list_id = $1 AND u.deleted_at IS NULL AND ` + notBlocked("$5::uuid", "u.id") + `
+		AND ($2::timestamptz IS NULL OR (s.created_at, s.user_id) < ($2, $3::uuid))
+		ORDER BY s.created_at DESC, s.user_id DESC
+		LIMIT $4`
+	return l.listMemberships(ctx, query, viewerId, listId, cursor, limit)
+}
+
+// listMemberships runs a keyset paginated listing of the users of a list. The
+// query must select the membership followed by followUserColumns and take the
+// list id, cursor time, cursor id, limit and viewer id as $1 to $5.
+func (l *listRepository) listMemberships(ctx context.Context, query, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, listId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var memberships []*domain.ListMembership
+	for rows.Next() {
+		membership := &domain.ListMembership{User: &domain.User{}}
+		if err := rows.Scan(
+			&membership.ListId,
+			&membership.UserId,
+			&membership.CreatedAt,
+			&membership.User.Id,
+			&membership.User.Username,
+			&membership.User.Protected,
+			&membership.User.FollowerCount,
+			&membership.User.FollowingCount,
+			&membership.User.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, membership)
+	}
+	return memberships, rows.Err()
+}
+
+// Timeline returns the posts of the members of the list, newest first. Each
+// member's posts are limited before they are merged, so the cost does not grow
+// with the member's post count. Members the viewer blocked, was blocked by or
+// muted are left out, and so are protected members the viewer does not follow.
+func (l *listRepository) Timeline(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.Post, error) {
+	query := `WITH candidates AS (
+			SELECT r.id
+			FROM list_members m
+			CROSS JOIN LATERAL (
+				SELECT p.id FROM posts p
+				WHERE p.author_id = m.user_id AND p.deleted_at IS NULL
+				AND ($2::timestamptz IS NULL OR (p.created_at, p.id) < ($2, $3::uuid))
+				ORDER BY p.created_at DESC, p.id DESC
+				LIMIT $4
+			) r
+			WHERE m.list_id = $1 AND ` + notHidden("$5::uuid", "m.user_id") + `
+			AND ` + notProtected("$5::uuid", "m.user_id") + `
+		)
+		SELECT ` + postColumns + `
+		FROM posts p ` + postJoins("$5::uuid") + `
+		WHERE p.id IN (SELECT id FROM candidates)
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := l.dbRead.QueryContext(ctx, query, listId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// removeListMemberships removes each of two users from the lists of the other,
+// as a member and as a subscriber, and adjusts the counts of those lists.
+func removeListMemberships(ctx context.Context, tx *sql.Tx, userId, otherId string) error {
+	for _, query := range []string{
+		`WITH removed AS (
+			DELETE FROM list_members m USING lists l
+			WHERE m.list_id = l.id AND ((l.owner_id = $1 AND m.user_id = $2) OR (l.owner_id = $2 AND m.user_id = $1))
+			RETURNING m.list_id
+		)
+		UPDATE lists SET member_count = member_count - 1 FROM removed WHERE lists.id = removed.list_id`,
+		`WITH removed AS (
+			DELETE FROM list_subscribers s USING lists l
+			WHERE s.list_id = l.id AND ((l.owner_id = $1 AND s.user_id = $2) OR (l.owner_id = $2 AND s.user_id = $1))
+			RETURNING s.list_id
+		)
+		UPDATE lists SET subscriber_count = subscriber_count - 1 FROM removed WHERE lists.id = removed.list_id`,
+	} {
+		if _, err := tx.ExecContext(ctx, query, userId, otherId); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func NewListRepository(dbWrite, dbRead *sql.DB) ListRepository {
+	return &listRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
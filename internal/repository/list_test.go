@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestList_Lifecycle(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	lists := NewListRepository(db, db)
+	blocks := NewBlockRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := ids[0], ids[1], ids[2], ids[3]
+
+	list, err := lists.Create(ctx, &domain.List{OwnerId: alice, Name: "Friends"})
+	require.NoError(t, err)
+
+	members := func() []string {
+		t.Helper()
+		memberships, err := lists.ListMembers(ctx, alice, list.Id, nil, 10)
+		require.NoError(t, err)
+		ids := make([]string, 0, len(memberships))
+		for _, membership := range memberships {
+			ids = append(ids, membership.UserId)
+		}
+		return ids
+	}
+
+	t.Run("members are added once", func(t *testing.T) {
+		for _, userId := range []string{bob, carol} {
+			added, err := lists.AddMember(ctx, list.Id, userId)
+			require.NoError(t, err)
+			require.True(t, added)
+		}
+
+		added, err := lists.AddMember(ctx, list.Id, bob)
+		require.NoError(t, err)
+		require.False(t, added)
+		require.Equal(t, []string{carol, bob}, members())
+
+		got, err := lists.GetById(ctx, alice, list.Id)
+		require.NoError(t, err)
+		require.Equal(t, 2, got.MemberCount)
+	})
+
+	t.Run("the timeline merges the posts of members", func(t *testing.T) {
+		var want []string
+		for _, authorId := range []string{bob, dave, carol, bob} {
+			post, err := posts.Create(ctx, &domain.Post{AuthorId: authorId, Body: "post"})
+			require.NoError(t, err)
+			if authorId != dave {
+				want = append([]string{post.Id}, want...)
+			}
+		}
+
+		timeline, err := lists.Timeline(ctx, alice, list.Id, nil, 10)
+		require.NoError(t, err)
+		got := make([]string, 0, len(timeline))
+		for _, post := range timeline {
+			got = append(got, post.Id)
+		}
+		require.Equal(t, want, got)
+	})
+
+	t.Run("private lists are hidden from others", func(t *testing.T) {
+		subscribed, err := lists.Subscribe(ctx, list.Id, dave)
+		require.NoError(t, err)
+		require.True(t, subscribed)
+
+		private := true
+		_, err = lists.Update(ctx, alice, list.Id, &domain.ListUpdate{Private: &private})
+		require.NoError(t, err)
+
+		_, err = lists.GetById(ctx, dave, list.Id)
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+
+		got, err := lists.GetById(ctx, alice, list.Id)
+		require.NoError(t, err)
+		require.Zero(t, got.SubscriberCount)
+	})
+
+	t.Run("a block removes the membership", func(t *testing.T) {
+		_, err := blocks.Block(ctx, carol, alice)
+		require.NoError(t, err)
+		require.Equal(t, []string{bob}, members())
+
+		got, err := lists.GetById(ctx, alice, list.Id)
+		require.NoError(t, err)
+		require.Equal(t, 1, got.MemberCount)
+	})
+
+	t.Run("users who blocked the owner cannot be added", func(t *testing.T) {
+		_, err := lists.AddMember(ctx, list.Id, carol)
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+
+		_, err = blocks.Unblock(ctx, carol, alice)
+		require.NoError(t, err)
+
+		_, err = lists.AddMember(ctx, list.Id, carol)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type MediaRepository interface {
+	Reuse(ctx context.Context, userId, hash string) (*domain.Media, error)
+	Create(ctx context.Context, media *domain.Media) (*domain.Media, error)
+	DeleteOrphans(ctx context.Context, before time.Time) (int64, error)
+	DeleteUnusedBlobs(ctx context.Context, before time.Time, limit int, remove func(blob *domain.MediaBlob) error) (int, error)
+}
+
+type mediaRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// postMedia selects the media attached to the post aliased p as a JSON array
+// in the order they were attached in, decoded by scanPost.
+const postMedia = `(SELECT COALESCE(JSON_AGG(JSON_BUILD_OBJECT('id', m.id, 'user_id', m.user_id, 'post_id', m.post_id,
+		'position', m.position, 'alt_text', m.alt_text, 'created_at', m.created_at, 'hash', b.hash,
+		'content_type', b.content_type, 'width', b.width, 'height', b.height, 'size', b.size,
+		'blurhash', b.blurhash, 'variants', b.variants) ORDER BY m.position), '[]')
+		FROM media m JOIN media_blobs b ON b.hash = m.hash WHERE m.post_id = p.id)`
+
+// Reuse records an upload of content that is already stored. It returns
+// customErr.ErrNotFound when there is no blob with the hash, in which case the
+// content must be stored with Create.
+func (m *mediaRepository) Reuse(ctx context.Context, userId, hash string) (*domain.Media, error) {
+	query := `WITH blob AS (
+			UPDATE media_blobs SET used_at = NOW() WHERE hash = $2
+			RETURNING hash, content_type, width, height, size, blurhash, variants
+		), created AS (
+			INSERT INTO media (user_id, hash) SELECT $1, hash FROM blob
+			RETURNING id, created_at
+		)
+		SELECT c.id, c.created_at, b.hash, b.content_type, b.width, b.height, b.size, b.blurhash, b.variants
+		FROM created c CROSS JOIN blob b`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	media := &domain.Media{UserId: userId}
+	var variants []byte
+	if err := m.dbWrite.QueryRowContext(ctx, query, userId, hash).Scan(
+		&media.Id,
+		&media.CreatedAt,
+		&media.Hash,
+		&media.ContentType,
+		&media.Width,
+		&media.Height,
+		&media.Size,
+		&media.Blurhash,
+		&variants,
+	); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(variants, &media.Variants); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// Create records the blob of the media, unless an upload of the same content
+// recorded it meanwhile, together with the media itself.
+func (m *mediaRepository) Create(ctx context.Context, media *domain.Media) (*domain.Media, error) {
+	variants, err := json.Marshal(media.Variants)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := m.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	blob := `INSERT INTO media_blobs (hash, content_type, width, height, size, blurhash, variants)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (hash) DO UPDATE SET used_at = NOW()`
+	if _, err := tx.ExecContext(ctx, blob, media.Hash, media.ContentType, media.Width, media.Height, media.Size, media.Blurhash, variants); err != nil {
+		return nil, err
+	}
+
+	query := `INSERT INTO media (user_id, hash) VALUES ($1, $2) RETURNING id, created_at`
+	if err := tx.QueryRowContext(ctx, query, media.UserId, media.Hash).Scan(&media.Id, &media.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// DeleteOrphans removes the media uploaded before the given time that were
+// never attached to a post, and returns how many there were.
+func (m *mediaRepository) DeleteOrphans(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := m.dbWrite.ExecContext(ctx, `DELETE FROM media WHERE post_id IS NULL AND created_at < $1`, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// DeleteUnusedBlobs removes up to limit blobs no media refers to that were last
+// used before the given time, and returns how many it removed. remove is called
+// for every blob to delete its stored variants before the rows are gone; the
+// rows stay locked meanwhile, so an upload of the same content waits and then
+// stores it again. When remove fails nothing is deleted.
+func (m *mediaRepository) DeleteUnusedBlobs(ctx context.Context, before time.Time, limit int, remove func(blob *domain.MediaBlob) error) (int, error) {
+	query := `DELETE FROM media_blobs WHERE hash IN (
+			SELECT b.hash FROM media_blobs b
+			WHERE b.used_at < $1 AND NOT EXISTS (SELECT 1 FROM media m WHERE m.hash = b.hash)
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING hash, variants`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := m.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var blobs []*domain.MediaBlob
+	for rows.Next() {
+		var (
+			blob     domain.MediaBlob
+			variants []byte
+		)
+		if err := rows.Scan(&blob.Hash, &variants); err != nil {
+			return 0, err
+		}
+		if err := json.Unmarshal(variants, &blob.Variants); err != nil {
+			return 0, err
+		}
+		blobs = append(blobs, &blob)
+	}
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	rows.Close()
+
+	for _, blob := range blobs {
+		if err := remove(blob); err != nil {
+			return 0, err
+		}
+	}
+	return len(blobs), tx.Commit()
+}
+
+func NewMediaRepository(dbWrite, dbRead *sql.DB) MediaRepository {
+	return &mediaRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// TestMedia_Lifecycle uploads the same content twice, attaches one upload to a
+// post and checks that the cleanup keeps only what a post still uses.
+func TestMedia_Lifecycle(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	media := NewMediaRepository(db, db)
+	posts := NewPostRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+
+	sum := sha256.Sum256(fmt.Appendf(nil, "%s", t.Name()+time.Now().String()))
+	hash := hex.EncodeToString(sum[:])
+
+	_, err := media.Reuse(ctx, alice, hash)
+	require.ErrorIs(t, err, customErr.ErrNotFound)
+
+	first, err := media.Create(ctx, &domain.Media{UserId: alice, MediaBlob: domain.MediaBlob{
+		Hash: hash, ContentType: "image/png", Width: 2, Height: 1, Size: 10, Blurhash: "LEHV6nWB2yk8",
+		Variants: []*domain.MediaVariant{{Name: "original", Key: "media/" + hash + "/original.png", URL: "/blobs/media/" + hash + "/original.png"}},
+	}})
+	require.NoError(t, err)
+
+	second, err := media.Reuse(ctx, alice, hash)
+	require.NoError(t, err)
+	require.NotEqual(t, first.Id, second.Id)
+	require.Equal(t, first.Variants, second.Variants)
+
+	t.Run("only the uploader attaches once", func(t *testing.T) {
+		_, err := posts.Create(ctx, &domain.Post{AuthorId: bob, Media: []*domain.Media{{Id: first.Id}}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+
+		post, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Media: []*domain.Media{{Id: first.Id, AltText: "a dot"}}})
+		require.NoError(t, err)
+		require.Len(t, post.Media, 1)
+		require.Equal(t, "a dot", post.Media[0].AltText)
+		require.Equal(t, first.Variants, post.Media[0].Variants)
+
+		_, err = posts.Create(ctx, &domain.Post{AuthorId: alice, Media: []*domain.Media{{Id: first.Id}}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+
+		read, err := posts.GetById(ctx, bob, post.Id)
+		require.NoError(t, err)
+		require.Len(t, read.Media, 1)
+		require.Equal(t, hash, read.Media[0].Hash)
+	})
+
+	t.Run("cleanup keeps attached content", func(t *testing.T) {
+		removed, err := media.DeleteOrphans(ctx, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, removed, int64(1))
+
+		_, err = media.DeleteUnusedBlobs(ctx, time.Now().Add(time.Minute), 1000, func(blob *domain.MediaBlob) error {
+			require.NotEqual(t, hash, blob.Hash)
+			return nil
+		})
+		require.NoError(t, err)
+
+		_, err = media.Reuse(ctx, bob, hash)
+		require.NoError(t, err)
+	})
+}
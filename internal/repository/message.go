@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type MessageRepository interface {
+	CreateConversation(ctx context.Context, conversation *domain.Conversation, participantIds []string) (string, error)
+	GetConversation(ctx context.Context, userId, id string) (*domain.Conversation, error)
+	ListConversations(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Conversation, error)
+	DeleteConversation(ctx context.Context, userId, id string) error
+	CreateMessage(ctx context.Context, message *domain.Message) (*domain.Message, error)
+	ListMessages(ctx context.Context, userId, conversationId string, cursor *domain.Cursor, limit int) ([]*domain.Message, error)
+	MarkRead(ctx context.Context, userId, conversationId, messageId string) (*domain.ReadReceipt, error)
+	GetPrivacy(ctx context.Context, userId string) (domain.MessagePrivacy, error)
+	SetPrivacy(ctx context.Context, userId string, privacy domain.MessagePrivacy) error
+}
+
+type messageRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// visibleMessages filters out the messages sent before the participant deleted
+// the conversation and those of deactivated senders or senders the participant
+// blocked or was blocked by; the messages must be aliased m and the participant p.
+var visibleMessages = `(p.cleared_at IS NULL OR m.created_at > p.cleared_at)
+	AND EXISTS (SELECT 1 FROM users s WHERE s.id = m.sender_id AND s.deleted_at IS NULL)
+	AND ` + notBlocked("p.user_id", "m.sender_id")
+
+// conversationColumns lists the columns scanConversation reads, as seen by the
+// participant aliased p. A message is unread when someone else sent it after
+// the participant's read cursor.
+var conversationColumns = `c.id, c.direct_key, c.created_by, c.created_at, c.updated_at,
+	(SELECT COALESCE(JSON_AGG(JSON_BUILD_OBJECT(
+			'user', JSON_BUILD_OBJECT('id', u.id, 'username', u.username, 'created_at', u.created_at),
+			'last_read_message_id', cp.last_read_message_id,
+			'read_at', cp.read_at
+		) ORDER BY cp.joined_at, u.id), '[]')
+	FROM conversation_participants cp JOIN users u ON u.id = cp.user_id
+	WHERE cp.conversation_id = c.id AND u.deleted_at IS NULL),
+	(SELECT JSON_BUILD_OBJECT('id', m.id, 'conversation_id', m.conversation_id, 'sender_id', m.sender_id, 'body', m.body, 'created_at', m.created_at,
+			'sender', JSON_BUILD_OBJECT('id', u.id, 'username', u.username, 'created_at', u.created_at))
+	FROM messages m JOIN users u ON u.id = m.sender_id
+	WHERE m.conversation_id = c.id AND ` + visibleMessages + `
+	ORDER BY m.created_at DESC, m.id DESC
+	LIMIT 1),
+	(SELECT COUNT(*) FROM messages m
+	WHERE m.conversation_id = c.id AND m.sender_id <> p.user_id AND ` + visibleMessages + `
+	AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.id = p.last_read_message_id AND (r.created_at, r.id) >= (m.created_at, m.id)))`
+
+func scanConversation(scanner interface{ Scan(dest ...any) error }) (*domain.Conversation, error) {
+	var (
+		conversation domain.Conversation
+		participants []byte
+		lastMessage  []byte
+	)
+	if err := scanner.Scan(
+		&conversation.Id,
+		&conversation.DirectKey,
+		&conversation.CreatedBy,
+		&conversation.CreatedAt,
+		&conversation.UpdatedAt,
+		&participants,
+		&lastMessage,
+		&conversation.UnreadCount,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(participants, &conversation.Participants); err != nil {
+		return nil, err
+	}
+	if lastMessage != nil {
+		if err := json.Unmarshal(lastMessage, &conversation.LastMessage); err != nil {
+			return nil, err
+		}
+	}
+	return &conversation, nil
+}
+
+// CreateConversation starts a conversation between the participants and returns
+// its id. A one-to-one conversation is only created once: starting it again
+// returns the existing one.
+func (m *messageRepository) CreateConversation(ctx context.Context, conversation *domain.Conversation, participantIds []string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	ids, err := json.Marshal(participantIds)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := m.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback()
+
+	// DO UPDATE rather than DO NOTHING so the existing conversation's id is returned.
+	query := `INSERT INTO conversations (direct_key, created_by) VALUES ($1, $2)
+		ON CONFLICT (direct_key) DO UPDATE SET direct_key = EXCLUDED.direct_key
+		RETURNING id`
+
+	var id string
+	if err := tx.QueryRowContext(ctx, query, conversation.DirectKey, conversation.CreatedBy).Scan(&id); err != nil {
+		return "", err
+	}
+
+	participants := `INSERT INTO conversation_participants (conversation_id, user_id)
+		SELECT $1, t.value::uuid FROM JSON_ARRAY_ELEMENTS_TEXT($2::json) t
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, participants, id, ids); err != nil {
+		return "", err
+	}
+	return id, tx.Commit()
+}
+
+// GetConversation returns the conversation if the user takes part in it. It
+// reads from the primary, as it is used right after starting a conversation.
+func (m *messageRepository) GetConversation(ctx context.Context, userId, id string) (*domain.Conversation, error) {
+	query := `SELECT ` + conversationColumns + `
+		FROM conversations c JOIN conversation_participants p ON p.conversation_id = c.id AND p.user_id = $1
+		WHERE c.id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	conversation, err := scanConversation(m.dbWrite.QueryRowContext(ctx, query, userId, id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return conversation, nil
+}
+
+// ListConversations returns the user's conversations with the latest activity
+// first. A conversation the user deleted is listed again once a new message
+// arrives.
+func (m *messageRepository) ListConversations(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Conversation, error) {
+	query := `SELECT ` + conversationColumns + `
+		FROM conversations c JOIN conversation_participants p ON p.conversation_id = c.id
+		WHERE p.user_id = $1 AND (p.cleared_at IS NULL OR c.updated_at > p.cleared_at)
+		AND ($2::timestamptz IS NULL OR (c.updated_at, c.id) < ($2, $3::uuid))
+		ORDER BY c.updated_at DESC, c.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := m.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var conversations []*domain.Conversation
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, conversation)
+	}
+	return conversations, rows.Err()
+}
+
+// DeleteConversation deletes the conversation for the user only: the messages
+// sent so far are hidden from them, while the other participants keep theirs.
+func (m *messageRepository) DeleteConversation(ctx context.Context, userId, id string) error {
+	query := `UPDATE conversation_participants SET cleared_at = NOW() WHERE conversation_id = $1 AND user_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := m.dbWrite.ExecContext(ctx, query, id, userId)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+// CreateMessage stores the message, moves the conversation up the participants'
+// lists and marks the message read by its sender.
+func (m *messageRepository) CreateMessage(ctx context.Context, message *domain.Message) (*domain.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := m.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO messages (conversation_id, sender_id, body) VALUES ($1, $2, $3) RETURNING id, created_at`
+	if err := tx.QueryRowContext(ctx, query, message.ConversationId, message.SenderId, message.Body).Scan(&message.Id, &message.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE conversations SET updated_at = $2 WHERE id = $1`, message.ConversationId, message.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	read := `UPDATE conversation_participants SET last_read_message_id = $3, read_at = $4
+		WHERE conversation_id = $1 AND user_id = $2`
+	if _, err := tx.ExecContext(ctx, read, message.ConversationId, message.SenderId, message.Id, message.CreatedAt); err != nil {
+		return nil, err
+	}
+	return message, tx.Commit()
+}
+
+// ListMessages returns the conversation's messages as seen by the user, newest
+// first, so that following the cursor pages back through the history.
+func (m *messageRepository) ListMessages(ctx context.Context, userId, conversationId string, cursor *domain.Cursor, limit int) ([]*domain.Message, error) {
+	query := `SELECT m.id, m.conversation_id, m.sender_id, m.body, m.created_at, u.id, u.username, u.created_at
+		FROM messages m
+		JOIN conversation_participants p ON p.conversation_id = m.conversation_id AND p.user_id = $1
+		JOIN users u ON u.id = m.sender_id
+		WHERE m.conversation_id = $2 AND ` + visibleMessages + `
+		AND ($3::timestamptz IS NULL OR (m.created_at, m.id) < ($3, $4::uuid))
+		ORDER BY m.created_at DESC, m.id DESC
+		LIMIT $5`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := m.dbRead.QueryContext(ctx, query, userId, conversationId, cursorTime, cursorId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		message := &domain.Message{Sender: &domain.User{}}
+		if err := rows.Scan(
+			&message.Id,
+			&message.ConversationId,
+			&message.SenderId,
+			&message.Body,
+			&message.CreatedAt,
+			&message.Sender.Id,
+			&message.Sender.Username,
+			&message.Sender.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// MarkRead moves the user's read cursor to the message. The cursor only moves
+// forward: reading an older message returns no receipt.
+func (m *messageRepository) MarkRead(ctx context.Context, userId, conversationId, messageId string) (*domain.ReadReceipt, error) {
+	query := `UPDATE conversation_participants p SET last_read_message_id = m.id, read_at = NOW()
+		FROM messages m
+		WHERE p.conversation_id = $1 AND p.user_id = $2 AND m.id = $3 AND m.conversation_id = p.conversation_id
+		AND NOT EXISTS (SELECT 1 FROM messages r WHERE r.id = p.last_read_message_id AND (r.created_at, r.id) >= (m.created_at, m.id))
+		RETURNING p.read_at`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	receipt := &domain.ReadReceipt{ConversationId: conversationId, UserId: userId, MessageId: messageId}
+	err := m.dbWrite.QueryRowContext(ctx, query, conversationId, userId, messageId).Scan(&receipt.ReadAt)
+	if err == nil {
+		return receipt, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+
+	exists := `SELECT EXISTS (
+			SELECT 1 FROM messages m JOIN conversation_participants p ON p.conversation_id = m.conversation_id AND p.user_id = $2
+			WHERE m.id = $3 AND m.conversation_id = $1
+		)`
+	var found bool
+	if err := m.dbWrite.QueryRowContext(ctx, exists, conversationId, userId, messageId).Scan(&found); err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, customErr.ErrNotFound
+	}
+	return nil, nil
+}
+
+func (m *messageRepository) GetPrivacy(ctx context.Context, userId string) (domain.MessagePrivacy, error) {
+	query := `SELECT message_privacy FROM users WHERE id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var privacy domain.MessagePrivacy
+	if err := m.dbRead.QueryRowContext(ctx, query, userId).Scan(&privacy); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return "", customErr.ErrNotFound
+		default:
+			return "", err
+		}
+	}
+	return privacy, nil
+}
+
+func (m *messageRepository) SetPrivacy(ctx context.Context, userId string, privacy domain.MessagePrivacy) error {
+	query := `UPDATE users SET message_privacy = $2 WHERE id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := m.dbWrite.ExecContext(ctx, query, userId, privacy)
+	return err
+}
+
+func NewMessageRepository(dbWrite, dbRead *sql.DB) MessageRepository {
+	return &messageRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
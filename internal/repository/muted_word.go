@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/lib/pq"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type MutedWordRepository interface {
+	Upsert(ctx context.Context, word *domain.MutedWord) (*domain.MutedWord, error)
+	List(ctx context.Context, userId string) ([]*domain.MutedWord, error)
+	Delete(ctx context.Context, userId, id string) error
+}
+
+type mutedWordRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// mutedWordUnspaced lists, as the inside of a bracket expression, the scripts
+// written without spaces between words: Thai, Lao, Myanmar, Khmer, kana and the
+// CJK ideographs. Words cannot be told apart there, so a muted phrase in those
+// scripts matches anywhere, and so does a phrase standing next to them.
+const mutedWordUnspaced = "\u0e00-\u0eff\u1000-\u109f\u1780-\u17ff" +
+	"\u3040-\u30ff\u3400-\u4dbf\u4e00-\u9fff\uf900-\ufaff\uff66-\uff9f" +
+	"\U00020000-\U0003134f"
+
+// mutedWordPattern is the SQL expression compiling the muted phrase to the
+// regular expression posts are matched against with ~*, when they are read.
+// The phrase must stand as whole words: at the ends of the post or next to a
+// character that is not a letter, a digit or an underscore, unless the phrase
+// or its neighbour is in mutedWordUnspaced. Letters and digits are those of the
+// database locale, which must be a UTF-8 one for "caf" to leave "café" alone.
+// The metacharacters of the phrase are escaped and its words may be separated
+// by any whitespace, phrases being stored with single spaces.
+func mutedWordPattern(phrase string) string {
+	boundary := `[^[:alnum:]_]|[` + mutedWordUnspaced + `]`
+	return `(CASE WHEN ` + phrase + ` ~ '^[` + mutedWordUnspaced + `]' THEN '' ELSE '(^|` + boundary + `)' END
+		|| REPLACE(REGEXP_REPLACE(` + phrase + `, '([\\.+*?()|[\]{}^$])', '\\\1', 'g'), ' ', '\s+')
+		|| CASE WHEN ` + phrase + ` ~ '[` + mutedWordUnspaced + `]$' THEN '' ELSE '($|` + boundary + `)' END)`
+}
+
+// Upsert mutes the phrase for the user, or updates the scopes and expiry of the
+// phrase if it is already muted.
+func (m *mutedWordRepository) Upsert(ctx context.Context, word *domain.MutedWord) (*domain.MutedWord, error) {
+	query := `INSERT INTO muted_words (user_id, phrase, scopes, expires_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, phrase) DO UPDATE SET scopes = EXCLUDED.scopes, expires_at = EXCLUDED.expires_at
+		RETURNING id, created_at`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	scopes := make([]string, 0, len(word.Scopes))
+	for _, scope := range word.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	if err := m.dbWrite.QueryRowContext(ctx, query, word.UserId, word.Phrase, pq.Array(scopes), word.ExpiresAt).Scan(&word.Id, &word.CreatedAt); err != nil {
+		return nil, err
+	}
+	return word, nil
+}
+
+// List returns the words the user muted that have not expired, most recent first.
+func (m *mutedWordRepository) List(ctx context.Context, userId string) ([]*domain.MutedWord, error) {
+	query := `SELECT id, user_id, phrase, scopes, expires_at, created_at
+		FROM muted_words
+		WHERE user_id = $1 AND (expires_at IS NULL OR expires_at > NOW())
+		ORDER BY created_at DESC, id DESC`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := m.dbRead.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var words []*domain.MutedWord
+	for rows.Next() {
+		var (
+			word   domain.MutedWord
+			scopes []string
+		)
+		if err := rows.Scan(&word.Id, &word.UserId, &word.Phrase, pq.Array(&scopes), &word.ExpiresAt, &word.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		for _, scope := range scopes {
+			word.Scopes = append(word.Scopes, domain.MutedWordScope(scope))
+		}
+		words = append(words, &word)
+	}
+	return words, rows.Err()
+}
+
+func (m *mutedWordRepository) Delete(ctx context.Context, userId, id string) error {
+	query := `DELETE FROM muted_words WHERE id = $1 AND user_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := m.dbWrite.ExecContext(ctx, query, id, userId)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+func NewMutedWordRepository(dbWrite, dbRead *sql.DB) MutedWordRepository {
+	return &mutedWordRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
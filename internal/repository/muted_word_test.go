@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// TestNotMutedWords has alice mute one phrase at a time and checks against
+// PostgreSQL whether bob's post hides it from her home timeline.
+func TestNotMutedWords(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	words := NewMutedWordRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+
+	testCases := []struct {
+		phrase string
+		body   string
+		muted  bool
+	}{
+		{phrase: "cat", body: "my cat sleeps", muted: true},
+		{phrase: "cat", body: "CAT!", muted: true},
+		{phrase: "cat", body: "concatenate", muted: false},
+		{phrase: "cat", body: "cat_lover", muted: false},
+		{phrase: "cat", body: "#cat", muted: true},
+		{phrase: "#cat", body: "cat", muted: false},
+		{phrase: "game of thrones", body: "Game  of\nThrones tonight", muted: true},
+		{phrase: "game of thrones", body: "game of throne", muted: false},
+		{phrase: "c++", body: "learning c++ today", muted: true},
+		{phrase: "c++", body: "learning cxx today", muted: false},
+		{phrase: "a.b", body: "axb", muted: false},
+		{phrase: "caf", body: "un café", muted: false},
+		{phrase: "café", body: "un café, merci", muted: true},
+		{phrase: "ünlü", body: "ünlüğü", muted: false},
+		{phrase: "ünlü", body: "«ünlü»", muted: true},
+		{phrase: "кот", body: "котик", muted: false},
+		{phrase: "кот", body: "мой кот спит", muted: true},
+		{phrase: "tea", body: "tea\u00a0time", muted: true},
+		{phrase: "tea", body: "tea\u061fcoffee", muted: true},
+		{phrase: "猫", body: "我的猫很可爱", muted: true},
+		{phrase: "แมว", body: "ฉันรักแมวของฉัน", muted: true},
+		{phrase: "iphone", body: "新しいiPhoneを買った", muted: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.phrase+"/"+tc.body, func(t *testing.T) {
+			word, err := words.Upsert(ctx, &domain.MutedWord{UserId: alice, Phrase: tc.phrase, Scopes: []domain.MutedWordScope{domain.MutedWordHome}})
+			require.NoError(t, err)
+			t.Cleanup(func() { require.NoError(t, words.Delete(ctx, alice, word.Id)) })
+
+			post, err := posts.Create(ctx, &domain.Post{AuthorId: bob, Body: tc.body})
+			require.NoError(t, err)
+
+			var shown bool
+			query := `SELECT ` + notMutedWords("$1::uuid", domain.MutedWordHome, "$2::uuid")
+			require.NoError(t, db.QueryRowContext(ctx, query, alice, post.Id).Scan(&shown))
+			require.Equal(t, tc.muted, !shown)
+		})
+	}
+}
+
+// TestMutedWords_Listings has alice mute words in different scopes and checks
+// the home timeline, replies and notifications she reads.
+func TestMutedWords_Listings(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	follows := NewFollowRepository(db, db)
+	timelines := NewTimelineRepository(db, db)
+	notifications := NewNotificationRepository(db, db)
+	words := NewMutedWordRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+	_, err := follows.Follow(ctx, alice, bob)
+	require.NoError(t, err)
+
+	post := func(authorId, body string, parent *domain.Post) *domain.Post {
+		p := &domain.Post{AuthorId: authorId, Body: body}
+		if parent != nil {
+			p.ParentId, p.ConversationId, p.Depth = &parent.Id, parent.ConversationId, parent.Depth+1
+		}
+		p, err := posts.Create(ctx, p)
+		require.NoError(t, err)
+		require.NoError(t, timelines.Add(ctx, p))
+		return p
+	}
+	root := post(alice, "what are you watching?", nil)
+	post(bob, "Ünlü dizi finali bu akşam", nil)
+	post(bob, "ünlüğü", nil)
+	post(bob, "café au lait", nil)
+	post(bob, "ÇAY time", root)
+	post(bob, "coffee time", root)
+	mention := post(bob, "Spoilers for @alice", nil)
+	post(alice, "my own dizi", nil)
+	fanOutPending(t, timelines)
+
+	_, err = notifications.Create(ctx, &domain.NotificationEvent{Type: domain.NotificationMention, RecipientId: alice, ActorId: bob, PostId: &mention.Id, CreatedAt: time.Now()}, "mention:"+mention.Id)
+	require.NoError(t, err)
+
+	home := func() []string {
+		entries, err := timelines.ListHome(ctx, alice, nil, 20)
+		require.NoError(t, err)
+		var bodies []string
+		for _, entry := range entries {
+			bodies = append(bodies, entry.Post.Body)
+		}
+		return bodies
+	}
+	require.Len(t, home(), 8)
+
+	expired := time.Now().Add(-time.Minute)
+	for _, word := range []*domain.MutedWord{
+		{UserId: alice, Phrase: "dizi", Scopes: []domain.MutedWordScope{domain.MutedWordHome}},
+		{UserId: alice, Phrase: "ünlü", Scopes: []domain.MutedWordScope{domain.MutedWordHome}},
+		{UserId: alice, Phrase: "caf", Scopes: []domain.MutedWordScope{domain.MutedWordHome}},
+		{UserId: alice, Phrase: "çay", Scopes: []domain.MutedWordScope{domain.MutedWordReplies}},
+		{UserId: alice, Phrase: "spoilers", Scopes: []domain.MutedWordScope{domain.MutedWordNotifications}},
+		{UserId: alice, Phrase: "coffee", Scopes: domain.MutedWordScopes, ExpiresAt: &expired},
+	} {
+		_, err := words.Upsert(ctx, word)
+		require.NoError(t, err)
+	}
+
+	t.Run("home timeline", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"ünlüğü", "café au lait", "ÇAY time", "coffee time", "Spoilers for @alice", "my own dizi", "what are you watching?"}, home())
+	})
+
+	t.Run("replies", func(t *testing.T) {
+		replies, err := posts.ListReplies(ctx, alice, root.Id, nil, 10, 10, 1)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		require.Equal(t, "coffee time", replies[0].Body)
+	})
+
+	t.Run("notifications", func(t *testing.T) {
+		list, err := notifications.List(ctx, alice, nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, list)
+	})
+
+	t.Run("listing skips expired words", func(t *testing.T) {
+		list, err := words.List(ctx, alice)
+		require.NoError(t, err)
+		require.Len(t, list, 5)
+	})
+}
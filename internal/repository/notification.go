@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+// notificationActorsShown is how many of the latest actors a notification is
+// listed with; the rest are only counted.
+const notificationActorsShown = 3
+
+type NotificationRepository interface {
+	Create(ctx context.Context, event *domain.NotificationEvent, groupKey string) (bool, error)
+	List(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Notification, error)
+	CountUnread(ctx context.Context, userId string) (int, error)
+	MarkRead(ctx context.Context, userId, id string) error
+	MarkAllRead(ctx context.Context, userId string) error
+	ListMutedTypes(ctx context.Context, userId string) ([]domain.NotificationType, error)
+	SetMutedTypes(ctx context.Context, userId string, types []domain.NotificationType) error
+}
+
+type notificationRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// visibleNotifications filters out notifications of muted types, those about
+// deleted posts or posts of protected accounts the recipient does not follow,
+// those whose every actor is hidden from the recipient and those about someone
+// else's post containing a word the recipient muted in notifications; the
+// notifications themselves must be aliased n.
+var visibleNotifications = `n.actor_count > 0
+	AND NOT EXISTS (SELECT 1 FROM notification_mutes m WHERE m.user_id = n.user_id AND m.type = n.type)
+	AND NOT EXISTS (SELECT 1 FROM posts p WHERE p.id = n.post_id AND p.deleted_at IS NOT NULL)
+	AND ` + postNotProtected("n.user_id", "n.post_id") + `
+	AND EXISTS (SELECT 1 FROM notification_actors va WHERE va.notification_id = n.id AND ` + notHidden("n.user_id", "va.actor_id") + `)
+	AND ` + notMutedWords("n.user_id", domain.MutedWordNotifications, "n.post_id")
+
+// Create adds the event to the recipient's unread notification with the same
+// group key, or starts a new one. An actor is counted once per notification, so
+// liking, unliking and liking again does not inflate "and 12 others". Events of
+// a type the recipient muted, from an account hidden from the recipient, or for
+// a deactivated recipient, are dropped; Create reports whether the event was
+// stored.
+func (n *notificationRepository) Create(ctx context.Context, event *domain.NotificationEvent, groupKey string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := n.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// DO UPDATE rather than DO NOTHING so the existing notification's id is returned.
+	upsert := `INSERT INTO notifications (user_id, type, post_id, group_key, created_at, updated_at)
+		SELECT $1::uuid, $2::varchar, $3::uuid, $4::text, $5::timestamptz, $5::timestamptz
+		WHERE EXISTS (SELECT 1 FROM users u WHERE u.id = $1::uuid AND u.deleted_at IS NULL)
+		AND NOT EXISTS (SELECT 1 FROM notification_mutes m WHERE m.user_id = $1::uuid AND m.type = $2::varchar)
+		AND ` + notHidden("$1::uuid", "$6::uuid") + `
+		ON CONFLICT (user_id, group_key) WHERE read_at IS NULL DO UPDATE SET updated_at = notifications.updated_at
+		RETURNING id`
+
+	var id string
+	if err := tx.QueryRowContext(ctx, upsert, event.RecipientId, event.Type, event.PostId, groupKey, event.CreatedAt, event.ActorId).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	actor := `WITH actor AS (
+			INSERT INTO notification_actors (notification_id, actor_id, created_at) VALUES ($1, $2, $3)
+			ON CONFLICT DO NOTHING
+			RETURNING notification_id
+		)
+		UPDATE notifications SET actor_count = actor_count + 1, updated_at = GREATEST(updated_at, $3)
+		WHERE id IN (SELECT notification_id FROM actor)`
+	if _, err := tx.ExecContext(ctx, actor, id, event.ActorId, event.CreatedAt); err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
+
+// List returns the user's notifications, newest first. They are ordered by when
+// they were created, which never changes, so a grouped notification stays in
+// place when another actor joins it and paging neither skips nor repeats it.
+// Once it is read, the next event starts a new notification at the top.
+func (n *notificationRepository) List(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Notification, error) {
+	query := `SELECT n.id, n.user_id, n.type, n.post_id, n.actor_count, n.read_at, n.created_at, n.updated_at,
+			(SELECT COALESCE(JSON_AGG(JSON_BUILD_OBJECT('id', a.id, 'username', a.username, 'created_at', a.created_at) ORDER BY a.acted_at DESC), '[]')
+			FROM (
+				SELECT u.id, u.username, u.created_at, na.created_at AS acted_at
+				FROM notification_actors na JOIN users u ON u.id = na.actor_id
+				WHERE na.notification_id = n.id AND u.deleted_at IS NULL AND ` + notHidden("n.user_id", "na.actor_id") + `
+				ORDER BY na.created_at DESC
+				LIMIT $5
+			) a)
+		FROM notifications n
+		WHERE n.user_id = $1 AND ` + visibleNotifications + `
+		AND ($2::timestamptz IS NULL OR (n.created_at, n.id) < ($2, $3::uuid))
+		ORDER BY n.created_at DESC, n.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := n.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit, notificationActorsShown)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*domain.Notification
+	for rows.Next() {
+		var (
+			notification domain.Notification
+			actors       []byte
+		)
+		if err := rows.Scan(
+			&notification.Id,
+			&notification.UserId,
+			&notification.Type,
+			&notification.PostId,
+			&notification.ActorCount,
+			&notification.ReadAt,
+			&notification.CreatedAt,
+			&notification.UpdatedAt,
+			&actors,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(actors, &notification.Actors); err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, &notification)
+	}
+	return notifications, rows.Err()
+}
+
+func (n *notificationRepository) CountUnread(ctx context.Context, userId string) (int, error) {
+	query := `SELECT COUNT(*) FROM notifications n WHERE n.user_id = $1 AND n.read_at IS NULL AND ` + visibleNotifications
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var count int
+	if err := n.dbRead.QueryRowContext(ctx, query, userId).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// MarkRead marks one of the user's notifications read; marking it again keeps
+// the original read time.
+func (n *notificationRepository) MarkRead(ctx context.Context, userId, id string) error {
+	query := `UPDATE notifications SET read_at = COALESCE(read_at, NOW()) WHERE id = $1 AND user_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := n.dbWrite.ExecContext(ctx, query, id, userId)
+	if err != nil {
+		return err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+func (n *notificationRepository) MarkAllRead(ctx context.Context, userId string) error {
+	query := `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := n.dbWrite.ExecContext(ctx, query, userId)
+	return err
+}
+
+func (n *notificationRepository) ListMutedTypes(ctx context.Context, userId string) ([]domain.NotificationType, error) {
+	query := `SELECT type FROM notification_mutes WHERE user_id = $1 ORDER BY type`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := n.dbRead.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []domain.NotificationType
+	for rows.Next() {
+		var t domain.NotificationType
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
+// SetMutedTypes replaces the notification types the user muted.
+func (n *notificationRepository) SetMutedTypes(ctx context.Context, userId string, types []domain.NotificationType) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	encoded, err := json.Marshal(types)
+	if err != nil {
+		return err
+	}
+
+	tx, err := n.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM notification_mutes WHERE user_id = $1`, userId); err != nil {
+		return err
+	}
+
+	insert := `INSERT INTO notification_mutes (user_id, type)
+		SELECT $1, t.value FROM JSON_ARRAY_ELEMENTS_TEXT($2::json) t
+		ON CONFLICT DO NOTHING`
+	if _, err := tx.ExecContext(ctx, insert, userId, encoded); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func NewNotificationRepository(dbWrite, dbRead *sql.DB) NotificationRepository {
+	return &notificationRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
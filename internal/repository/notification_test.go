@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// TestNotification_Paging has a group of alice's notifications get a new actor
+// between two pages of her inbox and checks it is listed exactly once.
+func TestNotification_Paging(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	notifications := NewNotificationRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := ids[0], ids[1], ids[2], ids[3]
+
+	now := time.Now()
+	follow := func(actorId, groupKey string, at time.Time) {
+		t.Helper()
+		event := &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: alice, ActorId: actorId, CreatedAt: at}
+		stored, err := notifications.Create(ctx, event, groupKey)
+		require.NoError(t, err)
+		require.True(t, stored)
+	}
+	follow(bob, "older", now.Add(-2*time.Minute))
+	follow(carol, "newer", now.Add(-time.Minute))
+
+	first, err := notifications.List(ctx, alice, nil, 1)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+	require.Equal(t, carol, first[0].Actors[0].Id)
+
+	// The older group gets a new actor while alice reads the first page.
+	follow(dave, "older", now)
+
+	second, err := notifications.List(ctx, alice, &domain.Cursor{Time: first[0].CreatedAt, Id: first[0].Id}, 10)
+	require.NoError(t, err)
+	require.Len(t, second, 1, "the joined group is neither skipped nor repeated")
+	require.Equal(t, 2, second[0].ActorCount)
+	require.Equal(t, dave, second[0].Actors[0].Id)
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type PollRepository interface {
+	Vote(ctx context.Context, postId, userId string, position int) error
+	Finalize(ctx context.Context, limit int) (int, error)
+	Announce(ctx context.Context, limit int, now time.Time) (*domain.PollAnnouncement, error)
+}
+
+type pollRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// createPoll stores the poll of a post being created and reads it back as its
+// author sees it.
+func createPoll(ctx context.Context, tx *sql.Tx, post *domain.Post) error {
+	if _, err := tx.ExecContext(ctx, `INSERT INTO polls (post_id, closes_at) VALUES ($1, $2)`, post.Id, post.Poll.ClosesAt); err != nil {
+		return err
+	}
+
+	options, err := json.Marshal(post.Poll.Options)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO poll_options (post_id, position, text)
+		SELECT $1, o.position, o.text FROM JSON_TO_RECORDSET($2::json) AS o(position INT, text TEXT)`
+	if _, err := tx.ExecContext(ctx, query, post.Id, options); err != nil {
+		return err
+	}
+
+	var stored []byte
+	if err := tx.QueryRowContext(ctx, `SELECT pp.poll FROM posts p `+postPoll("p.author_id")+` WHERE p.id = $1`, post.Id).Scan(&stored); err != nil {
+		return err
+	}
+	return json.Unmarshal(stored, &post.Poll)
+}
+
+// Vote records the user's vote for the option at position and counts it. The
+// primary key of poll_votes allows a single vote per user, so voting again is
+// customErr.ErrAlreadyVoted whatever the option. Voting on a closed poll or for
+// an option the poll does not have is a validation error.
+func (p *pollRepository) Vote(ctx context.Context, postId, userId string, position int) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := p.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the poll keeps Finalize from recounting it while the vote is
+	// being counted; Finalize skips it until the next pass instead.
+	var open bool
+	if err := tx.QueryRowContext(ctx, `SELECT closes_at > NOW() AND finalized_at IS NULL FROM polls WHERE post_id = $1 FOR NO KEY UPDATE`, postId).Scan(&open); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return customErr.ErrNotFound
+		}
+		return err
+	}
+	if !open {
+		return fmt.Errorf("%w: the poll is closed", customErr.ErrValidation)
+	}
+
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM poll_options WHERE post_id = $1 AND position = $2)`, postId, position).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: the poll has no option (%d)", customErr.ErrValidation, position)
+	}
+
+	res, err := tx.ExecContext(ctx, `INSERT INTO poll_votes (post_id, user_id, position) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`, postId, userId, position)
+	if err != nil {
+		return err
+	}
+
+	created, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if created == 0 {
+		return customErr.ErrAlreadyVoted
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE poll_options SET vote_count = vote_count + 1 WHERE post_id = $1 AND position = $2`, postId, position); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE polls SET vote_count = vote_count + 1 WHERE post_id = $1`, postId); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Finalize closes up to limit polls past their closing time: it recounts their
+// tallies from the votes and marks them finalized, returning how many it
+// finalized. Polls locked by a vote or by another instance are left for the
+// next call. Announcing the results is left to Announce.
+func (p *pollRepository) Finalize(ctx context.Context, limit int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := p.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	due := `SELECT post_id FROM polls
+		WHERE finalized_at IS NULL AND closes_at <= NOW()
+		ORDER BY closes_at
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED`
+	postIds, err := queryIds(ctx, tx, due, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(postIds) == 0 {
+		return 0, tx.Commit()
+	}
+
+	recount := `UPDATE poll_options o SET vote_count = (
+			SELECT COUNT(*) FROM poll_votes v WHERE v.post_id = o.post_id AND v.position = o.position
+		)
+		WHERE o.post_id = ANY($1::uuid[])`
+	if _, err := tx.ExecContext(ctx, recount, pq.Array(postIds)); err != nil {
+		return 0, err
+	}
+
+	finalize := `UPDATE polls pl SET finalized_at = NOW(),
+			vote_count = (SELECT COUNT(*) FROM poll_votes v WHERE v.post_id = pl.post_id)
+		WHERE pl.post_id = ANY($1::uuid[])`
+	if _, err := tx.ExecContext(ctx, finalize, pq.Array(postIds)); err != nil {
+		return 0, err
+	}
+	return len(postIds), tx.Commit()
+}
+
+// Announce stores the "poll closed" notifications of the next limit voters of
+// the earliest finalized poll not fully announced yet, and of its author along
+// with the first page. The notifications and the progress of the announcement
+// are committed together, so a failed page is retried and no voter is told
+// twice. Notifications follow the rules of NotificationRepository.Create and
+// are grouped by post like the events of the notification service. Announce
+// returns nil once every finalized poll was announced.
+func (p *pollRepository) Announce(ctx context.Context, limit int, now time.Time) (*domain.PollAnnouncement, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := p.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	pending := `SELECT pl.post_id, p.author_id, pl.announced_through
+		FROM polls pl
+		JOIN posts p ON p.id = pl.post_id
+		WHERE pl.finalized_at IS NOT NULL AND pl.announced_at IS NULL
+		ORDER BY pl.finalized_at
+		LIMIT 1
+		FOR UPDATE OF pl SKIP LOCKED`
+	var (
+		announcement = domain.PollAnnouncement{CreatedAt: now}
+		through      sql.NullString
+	)
+	if err := tx.QueryRowContext(ctx, pending).Scan(&announcement.PostId, &announcement.AuthorId, &through); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	page := `SELECT user_id FROM poll_votes
+		WHERE post_id = $1 AND ($2::uuid IS NULL OR user_id > $2)
+		ORDER BY user_id
+		LIMIT $3`
+	voterIds, err := queryIds(ctx, tx, page, announcement.PostId, through, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	recipientIds := voterIds
+	if !through.Valid {
+		recipientIds = append([]string{announcement.AuthorId}, voterIds...)
+	}
+
+	// Sibling statements do not see each other's rows, but foreign keys are
+	// checked at the end of the statement, once the notifications exist.
+	store := `WITH stored AS (
+			INSERT INTO notifications (user_id, type, post_id, group_key, actor_count, created_at, updated_at)
+			SELECT r.id, $3::varchar, $1::uuid, $3::varchar || ':' || $1::uuid::text, 1, $4::timestamptz, $4::timestamptz
+			FROM UNNEST($5::uuid[]) AS r(id)
+			JOIN users u ON u.id = r.id AND u.deleted_at IS NULL
+			WHERE NOT EXISTS (SELECT 1 FROM notification_mutes m WHERE m.user_id = r.id AND m.type = $3::varchar)
+			AND ` + notHidden("r.id", "$2::uuid") + `
+			ON CONFLICT (user_id, group_key) WHERE read_at IS NULL DO NOTHING
+			RETURNING id, user_id
+		), actors AS (
+			INSERT INTO notification_actors (notification_id, actor_id, created_at)
+			SELECT id, $2::uuid, $4::timestamptz FROM stored
+		)
+		SELECT user_id FROM stored`
+	announcement.RecipientIds, err = queryIds(ctx, tx, store, announcement.PostId, announcement.AuthorId, domain.NotificationPollClosed, now, pq.Array(recipientIds))
+	if err != nil {
+		return nil, err
+	}
+
+	progress := `UPDATE polls SET announced_through = COALESCE($2::uuid, announced_through),
+			announced_at = CASE WHEN $3::bool THEN NOW() END
+		WHERE post_id = $1`
+	var last *string
+	if len(voterIds) > 0 {
+		last = &voterIds[len(voterIds)-1]
+	}
+	if _, err := tx.ExecContext(ctx, progress, announcement.PostId, last, len(voterIds) < limit); err != nil {
+		return nil, err
+	}
+	return &announcement, tx.Commit()
+}
+
+// queryIds runs a query selecting a single column of ids.
+func queryIds(ctx context.Context, tx *sql.Tx, query string, args ...any) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func NewPollRepository(dbWrite, dbRead *sql.DB) PollRepository {
+	return &pollRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
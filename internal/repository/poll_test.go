@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPoll_Lifecycle(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	polls := NewPollRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol")
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	post, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "Tabs or spaces?", Poll: &domain.Poll{
+		ClosesAt: time.Now().Add(time.Hour),
+		Options:  []*domain.PollOption{{Position: 0, Text: "Tabs"}, {Position: 1, Text: "Spaces"}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, post.Poll.Options, 2)
+	require.Zero(t, *post.Poll.VoteCount)
+
+	poll := func(viewerId string) *domain.Poll {
+		t.Helper()
+		got, err := posts.GetById(ctx, viewerId, post.Id)
+		require.NoError(t, err)
+		return got.Poll
+	}
+
+	t.Run("one vote per user", func(t *testing.T) {
+		require.NoError(t, polls.Vote(ctx, post.Id, bob, 1))
+		require.ErrorIs(t, polls.Vote(ctx, post.Id, bob, 0), customErr.ErrAlreadyVoted)
+		require.ErrorIs(t, polls.Vote(ctx, post.Id, carol, 2), customErr.ErrValidation)
+	})
+
+	t.Run("tallies are hidden until the viewer voted", func(t *testing.T) {
+		hidden := poll(carol)
+		require.Nil(t, hidden.Vote)
+		require.Nil(t, hidden.VoteCount)
+		require.Nil(t, hidden.Options[1].VoteCount)
+
+		shown := poll(bob)
+		require.Equal(t, 1, *shown.Vote)
+		require.Equal(t, 1, *shown.Options[1].VoteCount)
+
+		require.Equal(t, 1, *poll(alice).VoteCount)
+	})
+
+	t.Run("closed polls are finalized once", func(t *testing.T) {
+		_, err := db.ExecContext(ctx, `UPDATE polls SET closes_at = NOW() - INTERVAL '1 minute' WHERE post_id = $1`, post.Id)
+		require.NoError(t, err)
+
+		require.ErrorIs(t, polls.Vote(ctx, post.Id, carol, 0), customErr.ErrValidation)
+		require.Equal(t, 0, *poll(carol).Options[0].VoteCount)
+
+		finalized, err := polls.Finalize(ctx, 10)
+		require.NoError(t, err)
+		require.Equal(t, 1, finalized)
+
+		finalized, err = polls.Finalize(ctx, 10)
+		require.NoError(t, err)
+		require.Zero(t, finalized)
+		require.NotNil(t, poll(carol).FinalizedAt)
+	})
+}
+
+// TestPoll_Announce has alice's poll closed with more voters than fit in a page
+// and checks each of them, and alice, is notified exactly once.
+func TestPoll_Announce(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	polls := NewPollRepository(db, db)
+	notifications := NewNotificationRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol", "dave")
+	alice, voters := ids[0], ids[1:]
+
+	post, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "Tabs or spaces?", Poll: &domain.Poll{
+		ClosesAt: time.Now().Add(time.Hour),
+		Options:  []*domain.PollOption{{Position: 0, Text: "Tabs"}, {Position: 1, Text: "Spaces"}},
+	}})
+	require.NoError(t, err)
+
+	for _, voterId := range voters {
+		require.NoError(t, polls.Vote(ctx, post.Id, voterId, 0))
+	}
+
+	_, err = db.ExecContext(ctx, `UPDATE polls SET closes_at = NOW() - INTERVAL '1 minute' WHERE post_id = $1`, post.Id)
+	require.NoError(t, err)
+	_, err = polls.Finalize(ctx, 10)
+	require.NoError(t, err)
+
+	var recipients []string
+	for {
+		announcement, err := polls.Announce(ctx, 2, time.Now())
+		require.NoError(t, err)
+		if announcement == nil {
+			break
+		}
+		require.Equal(t, post.Id, announcement.PostId)
+		recipients = append(recipients, announcement.RecipientIds...)
+	}
+	require.ElementsMatch(t, ids, recipients)
+
+	for _, recipientId := range ids {
+		received, err := notifications.List(ctx, recipientId, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, received, 1)
+		require.Equal(t, domain.NotificationPollClosed, received[0].Type)
+		require.Equal(t, alice, received[0].Actors[0].Id)
+	}
+}
@@ -0,0 +1,433 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type PostRepository interface {
+	Create(ctx context.Context, post *domain.Post) (*domain.Post, error)
+	GetById(ctx context.Context, viewerId, id string) (*domain.Post, error)
+	ListByAuthor(ctx context.Context, viewerId, authorId string, cursor *domain.Cursor, limit int) ([]*domain.Post, error)
+	ListReplies(ctx context.Context, viewerId, parentId string, cursor *domain.Cursor, limit, childLimit, depth int) ([]*domain.Post, error)
+	ListAncestors(ctx context.Context, viewerId, id string) ([]*domain.Post, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type postRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+const postColumns = `p.id, p.author_id, p.body, p.parent_id, p.conversation_id, p.depth,
+	(SELECT COALESCE(SUM(c.count), 0) FROM post_like_counts c WHERE c.post_id = p.id),
+	(SELECT COALESCE(JSON_AGG(JSON_BUILD_OBJECT('type', e.type, 'start', e.start_offset, 'end', e.end_offset, 'text', e.text, 'user_id', e.user_id) ORDER BY e.start_offset), '[]')
+		FROM post_entities e WHERE e.post_id = p.id),
+	` + postMedia + `, pp.poll,
+	p.created_at, p.updated_at, p.deleted_at, u.id, u.username, u.protected, u.created_at,
+	p.quote_id, q.author_id, q.body, q.parent_id, q.conversation_id, q.created_at, q.deleted_at,
+	qu.id, qu.username, qu.created_at`
+
+// postJoins joins the tables postColumns reads from; the post itself must be
+// aliased p. A quoted post the viewer may not see, because of a block or
+// because its author is protected, is left out, and scanPost marks the quote
+// unavailable.
+func postJoins(viewer string) string {
+	return `JOIN users u ON u.id = p.author_id
+	LEFT JOIN posts q ON q.id = p.quote_id AND ` + notBlocked(viewer, "q.author_id") + ` AND ` + notProtected(viewer, "q.author_id") + `
+	LEFT JOIN users qu ON qu.id = q.author_id
+	` + postPoll(viewer)
+}
+
+// postPoll joins the poll of the post aliased p as a JSON object named pp.poll,
+// NULL for posts without one, decoded by scanPost. The tallies are left out
+// until the viewer voted or the poll closed, unless the viewer is the author.
+func postPoll(viewer string) string {
+	return `LEFT JOIN LATERAL (
+		SELECT JSON_BUILD_OBJECT('post_id', pl.post_id, 'closes_at', pl.closes_at, 'finalized_at', pl.finalized_at,
+			'vote', pv.position, 'vote_count', CASE WHEN r.shown THEN pl.vote_count END,
+			'options', (SELECT JSON_AGG(JSON_BUILD_OBJECT('position', o.position, 'text', o.text,
+					'vote_count', CASE WHEN r.shown THEN o.vote_count END) ORDER BY o.position)
+				FROM poll_options o WHERE o.post_id = pl.post_id)) AS poll
+		FROM polls pl
+		LEFT JOIN poll_votes pv ON pv.post_id = pl.post_id AND pv.user_id = ` + viewer + `
+		CROSS JOIN LATERAL (SELECT pv.position IS NOT NULL OR pl.closes_at <= NOW() OR pl.finalized_at IS NOT NULL OR p.author_id = ` + viewer + ` AS shown) r
+		WHERE pl.post_id = p.id
+	) pp ON TRUE`
+}
+
+func scanPost(scanner interface{ Scan(dest ...any) error }) (*domain.Post, error) {
+	post := domain.Post{Author: &domain.User{}}
+	var (
+		quoteAuthorId, quoteBody, quoteConversationId, quoteUserId, quoteUsername sql.NullString
+		quoteCreatedAt, quoteUserCreatedAt                                        sql.NullTime
+		quoteParentId                                                             *string
+		quoteDeletedAt                                                            *time.Time
+		entities, media, poll                                                     []byte
+	)
+	if err := scanner.Scan(
+		&post.Id,
+		&post.AuthorId,
+		&post.Body,
+		&post.ParentId,
+		&post.ConversationId,
+		&post.Depth,
+		&post.LikeCount,
+		&entities,
+		&media,
+		&poll,
+		&post.CreatedAt,
+		&post.UpdatedAt,
+		&post.DeletedAt,
+		&post.Author.Id,
+		&post.Author.Username,
+		&post.Author.Protected,
+		&post.Author.CreatedAt,
+		&post.QuoteId,
+		&quoteAuthorId,
+		&quoteBody,
+		&quoteParentId,
+		&quoteConversationId,
+		&quoteCreatedAt,
+		&quoteDeletedAt,
+		&quoteUserId,
+		&quoteUsername,
+		&quoteUserCreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(entities, &post.Entities); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(media, &post.Media); err != nil {
+		return nil, err
+	}
+
+	if poll != nil {
+		if err := json.Unmarshal(poll, &post.Poll); err != nil {
+			return nil, err
+		}
+	}
+
+	if post.QuoteId != nil && !quoteAuthorId.Valid {
+		post.Quote = &domain.Post{Id: *post.QuoteId, Unavailable: true}
+	} else if post.QuoteId != nil {
+		post.Quote = &domain.Post{
+			Id:             *post.QuoteId,
+			AuthorId:       quoteAuthorId.String,
+			Body:           quoteBody.String,
+			ParentId:       quoteParentId,
+			ConversationId: quoteConversationId.String,
+			CreatedAt:      quoteCreatedAt.Time,
+			DeletedAt:      quoteDeletedAt,
+			Author: &domain.User{
+				Id:        quoteUserId.String,
+				Username:  quoteUsername.String,
+				CreatedAt: quoteUserCreatedAt.Time,
+			},
+		}
+	}
+	return &post, nil
+}
+
+// prefixScanner scans the leading columns of a row into prefix and the remaining
+// ones into the destinations of a scan helper such as scanPost.
+type prefixScanner struct {
+	rows   *sql.Rows
+	prefix []any
+}
+
+func (p prefixScanner) Scan(dest ...any) error {
+	return p.rows.Scan(append(append([]any{}, p.prefix...), dest...)...)
+}
+
+func scanPosts(rows *sql.Rows) ([]*domain.Post, error) {
+	defer rows.Close()
+
+	var posts []*domain.Post
+	for rows.Next() {
+		post, err := scanPost(rows)
+		if err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// Create inserts the post together with its entities and poll, attaches its
+// media and counts it on its author. A post without a conversation id starts a
+// new conversation whose id is the post's own id. Media must have been uploaded
+// by the author and not be attached to another post yet.
+func (p *postRepository) Create(ctx context.Context, post *domain.Post) (*domain.Post, error) {
+	query := `WITH new_post AS (SELECT uuid_generate_v1() AS id)
+		INSERT INTO posts (id, author_id, body, parent_id, conversation_id, depth, quote_id)
+		SELECT id, $1::uuid, $2::text, $3::uuid, COALESCE($4::uuid, id), $5::int, $6::uuid FROM new_post
+		RETURNING id, conversation_id, created_at, updated_at`
+	var conversationId *string
+	if post.ConversationId != "" {
+		conversationId = &post.ConversationId
+	}
+	args := []any{post.AuthorId, post.Body, post.ParentId, conversationId, post.Depth, post.QuoteId}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := p.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&post.Id, &post.ConversationId, &post.CreatedAt, &post.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET post_count = post_count + 1 WHERE id = $1`, post.AuthorId); err != nil {
+		return nil, err
+	}
+
+	if len(post.Entities) > 0 {
+		entities, err := json.Marshal(post.Entities)
+		if err != nil {
+			return nil, err
+		}
+
+		query := `INSERT INTO post_entities (post_id, type, start_offset, end_offset, text, user_id)
+			SELECT $1, e.type, e.start, e."end", e.text, e.user_id
+			FROM JSON_TO_RECORDSET($2::json) AS e(type TEXT, start INT, "end" INT, text TEXT, user_id UUID)`
+		if _, err := tx.ExecContext(ctx, query, post.Id, entities); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(post.Media) > 0 {
+		if err := attachMedia(ctx, tx, post); err != nil {
+			return nil, err
+		}
+	}
+
+	if post.Poll != nil {
+		if err := createPoll(ctx, tx, post); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+func attachMedia(ctx context.Context, tx *sql.Tx, post *domain.Post) error {
+	media, err := json.Marshal(post.Media)
+	if err != nil {
+		return err
+	}
+
+	query := `UPDATE media m SET post_id = $1, position = a.position, alt_text = a.alt_text
+		FROM JSON_TO_RECORDSET($3::json) AS a(id UUID, position INT, alt_text TEXT)
+		WHERE m.id = a.id AND m.user_id = $2 AND m.post_id IS NULL`
+	res, err := tx.ExecContext(ctx, query, post.Id, post.AuthorId, media)
+	if err != nil {
+		return err
+	}
+
+	attached, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if attached != int64(len(post.Media)) {
+		return fmt.Errorf("%w: media not found or already attached", customErr.ErrValidation)
+	}
+
+	var stored []byte
+	if err := tx.QueryRowContext(ctx, `SELECT `+postMedia+` FROM posts p WHERE p.id = $1`, post.Id).Scan(&stored); err != nil {
+		return err
+	}
+	return json.Unmarshal(stored, &post.Media)
+}
+
+// GetById returns the post unless it was deleted, the viewer and the author
+// blocked one another or the author is protected from the viewer.
+func (p *postRepository) GetById(ctx context.Context, viewerId, id string) (*domain.Post, error) {
+	query := `SELECT ` + postColumns + ` FROM posts p ` + postJoins("$2::uuid") + `
+		WHERE p.id = $1 AND p.deleted_at IS NULL AND ` + notBlocked("$2::uuid", "p.author_id") + `
+		AND ` + notProtected("$2::uuid", "p.author_id")
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	post, err := scanPost(p.dbRead.QueryRowContext(ctx, query, id, viewerId))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return post, nil
+}
+
+// ListByAuthor returns up to limit posts of the author, newest first, starting
+// after cursor when one is given. It returns nothing when the viewer and the
+// author blocked one another or the author is protected from the viewer.
+func (p *postRepository) ListByAuthor(ctx context.Context, viewerId, authorId string, cursor *domain.Cursor, limit int) ([]*domain.Post, error) {
+	query := `SELECT ` + postColumns + ` FROM posts p ` + postJoins("$5::uuid") + `
+		WHERE p.author_id = $1 AND p.deleted_at IS NULL AND ` + notBlocked("$5::uuid", "p.author_id") + `
+		AND ` + notProtected("$5::uuid", "p.author_id") + `
+		AND ($2::timestamptz IS NULL OR (p.created_at, p.id) < ($2, $3::uuid))
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := p.dbRead.QueryContext(ctx, query, authorId, cursorTime, cursorId, limit, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// ListReplies returns the reply tree below parentId in a single round trip. The
+// first level is paginated with cursor and limit, oldest first; every deeper level
+// holds at most childLimit replies per parent, down to depth levels. Deleted
+// replies are kept as tombstones, while replies of accounts the viewer blocked,
+// was blocked by, muted or may not read because they are protected, and replies
+// containing a word the viewer muted in replies, are left out together with the
+// replies below them.
+// Rows are ordered by level, then time.
+func (p *postRepository) ListReplies(ctx context.Context, viewerId, parentId string, cursor *domain.Cursor, limit, childLimit, depth int) ([]*domain.Post, error) {
+	query := `WITH RECURSIVE tree AS (
+			(SELECT r.id, 1 AS level
+			FROM posts r
+			WHERE r.parent_id = $1 AND ` + notHidden("$7::uuid", "r.author_id") + `
+			AND ` + notProtected("$7::uuid", "r.author_id") + `
+			AND ` + notMutedWords("$7::uuid", domain.MutedWordReplies, "r.id") + `
+			AND ($2::timestamptz IS NULL OR (r.created_at, r.id) > ($2, $3::uuid))
+			ORDER BY r.created_at, r.id
+			LIMIT $4)
+			UNION ALL
+			SELECT c.id, t.level + 1
+			FROM tree t
+			CROSS JOIN LATERAL (
+				SELECT r.id FROM posts r
+				WHERE r.parent_id = t.id AND ` + notHidden("$7::uuid", "r.author_id") + `
+				AND ` + notProtected("$7::uuid", "r.author_id") + `
+				AND ` + notMutedWords("$7::uuid", domain.MutedWordReplies, "r.id") + `
+				ORDER BY r.created_at, r.id
+				LIMIT $5
+			) c
+			WHERE t.level < $6
+		)
+		SELECT ` + postColumns + `
+		FROM tree
+		JOIN posts p ON p.id = tree.id
+		` + postJoins("$7::uuid") + `
+		ORDER BY tree.level, p.created_at, p.id`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := p.dbRead.QueryContext(ctx, query, parentId, cursorTime, cursorId, limit, childLimit, depth, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+// ListAncestors returns the post with the given id preceded by every post it
+// replies to, root first. Deleted posts are included as tombstones, and so are
+// the posts of accounts the viewer blocked, was blocked by or may not read
+// because they are protected, which are marked unavailable.
+func (p *postRepository) ListAncestors(ctx context.Context, viewerId, id string) ([]*domain.Post, error) {
+	query := `WITH RECURSIVE chain AS (
+			SELECT id, parent_id, 0 AS distance FROM posts WHERE id = $1
+			UNION ALL
+			SELECT r.id, r.parent_id, c.distance + 1
+			FROM posts r
+			JOIN chain c ON r.id = c.parent_id
+		)
+		SELECT NOT (` + notBlocked("$2::uuid", "p.author_id") + ` AND ` + notProtected("$2::uuid", "p.author_id") + `), ` + postColumns + `
+		FROM chain
+		JOIN posts p ON p.id = chain.id
+		` + postJoins("$2::uuid") + `
+		ORDER BY chain.distance DESC`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := p.dbRead.QueryContext(ctx, query, id, viewerId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var posts []*domain.Post
+	for rows.Next() {
+		var unavailable bool
+		post, err := scanPost(prefixScanner{rows: rows, prefix: []any{&unavailable}})
+		if err != nil {
+			return nil, err
+		}
+		post.Unavailable = unavailable
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
+
+// Delete soft deletes the post so it disappears from every read while the row is
+// kept, no longer counts it on its author and drops the bookmarks of it.
+func (p *postRepository) Delete(ctx context.Context, id string) error {
+	query := `WITH deleted AS (
+			UPDATE posts SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL
+			RETURNING author_id
+		), unbookmarked AS (
+			DELETE FROM bookmarks b USING deleted WHERE b.post_id = $1
+		)
+		UPDATE users SET post_count = post_count - 1 FROM deleted WHERE users.id = deleted.author_id`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := p.dbWrite.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return customErr.ErrNotFound
+	}
+	return nil
+}
+
+func NewPostRepository(dbWrite, dbRead *sql.DB) PostRepository {
+	return &postRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestProtected_Follows has bob request to follow protected alice and checks
+// that her posts stay hidden from him until she approves.
+func TestProtected_Follows(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	users := NewUserRepository(db, db)
+	posts := NewPostRepository(db, db)
+	follows := NewFollowRepository(db, db)
+	timelines := NewTimelineRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := ids[0], ids[1], ids[2], ids[3]
+
+	protected := true
+	_, err := users.UpdateProfile(ctx, alice, &domain.ProfileUpdate{Protected: &protected})
+	require.NoError(t, err)
+
+	post, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "protected"})
+	require.NoError(t, err)
+	reply, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "reply", ParentId: &post.Id, ConversationId: post.ConversationId, Depth: 1})
+	require.NoError(t, err)
+	quote, err := posts.Create(ctx, &domain.Post{AuthorId: carol, Body: "quote", QuoteId: &post.Id})
+	require.NoError(t, err)
+
+	visible := func(viewerId string) bool {
+		t.Helper()
+		_, err := posts.GetById(ctx, viewerId, post.Id)
+		if err != nil {
+			require.ErrorIs(t, err, customErr.ErrNotFound)
+			return false
+		}
+
+		byAuthor, err := posts.ListByAuthor(ctx, viewerId, alice, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, byAuthor, 2)
+
+		replies, err := posts.ListReplies(ctx, viewerId, post.Id, nil, 10, 0, 1)
+		require.NoError(t, err)
+		require.Len(t, replies, 1)
+		require.Equal(t, reply.Id, replies[0].Id)
+
+		quoting, err := posts.GetById(ctx, viewerId, quote.Id)
+		require.NoError(t, err)
+		require.False(t, quoting.Quote.Unavailable)
+		return true
+	}
+
+	t.Run("only the author sees the posts", func(t *testing.T) {
+		require.True(t, visible(alice))
+		require.False(t, visible(bob))
+
+		byAuthor, err := posts.ListByAuthor(ctx, bob, alice, nil, 10)
+		require.NoError(t, err)
+		require.Empty(t, byAuthor)
+
+		quoting, err := posts.GetById(ctx, bob, quote.Id)
+		require.NoError(t, err)
+		require.True(t, quoting.Quote.Unavailable)
+	})
+
+	t.Run("following only requests", func(t *testing.T) {
+		outcome, err := follows.Follow(ctx, bob, alice)
+		require.NoError(t, err)
+		require.Equal(t, domain.FollowRequested, outcome)
+
+		outcome, err = follows.Follow(ctx, bob, alice)
+		require.NoError(t, err)
+		require.Equal(t, domain.FollowUnchanged, outcome)
+
+		relationship, err := follows.GetRelationship(ctx, bob, alice)
+		require.NoError(t, err)
+		require.True(t, relationship.Requested)
+		require.False(t, relationship.Following)
+
+		requests, err := follows.ListRequests(ctx, alice, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.Equal(t, bob, requests[0].FollowerId)
+		require.False(t, visible(bob))
+	})
+
+	t.Run("approved followers see the posts", func(t *testing.T) {
+		approved, err := follows.Approve(ctx, alice, bob)
+		require.NoError(t, err)
+		require.True(t, approved)
+
+		approved, err = follows.Approve(ctx, alice, bob)
+		require.NoError(t, err)
+		require.False(t, approved)
+
+		user, err := users.GetById(ctx, alice)
+		require.NoError(t, err)
+		require.Equal(t, 1, user.FollowerCount)
+		require.True(t, visible(bob))
+
+		require.NoError(t, timelines.Add(ctx, post))
+		fanOutPending(t, timelines)
+		home, err := timelines.ListHome(ctx, bob, nil, 10)
+		require.NoError(t, err)
+		require.Len(t, home, 1)
+	})
+
+	t.Run("rejected requests are dropped", func(t *testing.T) {
+		_, err := follows.Follow(ctx, carol, alice)
+		require.NoError(t, err)
+
+		rejected, err := follows.Reject(ctx, alice, carol)
+		require.NoError(t, err)
+		require.True(t, rejected)
+		require.False(t, visible(carol))
+	})
+
+	t.Run("going public approves pending requests", func(t *testing.T) {
+		_, err := follows.Follow(ctx, dave, alice)
+		require.NoError(t, err)
+
+		approved, err := follows.ApproveAll(ctx, alice)
+		require.NoError(t, err)
+		require.Empty(t, approved)
+
+		public := false
+		_, err = users.UpdateProfile(ctx, alice, &domain.ProfileUpdate{Protected: &public})
+		require.NoError(t, err)
+
+		approved, err = follows.ApproveAll(ctx, alice)
+		require.NoError(t, err)
+		require.Equal(t, []string{dave}, approved)
+		require.True(t, visible(carol))
+
+		user, err := users.GetById(ctx, alice)
+		require.NoError(t, err)
+		require.Equal(t, 2, user.FollowerCount)
+	})
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type RepostRepository interface {
+	Create(ctx context.Context, repost *domain.Repost) (bool, error)
+	Delete(ctx context.Context, userId, postId string) (bool, error)
+}
+
+type repostRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// Create records the repost and reports whether it is new; reposting twice is a
+// no-op that leaves the original repost time in place.
+func (r *repostRepository) Create(ctx context.Context, repost *domain.Repost) (bool, error) {
+	query := `INSERT INTO reposts (user_id, post_id) VALUES ($1, $2) ON CONFLICT DO NOTHING RETURNING created_at`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.dbWrite.QueryRowContext(ctx, query, repost.UserId, repost.PostId).Scan(&repost.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete removes the repost and reports whether there was one.
+func (r *repostRepository) Delete(ctx context.Context, userId, postId string) (bool, error) {
+	query := `DELETE FROM reposts WHERE user_id = $1 AND post_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	res, err := r.dbWrite.ExecContext(ctx, query, userId, postId)
+	if err != nil {
+		return false, err
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+func NewRepostRepository(dbWrite, dbRead *sql.DB) RepostRepository {
+	return &repostRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"github.com/lib/pq"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"log/slog"
+	"time"
+)
+
+// streamChannel is the LISTEN/NOTIFY channel announcing new stream events. The
+// payload only says whom to wake, "user:<id>" or "author:<id>"; the events
+// themselves are read from stream_events.
+const streamChannel = "stream_events"
+
+// streamSettled matches the events e whose publishing transaction is older than
+// every transaction still running: no event can be committed before them in
+// stream order anymore, so a reader moving past them never skips one. Events are
+// read in stream order, e.xact_id then e.id.
+const streamSettled = `e.xact_id < pg_snapshot_xmin(pg_current_snapshot())`
+
+// streamAfter matches the events e following the position ($2, $3) in stream
+// order. The position is kept by the reader rather than looked up from an event
+// id, as the event may have been pruned since.
+const streamAfter = `(e.xact_id, e.id) > ($2::xid8, $3)`
+
+type StreamRepository interface {
+	Publish(ctx context.Context, event *domain.StreamEvent) error
+	ListAfter(ctx context.Context, userId string, after domain.StreamPosition, limit int) ([]*domain.StreamEvent, error)
+	CountAfter(ctx context.Context, userId string, after domain.StreamPosition, limit int) (int, error)
+	Bounds(ctx context.Context) (int64, domain.StreamPosition, error)
+	Position(ctx context.Context, id int64) (domain.StreamPosition, error)
+	ListFollowers(ctx context.Context, authorId string, userIds []string) ([]string, error)
+	Prune(ctx context.Context, before time.Time) error
+}
+
+type streamRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// streamAudience matches the events the user $1 receives: their own, and the
+// broadcasts of themselves and the accounts they follow unless hidden from them.
+// Timeline items and notifications about a post containing a word the user
+// muted there are left out, as they are from the home timeline and the
+// notifications, and so are events about a post of a protected account the
+// user does not follow.
+var streamAudience = `((e.user_id = $1
+	OR ((e.author_id = $1 OR e.author_id IN (SELECT f.followee_id FROM follows f WHERE f.follower_id = $1))
+	AND ` + notHidden("$1::uuid", "e.author_id") + `))
+	AND (e.type <> '` + string(domain.StreamTimeline) + `' OR ` + notMutedWords("$1::uuid", domain.MutedWordHome, "(e.payload->>'post_id')::uuid") + `)
+	AND (e.type <> '` + string(domain.StreamNotification) + `' OR ` + notMutedWords("$1::uuid", domain.MutedWordNotifications, "(e.payload->>'post_id')::uuid") + `)
+	AND ` + postNotProtected("$1::uuid", "(e.payload->>'post_id')::uuid") + `)`
+
+// Publish stores the event and wakes the subscribers on every instance once the
+// transaction commits. Publishers do not wait for one another; readers keep
+// events in order instead, see streamSettled.
+func (s *streamRepository) Publish(ctx context.Context, event *domain.StreamEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tx, err := s.dbWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `INSERT INTO stream_events (user_id, author_id, type, payload) VALUES ($1, $2, $3, $4) RETURNING id, xact_id, created_at`
+	if err := tx.QueryRowContext(ctx, query, event.UserId, event.AuthorId, event.Type, event.Payload).Scan(&event.Id, &event.XactId, &event.CreatedAt); err != nil {
+		return err
+	}
+
+	var wake string
+	if event.UserId != nil {
+		wake = "user:" + *event.UserId
+	} else {
+		wake = "author:" + *event.AuthorId
+	}
+	if _, err := tx.ExecContext(ctx, `SELECT pg_notify($1, $2)`, streamChannel, wake); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ListAfter returns the user's settled events following the position in stream
+// order. It reads from the primary since it runs right after a notification
+// from the primary. Events held back by a transaction still running are read
+// once it ended, on the next wake-up or heartbeat.
+func (s *streamRepository) ListAfter(ctx context.Context, userId string, after domain.StreamPosition, limit int) ([]*domain.StreamEvent, error) {
+	query := `SELECT e.id, e.xact_id, e.user_id, e.author_id, e.type, e.payload, e.created_at
+		FROM stream_events e
+		WHERE ` + streamAfter + ` AND ` + streamSettled + ` AND ` + streamAudience + `
+		ORDER BY e.xact_id, e.id
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	rows, err := s.dbWrite.QueryContext(ctx, query, userId, after.XactId, after.Id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*domain.StreamEvent
+	for rows.Next() {
+		var event domain.StreamEvent
+		if err := rows.Scan(&event.Id, &event.XactId, &event.UserId, &event.AuthorId, &event.Type, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, &event)
+	}
+	return events, rows.Err()
+}
+
+// CountAfter counts the user's settled events following the position, up to
+// limit: those ListAfter would return.
+func (s *streamRepository) CountAfter(ctx context.Context, userId string, after domain.StreamPosition, limit int) (int, error) {
+	query := `SELECT COUNT(*) FROM (
+			SELECT 1 FROM stream_events e WHERE ` + streamAfter + ` AND ` + streamSettled + ` AND ` + streamAudience + ` LIMIT $4
+		) t`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var count int
+	if err := s.dbWrite.QueryRowContext(ctx, query, userId, after.XactId, after.Id, limit).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// Bounds returns the id of the oldest stored event and the position of the
+// latest settled one in stream order, zero when there are none. Starting after
+// the latest settled event misses none of those still being published.
+func (s *streamRepository) Bounds(ctx context.Context) (int64, domain.StreamPosition, error) {
+	query := `SELECT COALESCE((SELECT MIN(id) FROM stream_events), 0), COALESCE(l.xact_id, '0'::xid8), COALESCE(l.id, 0)
+		FROM (SELECT 1) d
+		LEFT JOIN LATERAL (
+			SELECT e.xact_id, e.id FROM stream_events e WHERE ` + streamSettled + `
+			ORDER BY e.xact_id DESC, e.id DESC
+			LIMIT 1
+		) l ON TRUE`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		first int64
+		last  domain.StreamPosition
+	)
+	if err := s.dbWrite.QueryRowContext(ctx, query).Scan(&first, &last.XactId, &last.Id); err != nil {
+		return 0, domain.StreamPosition{}, err
+	}
+	return first, last, nil
+}
+
+// Position returns the position of the event in stream order. An event that is
+// not stored, such as the one before the oldest, is placed before every stored
+// event.
+func (s *streamRepository) Position(ctx context.Context, id int64) (domain.StreamPosition, error) {
+	query := `SELECT xact_id FROM stream_events WHERE id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	position := domain.StreamPosition{Id: id}
+	if err := s.dbWrite.QueryRowContext(ctx, query, id).Scan(&position.XactId); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return domain.StreamPosition{}, err
+	}
+	return position, nil
+}
+
+// ListFollowers returns those of userIds following the author.
+func (s *streamRepository) ListFollowers(ctx context.Context, authorId string, userIds []string) ([]string, error) {
+	query := `SELECT f.follower_id FROM follows f
+		WHERE f.followee_id = $1 AND f.follower_id IN (SELECT value::uuid FROM JSON_ARRAY_ELEMENTS_TEXT($2::json))`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	encoded, err := json.Marshal(userIds)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.dbRead.QueryContext(ctx, query, authorId, encoded)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var followers []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		followers = append(followers, id)
+	}
+	return followers, rows.Err()
+}
+
+// Prune removes the events created before the given time; clients that were
+// away longer cannot resume.
+func (s *streamRepository) Prune(ctx context.Context, before time.Time) error {
+	query := `DELETE FROM stream_events WHERE created_at < $1`
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := s.dbWrite.ExecContext(ctx, query, before)
+	return err
+}
+
+func NewStreamRepository(dbWrite, dbRead *sql.DB) StreamRepository {
+	return &streamRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
+
+// StreamListener reports the wake-ups published on streamChannel, from this
+// instance and every other one.
+type StreamListener interface {
+	// Listen calls wake with each notification payload until ctx is done. After
+	// the connection was lost and re-established it calls wake with an empty
+	// payload, as notifications may have been missed meanwhile.
+	Listen(ctx context.Context, wake func(payload string)) error
+}
+
+type streamListener struct {
+	dsn    string
+	logger *slog.Logger
+}
+
+func (s *streamListener) Listen(ctx context.Context, wake func(payload string)) error {
+	listener := pq.NewListener(s.dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			s.logger.Warn("stream listener connection", "event", event, "err", err.Error())
+		}
+	})
+	defer listener.Close()
+
+	// Listen blocks until connected, which may be never.
+	listening := make(chan error, 1)
+	go func() {
+		listening <- listener.Listen(streamChannel)
+	}()
+	select {
+	case err := <-listening:
+		if err != nil {
+			return err
+		}
+	case <-ctx.Done():
+		return nil
+	}
+
+	// A connection that silently died would only be noticed on the next
+	// notification, so it is checked regularly.
+	ping := time.NewTicker(time.Minute)
+	defer ping.Stop()
+	for {
+		select {
+		case notification := <-listener.Notify:
+			if notification == nil {
+				wake("")
+				continue
+			}
+			wake(notification.Extra)
+		case <-ping.C:
+			go listener.Ping()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func NewStreamListener(dsn string, logger *slog.Logger) StreamListener {
+	return &streamListener{
+		dsn:    dsn,
+		logger: logger,
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// TestStream_Order publishes an event while an earlier publication is still in
+// flight and checks readers wait for the earlier one instead of passing it.
+func TestStream_Order(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	streams := NewStreamRepository(db, db)
+
+	ids := seedUsers(t, db, "alice")
+	alice := ids[0]
+
+	_, last, err := streams.Bounds(ctx)
+	require.NoError(t, err)
+
+	tx, err := db.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer tx.Rollback()
+
+	var early domain.StreamPosition
+	err = tx.QueryRowContext(ctx, `INSERT INTO stream_events (user_id, type, payload) VALUES ($1, $2, '{}') RETURNING xact_id, id`, alice, domain.StreamMessage).Scan(&early.XactId, &early.Id)
+	require.NoError(t, err)
+
+	late := &domain.StreamEvent{UserId: &alice, Type: domain.StreamMessage, Payload: []byte(`{}`)}
+	require.NoError(t, streams.Publish(ctx, late))
+	require.Greater(t, late.Id, early.Id)
+
+	events, err := streams.ListAfter(ctx, alice, last, 10)
+	require.NoError(t, err)
+	require.Empty(t, events, "the later event waits for the earlier publication")
+
+	require.NoError(t, tx.Commit())
+
+	events, err = streams.ListAfter(ctx, alice, last, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, early.Id, events[0].Id)
+	require.Equal(t, late.Id, events[1].Id)
+
+	events, err = streams.ListAfter(ctx, alice, early, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, late.Id, events[0].Id)
+}
+
+// TestStream_Pruned keeps the position of an event that is pruned afterwards
+// and checks reading after it still skips the events before it.
+func TestStream_Pruned(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	streams := NewStreamRepository(db, db)
+
+	ids := seedUsers(t, db, "alice")
+	alice := ids[0]
+
+	var published []*domain.StreamEvent
+	for range 3 {
+		event := &domain.StreamEvent{UserId: &alice, Type: domain.StreamMessage, Payload: []byte(`{}`)}
+		require.NoError(t, streams.Publish(ctx, event))
+		published = append(published, event)
+	}
+	seen := published[1]
+
+	position, err := streams.Position(ctx, seen.Id)
+	require.NoError(t, err)
+	require.Equal(t, seen.Position(), position)
+
+	_, err = db.ExecContext(ctx, `DELETE FROM stream_events WHERE id = $1`, seen.Id)
+	require.NoError(t, err)
+
+	events, err := streams.ListAfter(ctx, alice, position, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, published[2].Id, events[0].Id)
+
+	count, err := streams.CountAfter(ctx, alice, position, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
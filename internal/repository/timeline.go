@@ -0,0 +1,385 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+// TimelineRepository stores the materialized part of home timelines. Posts and
+// reposts are written to the followers' timelines in the background, except
+// those of accounts with many followers, which are merged in when reading.
+type TimelineRepository interface {
+	Add(ctx context.Context, post *domain.Post) error
+	AddRepost(ctx context.Context, repost *domain.Repost) error
+	FanOutPending(ctx context.Context, limit int) (int, error)
+	Reconcile(ctx context.Context, fanOutThreshold, backfill int) (bool, error)
+	Backfill(ctx context.Context, userId, authorId string, limit int) error
+	RemoveAuthor(ctx context.Context, userId, authorId string) error
+	RemovePost(ctx context.Context, postId string) error
+	RemoveRepost(ctx context.Context, userId, postId string) error
+	ListHome(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.TimelineEntry, error)
+}
+
+type timelineRepository struct {
+	dbWrite *sql.DB
+	dbRead  *sql.DB
+}
+
+// Add adds the post to its author's timeline. It reaches the followers through
+// FanOutPending.
+func (t *timelineRepository) Add(ctx context.Context, post *domain.Post) error {
+	query := `INSERT INTO timeline_entries (user_id, post_id, author_id, created_at)
+		VALUES ($2, $1, $2, $3)
+		ON CONFLICT DO NOTHING`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, post.Id, post.AuthorId, post.CreatedAt)
+	return err
+}
+
+// AddRepost adds the reposted post to the reposter's timeline. It reaches the
+// reposter's followers through FanOutPending.
+func (t *timelineRepository) AddRepost(ctx context.Context, repost *domain.Repost) error {
+	query := `INSERT INTO timeline_entries (user_id, post_id, author_id, reposter_id, created_at)
+		VALUES ($1, $2, $3, $1, $4)
+		ON CONFLICT DO NOTHING`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, repost.UserId, repost.PostId, repost.Post.AuthorId, repost.CreatedAt)
+	return err
+}
+
+// FanOutPending writes up to limit of the oldest posts, and as many reposts,
+// that were not fanned out yet to the timelines of their author's or reposter's
+// followers, and returns how many it handled. Those of accounts whose timeline
+// is pulled are only marked as handled. The accounts are locked for the
+// statement so that Reconcile cannot clear the flag in between: a post is
+// either fanned out here or backfilled there. Each reposter gets its own entry,
+// but timelines already holding the post keep listing it where it is, so a post
+// reposted by several followed accounts shows up once and stays when one of
+// them undoes their repost.
+func (t *timelineRepository) FanOutPending(ctx context.Context, limit int) (int, error) {
+	posts := `WITH batch AS (
+			SELECT p.id, p.author_id, p.created_at, p.deleted_at IS NULL AS live
+			FROM posts p
+			WHERE p.fanned_out_at IS NULL
+			ORDER BY p.created_at, p.id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), pushed AS (
+			SELECT u.id FROM users u
+			WHERE u.id IN (SELECT author_id FROM batch) AND NOT u.timeline_pulled
+			FOR KEY SHARE
+		), fanned AS (
+			INSERT INTO timeline_entries (user_id, post_id, author_id, created_at)
+			SELECT f.follower_id, b.id, b.author_id, b.created_at
+			FROM batch b
+			JOIN pushed a ON a.id = b.author_id
+			JOIN follows f ON f.followee_id = b.author_id
+			WHERE b.live
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE posts SET fanned_out_at = NOW() FROM batch WHERE posts.id = batch.id`
+	reposts := `WITH batch AS (
+			SELECT r.user_id, r.post_id, r.created_at
+			FROM reposts r
+			WHERE r.fanned_out_at IS NULL
+			ORDER BY r.created_at, r.user_id, r.post_id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), pushed AS (
+			SELECT u.id FROM users u
+			WHERE u.id IN (SELECT user_id FROM batch) AND NOT u.timeline_pulled
+			FOR KEY SHARE
+		), fanned AS (
+			INSERT INTO timeline_entries (user_id, post_id, author_id, reposter_id, created_at)
+			SELECT f.follower_id, b.post_id, p.author_id, b.user_id, b.created_at
+			FROM batch b
+			JOIN pushed a ON a.id = b.user_id
+			JOIN posts p ON p.id = b.post_id AND p.deleted_at IS NULL
+			JOIN follows f ON f.followee_id = b.user_id
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE reposts r SET fanned_out_at = NOW() FROM batch b WHERE r.user_id = b.user_id AND r.post_id = b.post_id`
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	var handled int64
+	for _, query := range []string{posts, reposts} {
+		res, err := t.dbWrite.ExecContext(ctx, query, limit)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		handled += n
+	}
+	return int(handled), nil
+}
+
+// Reconcile moves one account that crossed the fan-out threshold to the other
+// side of it and reports whether there was one. An account reaching the
+// threshold is only flagged as pulled: what was fanned out so far stays and
+// the rest is merged on read. For an account falling below it, its latest
+// posts and reposts, backfill of each, are first written to its followers'
+// timelines, since those made while it was pulled were never fanned out.
+func (t *timelineRepository) Reconcile(ctx context.Context, fanOutThreshold, backfill int) (bool, error) {
+	pull := `UPDATE users SET timeline_pulled = TRUE
+		WHERE id = (
+			SELECT id FROM users
+			WHERE NOT timeline_pulled AND follower_count >= $1
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)`
+	push := `WITH author AS (
+			SELECT id FROM users
+			WHERE timeline_pulled AND follower_count < $1
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		), recent AS (
+			SELECT p.id AS post_id, p.author_id, p.created_at, NULL::uuid AS reposter_id
+			FROM author a
+			CROSS JOIN LATERAL (
+				SELECT p.id, p.author_id, p.created_at FROM posts p
+				WHERE p.author_id = a.id AND p.deleted_at IS NULL
+				ORDER BY p.created_at DESC, p.id DESC
+				LIMIT $2
+			) p
+			UNION ALL
+			SELECT r.post_id, p.author_id, r.created_at, r.user_id
+			FROM author a
+			CROSS JOIN LATERAL (
+				SELECT r.post_id, r.user_id, r.created_at FROM reposts r
+				WHERE r.user_id = a.id
+				ORDER BY r.created_at DESC, r.post_id DESC
+				LIMIT $2
+			) r
+			JOIN posts p ON p.id = r.post_id AND p.deleted_at IS NULL
+		), backfilled AS (
+			INSERT INTO timeline_entries (user_id, post_id, author_id, reposter_id, created_at)
+			SELECT f.follower_id, r.post_id, r.author_id, r.reposter_id, r.created_at
+			FROM recent r
+			JOIN author a ON TRUE
+			JOIN follows f ON f.followee_id = a.id
+			ON CONFLICT DO NOTHING
+		)
+		UPDATE users SET timeline_pulled = FALSE FROM author WHERE users.id = author.id`
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	res, err := t.dbWrite.ExecContext(ctx, pull, fanOutThreshold)
+	if err != nil {
+		return false, err
+	}
+
+	pulled, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if pulled > 0 {
+		return true, nil
+	}
+
+	res, err = t.dbWrite.ExecContext(ctx, push, fanOutThreshold, backfill)
+	if err != nil {
+		return false, err
+	}
+
+	pushed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return pushed > 0, nil
+}
+
+// Backfill copies the author's latest posts into the user's timeline, typically
+// right after the user followed the author. Posts of accounts whose timeline is
+// pulled are merged on read and need no backfill.
+func (t *timelineRepository) Backfill(ctx context.Context, userId, authorId string, limit int) error {
+	query := `INSERT INTO timeline_entries (user_id, post_id, author_id, created_at)
+		SELECT $1, p.id, p.author_id, p.created_at FROM posts p
+		JOIN users a ON a.id = p.author_id AND NOT a.timeline_pulled
+		WHERE p.author_id = $2 AND p.deleted_at IS NULL
+		ORDER BY p.created_at DESC, p.id DESC
+		LIMIT $3
+		ON CONFLICT DO NOTHING`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, userId, authorId, limit)
+	return err
+}
+
+// RemoveAuthor removes what the account brought into the user's timeline: its
+// own posts and its reposts. Posts that also reached the user through another
+// followed reposter stay, listed from the next earliest entry.
+func (t *timelineRepository) RemoveAuthor(ctx context.Context, userId, authorId string) error {
+	query := `DELETE FROM timeline_entries WHERE user_id = $1 AND COALESCE(reposter_id, author_id) = $2`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, userId, authorId)
+	return err
+}
+
+func (t *timelineRepository) RemovePost(ctx context.Context, postId string) error {
+	query := `DELETE FROM timeline_entries WHERE post_id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, postId)
+	return err
+}
+
+// RemoveRepost removes the entries the repost brought in, leaving those of the
+// author and of other reposters of the post.
+func (t *timelineRepository) RemoveRepost(ctx context.Context, userId, postId string) error {
+	query := `DELETE FROM timeline_entries WHERE reposter_id = $1 AND post_id = $2`
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err := t.dbWrite.ExecContext(ctx, query, userId, postId)
+	return err
+}
+
+// homeVisible holds when the post aliased p is shown on the home timeline of
+// the user $1.
+var homeVisible = `p.deleted_at IS NULL AND ` + notHidden("$1::uuid", "p.author_id") + `
+	AND ` + notProtected("$1::uuid", "p.author_id") + `
+	AND ` + notMutedWords("$1::uuid", domain.MutedWordHome, "p.id")
+
+// homeEarlierSource holds when the post reaches the user $1 through a source
+// older than the one at createdAt brought in by reposter, NULL for the post
+// itself: a materialized entry, a post of a followed account whose timeline is
+// pulled, or a repost by such an account. Sources brought in by a hidden
+// reposter do not count.
+func homeEarlierSource(post, createdAt, reposter string) string {
+	source := `(` + createdAt + `, COALESCE(` + reposter + `::text, ''))`
+	return `(EXISTS (SELECT 1 FROM timeline_entries e
+			WHERE e.user_id = $1 AND e.post_id = ` + post + `
+			AND (e.reposter_id IS NULL OR ` + notHidden("$1::uuid", "e.reposter_id") + `)
+			AND (e.created_at, COALESCE(e.reposter_id::text, '')) < ` + source + `)
+		OR EXISTS (SELECT 1 FROM posts ep JOIN popular ea ON ea.id = ep.author_id
+			WHERE ep.id = ` + post + ` AND (ep.created_at, '') < ` + source + `)
+		OR EXISTS (SELECT 1 FROM reposts er JOIN popular ea ON ea.id = er.user_id
+			WHERE er.post_id = ` + post + ` AND ` + notHidden("$1::uuid", "er.user_id") + `
+			AND (er.created_at, er.user_id::text) < ` + source + `))`
+}
+
+// ListHome returns a page of the user's home timeline, newest first. It merges
+// the materialized entries with the latest posts and reposts of followed
+// accounts whose timeline is pulled, which are not fanned out. Each source is
+// limited before the merge so the cost does not grow with the follower count,
+// and a post reaching the user several ways is listed once, at its earliest
+// source not brought in by a hidden reposter, whichever source was limited
+// away. Later reposts therefore never move a post across a page the client
+// already holds. Posts and reposts of
+// accounts the user blocked, was blocked by or muted are left out, and so are
+// posts containing a word the user muted on the home timeline and posts of
+// protected accounts the user no longer follows, such as those reposted before
+// their author became protected. They are filtered out of each source before it
+// is limited, so hidden posts never take the place of visible ones.
+func (t *timelineRepository) ListHome(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.TimelineEntry, error) {
+	query := `WITH popular AS (
+			SELECT f.followee_id AS id
+			FROM follows f
+			JOIN users a ON a.id = f.followee_id AND a.timeline_pulled
+			WHERE f.follower_id = $1
+		), candidates AS (
+			(SELECT t.post_id AS id, t.created_at, t.reposter_id
+			FROM timeline_entries t
+			JOIN posts p ON p.id = t.post_id
+			WHERE t.user_id = $1
+			AND ($2::timestamptz IS NULL OR (t.created_at, t.post_id) < ($2, $3::uuid))
+			AND (t.reposter_id IS NULL OR ` + notHidden("$1::uuid", "t.reposter_id") + `)
+			AND ` + homeVisible + `
+			AND NOT ` + homeEarlierSource("t.post_id", "t.created_at", "t.reposter_id") + `
+			ORDER BY t.created_at DESC, t.post_id DESC
+			LIMIT $4)
+			UNION ALL
+			SELECT r.id, r.created_at, NULL::uuid
+			FROM popular
+			CROSS JOIN LATERAL (
+				SELECT p.id, p.created_at FROM posts p
+				WHERE p.author_id = popular.id AND ` + homeVisible + `
+				AND ($2::timestamptz IS NULL OR (p.created_at, p.id) < ($2, $3::uuid))
+				AND NOT ` + homeEarlierSource("p.id", "p.created_at", "NULL::uuid") + `
+				ORDER BY p.created_at DESC, p.id DESC
+				LIMIT $4
+			) r
+			UNION ALL
+			SELECT r.post_id, r.created_at, r.user_id
+			FROM popular
+			CROSS JOIN LATERAL (
+				SELECT rp.post_id, rp.created_at, rp.user_id FROM reposts rp
+				JOIN posts p ON p.id = rp.post_id
+				WHERE rp.user_id = popular.id AND ` + notHidden("$1::uuid", "rp.user_id") + `
+				AND ` + homeVisible + `
+				AND ($2::timestamptz IS NULL OR (rp.created_at, rp.post_id) < ($2, $3::uuid))
+				AND NOT ` + homeEarlierSource("rp.post_id", "rp.created_at", "rp.user_id") + `
+				ORDER BY rp.created_at DESC, rp.post_id DESC
+				LIMIT $4
+			) r
+		), entries AS (
+			SELECT DISTINCT ON (id) id, created_at, reposter_id
+			FROM candidates
+			ORDER BY id, created_at, reposter_id NULLS FIRST
+		)
+		SELECT entries.created_at, ru.id, ru.username, ru.created_at, ` + postColumns + `
+		FROM entries
+		JOIN posts p ON p.id = entries.id
+		` + postJoins("$1::uuid") + `
+		LEFT JOIN users ru ON ru.id = entries.reposter_id
+		ORDER BY entries.created_at DESC, entries.id DESC
+		LIMIT $4`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var (
+		cursorTime *time.Time
+		cursorId   *string
+	)
+	if cursor != nil {
+		cursorTime, cursorId = &cursor.Time, &cursor.Id
+	}
+
+	rows, err := t.dbRead.QueryContext(ctx, query, userId, cursorTime, cursorId, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.TimelineEntry
+	for rows.Next() {
+		var (
+			entry                        domain.TimelineEntry
+			reposterId, reposterUsername sql.NullString
+			reposterCreatedAt            sql.NullTime
+		)
+		post, err := scanPost(prefixScanner{rows: rows, prefix: []any{&entry.CreatedAt, &reposterId, &reposterUsername, &reposterCreatedAt}})
+		if err != nil {
+			return nil, err
+		}
+
+		entry.Post = post
+		if reposterId.Valid {
+			entry.RepostedBy = &domain.User{Id: reposterId.String, Username: reposterUsername.String, CreatedAt: reposterCreatedAt.Time}
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, rows.Err()
+}
+
+func NewTimelineRepository(dbWrite, dbRead *sql.DB) TimelineRepository {
+	return &timelineRepository{
+		dbWrite: dbWrite,
+		dbRead:  dbRead,
+	}
+}
@@ -0,0 +1,285 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// fanOutPending fans out every pending post and repost, as the timeline worker
+// does.
+func fanOutPending(t *testing.T, timelines TimelineRepository) {
+	t.Helper()
+	for {
+		handled, err := timelines.FanOutPending(context.Background(), 100)
+		require.NoError(t, err)
+		if handled < 100 {
+			return
+		}
+	}
+}
+
+// reconcile moves every account that crossed the threshold, as the timeline
+// worker does.
+func reconcile(t *testing.T, timelines TimelineRepository, threshold int) {
+	t.Helper()
+	for {
+		moved, err := timelines.Reconcile(context.Background(), threshold, 10)
+		require.NoError(t, err)
+		if !moved {
+			return
+		}
+	}
+}
+
+// TestTimeline_Reposts has dave follow alice and bob, who both repost carol's
+// post, and checks the post stays on his home timeline until neither repost
+// applies anymore.
+func TestTimeline_Reposts(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	follows := NewFollowRepository(db, db)
+	reposts := NewRepostRepository(db, db)
+	timelines := NewTimelineRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := ids[0], ids[1], ids[2], ids[3]
+
+	for _, followeeId := range []string{alice, bob} {
+		_, err := follows.Follow(ctx, dave, followeeId)
+		require.NoError(t, err)
+	}
+
+	post, err := posts.Create(ctx, &domain.Post{AuthorId: carol, Body: "reposted"})
+	require.NoError(t, err)
+
+	for _, reposterId := range []string{alice, bob} {
+		repost := &domain.Repost{UserId: reposterId, PostId: post.Id, Post: post}
+		_, err := reposts.Create(ctx, repost)
+		require.NoError(t, err)
+		require.NoError(t, timelines.AddRepost(ctx, repost))
+		fanOutPending(t, timelines)
+	}
+
+	home := func() []*domain.TimelineEntry {
+		t.Helper()
+		entries, err := timelines.ListHome(ctx, dave, nil, 10)
+		require.NoError(t, err)
+		return entries
+	}
+
+	t.Run("listed once from the first repost", func(t *testing.T) {
+		entries := home()
+		require.Len(t, entries, 1)
+		require.Equal(t, post.Id, entries[0].Post.Id)
+		require.Equal(t, alice, entries[0].RepostedBy.Id)
+	})
+
+	t.Run("undoing one repost keeps the other", func(t *testing.T) {
+		require.NoError(t, timelines.RemoveRepost(ctx, alice, post.Id))
+
+		entries := home()
+		require.Len(t, entries, 1)
+		require.Equal(t, post.Id, entries[0].Post.Id)
+		require.Equal(t, bob, entries[0].RepostedBy.Id)
+	})
+
+	t.Run("unfollowing the last reposter removes the post", func(t *testing.T) {
+		require.NoError(t, timelines.RemoveAuthor(ctx, dave, bob))
+		require.Empty(t, home())
+	})
+}
+
+// TestTimeline_Threshold has bob follow alice while she crosses the fan-out
+// threshold one way and then the other, and checks none of her posts goes
+// missing from his home timeline.
+func TestTimeline_Threshold(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	follows := NewFollowRepository(db, db)
+	timelines := NewTimelineRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob")
+	alice, bob := ids[0], ids[1]
+
+	_, err := follows.Follow(ctx, bob, alice)
+	require.NoError(t, err)
+
+	post := func(body string) *domain.Post {
+		t.Helper()
+		p, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: body})
+		require.NoError(t, err)
+		require.NoError(t, timelines.Add(ctx, p))
+		fanOutPending(t, timelines)
+		return p
+	}
+
+	home := func() []string {
+		t.Helper()
+		entries, err := timelines.ListHome(ctx, bob, nil, 10)
+		require.NoError(t, err)
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.Post.Id)
+		}
+		return ids
+	}
+
+	materialized := func() int {
+		t.Helper()
+		var count int
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM timeline_entries WHERE user_id = $1`, bob).Scan(&count))
+		return count
+	}
+
+	pushed := post("pushed")
+	require.Equal(t, []string{pushed.Id}, home())
+	require.Equal(t, 1, materialized())
+
+	t.Run("reaching the threshold merges on read", func(t *testing.T) {
+		reconcile(t, timelines, 1)
+		pulled := post("pulled")
+
+		require.Equal(t, []string{pulled.Id, pushed.Id}, home())
+		require.Equal(t, 1, materialized())
+	})
+
+	t.Run("falling below it backfills", func(t *testing.T) {
+		reconcile(t, timelines, 1<<30)
+
+		require.Len(t, home(), 2)
+		require.Equal(t, 2, materialized())
+	})
+}
+
+// TestTimeline_Filtered has carol follow alice and bob, mute bob and a word, and
+// checks that bob's posts and alice's muted post, all newer than alice's other
+// posts, do not take their place on a page, whether the timeline is
+// materialized or pulled.
+func TestTimeline_Filtered(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	posts := NewPostRepository(db, db)
+	follows := NewFollowRepository(db, db)
+	blocks := NewBlockRepository(db, db)
+	words := NewMutedWordRepository(db, db)
+	timelines := NewTimelineRepository(db, db)
+
+	ids := seedUsers(t, db, "alice", "bob", "carol")
+	alice, bob, carol := ids[0], ids[1], ids[2]
+
+	for _, followeeId := range []string{alice, bob} {
+		_, err := follows.Follow(ctx, carol, followeeId)
+		require.NoError(t, err)
+	}
+
+	post := func(authorId, body string) *domain.Post {
+		t.Helper()
+		p, err := posts.Create(ctx, &domain.Post{AuthorId: authorId, Body: body})
+		require.NoError(t, err)
+		require.NoError(t, timelines.Add(ctx, p))
+		return p
+	}
+	older := post(alice, "older")
+	newer := post(alice, "newer")
+	post(alice, "spoilers ahead")
+	for range 3 {
+		post(bob, "muted")
+	}
+	fanOutPending(t, timelines)
+
+	_, err := blocks.Mute(ctx, carol, bob)
+	require.NoError(t, err)
+	_, err = words.Upsert(ctx, &domain.MutedWord{UserId: carol, Phrase: "spoilers", Scopes: []domain.MutedWordScope{domain.MutedWordHome}})
+	require.NoError(t, err)
+
+	page := func() []string {
+		t.Helper()
+		entries, err := timelines.ListHome(ctx, carol, nil, 2)
+		require.NoError(t, err)
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.Post.Id)
+		}
+		return ids
+	}
+
+	t.Run("materialized", func(t *testing.T) {
+		require.Equal(t, []string{newer.Id, older.Id}, page())
+	})
+
+	t.Run("pulled", func(t *testing.T) {
+		reconcile(t, timelines, 1)
+		require.Equal(t, []string{newer.Id, older.Id}, page())
+	})
+}
+
+// TestTimeline_RepostBetweenPages has carol page through alice's posts on her
+// home timeline while bob reposts one on each side of her cursor, and checks
+// every post is listed exactly once, whether bob's timeline is materialized or
+// pulled.
+func TestTimeline_RepostBetweenPages(t *testing.T) {
+	for name, pulled := range map[string]bool{"materialized": false, "pulled": true} {
+		t.Run(name, func(t *testing.T) {
+			db := testDB(t)
+			ctx := context.Background()
+			posts := NewPostRepository(db, db)
+			follows := NewFollowRepository(db, db)
+			reposts := NewRepostRepository(db, db)
+			timelines := NewTimelineRepository(db, db)
+
+			ids := seedUsers(t, db, "alice", "bob", "carol")
+			alice, bob, carol := ids[0], ids[1], ids[2]
+
+			for _, followeeId := range []string{alice, bob} {
+				_, err := follows.Follow(ctx, carol, followeeId)
+				require.NoError(t, err)
+			}
+			if pulled {
+				reconcile(t, timelines, 1)
+			}
+
+			var created []*domain.Post
+			for _, body := range []string{"first", "second", "third"} {
+				p, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: body})
+				require.NoError(t, err)
+				require.NoError(t, timelines.Add(ctx, p))
+				created = append(created, p)
+			}
+			fanOutPending(t, timelines)
+			first, second, third := created[0], created[1], created[2]
+
+			page := func(cursor *domain.Cursor) ([]string, *domain.Cursor) {
+				t.Helper()
+				entries, err := timelines.ListHome(ctx, carol, cursor, 2)
+				require.NoError(t, err)
+				var ids []string
+				for _, entry := range entries {
+					ids = append(ids, entry.Post.Id)
+				}
+				last := entries[len(entries)-1]
+				return ids, &domain.Cursor{Time: last.CreatedAt, Id: last.Post.Id}
+			}
+
+			ids, cursor := page(nil)
+			require.Equal(t, []string{third.Id, second.Id}, ids)
+
+			for _, p := range []*domain.Post{first, third} {
+				repost := &domain.Repost{UserId: bob, PostId: p.Id, Post: p}
+				_, err := reposts.Create(ctx, repost)
+				require.NoError(t, err)
+				require.NoError(t, timelines.AddRepost(ctx, repost))
+			}
+			fanOutPending(t, timelines)
+
+			ids, _ = page(cursor)
+			require.Equal(t, []string{first.Id}, ids)
+
+			ids, _ = page(nil)
+			require.Equal(t, []string{third.Id, second.Id}, ids)
+		})
+	}
+}
@@ -11,8 +11,11 @@ import (
 
 type UserRepository interface {
 	Create(ctx context.Context, user *domain.User) (*domain.User, error)
+	GetById(ctx context.Context, id string) (*domain.User, error)
 	GetByUsername(ctx context.Context, username string) (*domain.User, error)
+	GetProfile(ctx context.Context, viewerId, username string) (*domain.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	UpdateProfile(ctx context.Context, id string, update *domain.ProfileUpdate) (*domain.User, error)
 }
 
 type userRepository struct {
@@ -20,6 +23,37 @@ type userRepository struct {
 	dbRead  *sql.DB
 }
 
+// userColumns lists the columns scanUser reads. Deleted users are still returned
+// so callers can tell a deactivated account from one that never existed.
+const userColumns = `id, username, email, password, display_name, bio, location, website, avatar_url, banner_url,
+	post_count, follower_count, following_count, protected, created_at, updated_at, deleted_at`
+
+func scanUser(scanner interface{ Scan(dest ...any) error }) (*domain.User, error) {
+	var user domain.User
+	if err := scanner.Scan(
+		&user.Id,
+		&user.Username,
+		&user.Email,
+		&user.Password,
+		&user.DisplayName,
+		&user.Bio,
+		&user.Location,
+		&user.Website,
+		&user.AvatarURL,
+		&user.BannerURL,
+		&user.PostCount,
+		&user.FollowerCount,
+		&user.FollowingCount,
+		&user.Protected,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+		&user.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (u *userRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `INSERT INTO users (username, email, password) VALUES ($1, $2, $3) RETURNING id, created_at`
 	args := []any{user.Username, user.Email, user.Password}
@@ -32,20 +66,31 @@ func (u *userRepository) Create(ctx context.Context, user *domain.User) (*domain
 	return user, nil
 }
 
+func (u *userRepository) GetById(ctx context.Context, id string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE id = $1`
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	user, err := scanUser(u.dbRead.QueryRowContext(ctx, query, id))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// GetByUsername ignores case, so @Alice and @alice are the same account.
 func (u *userRepository) GetByUsername(ctx context.Context, username string) (*domain.User, error) {
-	query := `SELECT * FROM users WHERE username = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(username) = LOWER($1)`
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
-	var user domain.User
 
-	if err := u.dbRead.QueryRowContext(ctx, query, username).Scan(
-		&user.Id,
-		&user.Username,
-		&user.Email,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	); err != nil {
+	user, err := scanUser(u.dbRead.QueryRowContext(ctx, query, username))
+	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
 			return nil, customErr.ErrNotFound
@@ -53,24 +98,66 @@ func (u *userRepository) GetByUsername(ctx context.Context, username string) (*d
 			return nil, err
 		}
 	}
+	return user, nil
+}
 
-	return &user, nil
+// GetProfile looks the user up like GetByUsername, as seen by the viewer:
+// accounts the viewer blocked or was blocked by are not found.
+func (u *userRepository) GetProfile(ctx context.Context, viewerId, username string) (*domain.User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE LOWER(username) = LOWER($1) AND ` + notBlocked("$2::uuid", "users.id")
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	user, err := scanUser(u.dbRead.QueryRowContext(ctx, query, username, viewerId))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return user, nil
 }
 
 func (u *userRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
-	query := `SELECT * FROM users WHERE email = $1`
+	query := `SELECT ` + userColumns + ` FROM users WHERE email = $1`
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	var user domain.User
+	user, err := scanUser(u.dbRead.QueryRowContext(ctx, query, email))
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, customErr.ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+	return user, nil
+}
 
-	if err := u.dbRead.QueryRowContext(ctx, query, email).Scan(
-		&user.Id,
-		&user.Username,
-		&user.Email,
-		&user.Password,
-		&user.CreatedAt,
-		&user.UpdatedAt); err != nil {
+// UpdateProfile changes the profile fields set in update in a single statement,
+// so concurrent edits of different fields do not overwrite each other. A
+// deactivated account cannot be edited.
+func (u *userRepository) UpdateProfile(ctx context.Context, id string, update *domain.ProfileUpdate) (*domain.User, error) {
+	query := `UPDATE users SET
+			display_name = COALESCE($2, display_name),
+			bio = COALESCE($3, bio),
+			location = COALESCE($4, location),
+			website = COALESCE($5, website),
+			avatar_url = COALESCE($6, avatar_url),
+			banner_url = COALESCE($7, banner_url),
+			protected = COALESCE($8, protected),
+			updated_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+		RETURNING ` + userColumns
+	args := []any{id, update.DisplayName, update.Bio, update.Location, update.Website, update.AvatarURL, update.BannerURL, update.Protected}
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	user, err := scanUser(u.dbWrite.QueryRowContext(ctx, query, args...))
+	if err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
 			return nil, customErr.ErrNotFound
@@ -78,7 +165,7 @@ func (u *userRepository) GetByEmail(ctx context.Context, email string) (*domain.
 			return nil, err
 		}
 	}
-	return &user, nil
+	return user, nil
 }
 
 func NewUserRepository(dbWrite, dbRead *sql.DB) UserRepository {
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestUser_Profile(t *testing.T) {
+	db := testDB(t)
+	ctx := context.Background()
+	users := NewUserRepository(db, db)
+	posts := NewPostRepository(db, db)
+
+	alice := seedUsers(t, db, "Alice")[0]
+	user, err := users.GetById(ctx, alice)
+	require.NoError(t, err)
+
+	t.Run("usernames are looked up regardless of case", func(t *testing.T) {
+		found, err := users.GetByUsername(ctx, strings.ToUpper(user.Username))
+		require.NoError(t, err)
+		require.Equal(t, alice, found.Id)
+
+		_, err = users.GetByUsername(ctx, user.Username+"_nobody")
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+
+	t.Run("posts are counted", func(t *testing.T) {
+		first, err := posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "one"})
+		require.NoError(t, err)
+		_, err = posts.Create(ctx, &domain.Post{AuthorId: alice, Body: "two"})
+		require.NoError(t, err)
+		require.NoError(t, posts.Delete(ctx, first.Id))
+		require.ErrorIs(t, posts.Delete(ctx, first.Id), customErr.ErrNotFound)
+
+		found, err := users.GetById(ctx, alice)
+		require.NoError(t, err)
+		require.Equal(t, 1, found.PostCount)
+	})
+
+	t.Run("only the given fields are updated", func(t *testing.T) {
+		bio, location := "Gopher", "Tehran"
+		_, err := users.UpdateProfile(ctx, alice, &domain.ProfileUpdate{Bio: &bio, Location: &location})
+		require.NoError(t, err)
+
+		cleared := ""
+		updated, err := users.UpdateProfile(ctx, alice, &domain.ProfileUpdate{Location: &cleared})
+		require.NoError(t, err)
+		require.Equal(t, "Gopher", updated.Bio)
+		require.Empty(t, updated.Location)
+	})
+}
@@ -0,0 +1,59 @@
+package repository
+
+import "github.com/saleh-ghazimoradi/X/internal/domain"
+
+// Blocks and mutes are enforced by the queries reading content rather than by
+// the services, so that a page is never cut short by filtering after the fact
+// and every listing applies the same rules. The helpers below build the SQL
+// conditions; they take expressions naming the reader and the account whose
+// content is read, such as "$1::uuid" and "p.author_id".
+//
+// A block hides both accounts from each other everywhere. A mute only hides the
+// muted account from the muter's feeds: the home timeline, replies,
+// notifications and the event stream. Reading a post or a profile directly
+// still works.
+//
+// Muted words are applied the same way, when reading, so muting or unmuting a
+// word changes what existing posts are shown at once.
+//
+// The posts of a protected account are only shown to the account itself and
+// its followers, wherever they are read: directly, in threads, as quotes, in
+// timelines, in notifications and in the event stream. The account itself
+// stays visible, so others can request to follow it.
+
+// notBlocked holds when neither account blocked the other.
+func notBlocked(viewer, account string) string {
+	return `NOT EXISTS (SELECT 1 FROM blocks blk
+		WHERE (blk.blocker_id = ` + viewer + ` AND blk.blocked_id = ` + account + `)
+		OR (blk.blocker_id = ` + account + ` AND blk.blocked_id = ` + viewer + `))`
+}
+
+// notHidden holds when notBlocked does and the reader did not mute the account.
+func notHidden(viewer, account string) string {
+	return `(` + notBlocked(viewer, account) + ` AND NOT EXISTS (SELECT 1 FROM mutes mt
+		WHERE mt.user_id = ` + viewer + ` AND mt.muted_id = ` + account + `))`
+}
+
+// notMutedWords holds when the post does not contain a word the reader muted in
+// scope. The reader's own posts are never hidden.
+func notMutedWords(viewer string, scope domain.MutedWordScope, post string) string {
+	return `NOT EXISTS (SELECT 1 FROM posts mp JOIN muted_words mw ON mw.user_id = ` + viewer + `
+		WHERE mp.id = ` + post + ` AND mp.author_id <> ` + viewer + `
+		AND '` + string(scope) + `' = ANY(mw.scopes)
+		AND (mw.expires_at IS NULL OR mw.expires_at > NOW())
+		AND mp.body ~* ` + mutedWordPattern("mw.phrase") + `)`
+}
+
+// notProtected holds when the posts of the account are visible to the reader:
+// the account is public or the reader's own, or the reader follows it.
+func notProtected(viewer, account string) string {
+	return `(` + account + ` = ` + viewer + `
+		OR NOT EXISTS (SELECT 1 FROM users pu WHERE pu.id = ` + account + ` AND pu.protected)
+		OR EXISTS (SELECT 1 FROM follows pf WHERE pf.follower_id = ` + viewer + ` AND pf.followee_id = ` + account + `))`
+}
+
+// postNotProtected holds when notProtected does for the author of the post, and
+// when there is no post, as for a NULL post id.
+func postNotProtected(viewer, post string) string {
+	return `NOT EXISTS (SELECT 1 FROM posts pp WHERE pp.id = ` + post + ` AND NOT ` + notProtected(viewer, "pp.author_id") + `)`
+}
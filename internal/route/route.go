@@ -0,0 +1,157 @@
+package route
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/handler"
+	"github.com/saleh-ghazimoradi/X/internal/metrics"
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"github.com/saleh-ghazimoradi/X/internal/tracing"
+	"log/slog"
+	"net/http"
+)
+
+type Handlers struct {
+	Health       *handler.HealthHandler
+	Auth         *handler.AuthHandler
+	User         *handler.UserHandler
+	Post         *handler.PostHandler
+	Media        *handler.MediaHandler
+	Follow       *handler.FollowHandler
+	Block        *handler.BlockHandler
+	MutedWord    *handler.MutedWordHandler
+	Timeline     *handler.TimelineHandler
+	Like         *handler.LikeHandler
+	Bookmark     *handler.BookmarkHandler
+	Poll         *handler.PollHandler
+	List         *handler.ListHandler
+	Repost       *handler.RepostHandler
+	Notification *handler.NotificationHandler
+	Message      *handler.MessageHandler
+	Stream       *handler.StreamHandler
+	// Blobs serves the stored media when they are kept on local disk.
+	Blobs   http.Handler
+	Metrics *metrics.Metrics
+	Logger  *slog.Logger
+	// Authenticate guards the routes that need a signed in user.
+	Authenticate func(http.Handler) http.Handler
+	// AuthenticateStream guards the streaming routes, whose clients may not be
+	// able to send the token in a header.
+	AuthenticateStream func(http.Handler) http.Handler
+	// AuthenticateMetrics guards the metrics, which are not served when it is
+	// nil.
+	AuthenticateMetrics func(http.Handler) http.Handler
+}
+
+func New(h Handlers) http.Handler {
+	mux := http.NewServeMux()
+	protected := func(handler http.HandlerFunc) http.Handler {
+		return h.Authenticate(handler)
+	}
+
+	if h.AuthenticateMetrics != nil {
+		mux.Handle("GET /metrics", h.AuthenticateMetrics(h.Metrics.Handler()))
+	}
+
+	mux.HandleFunc("GET /livez", h.Health.Liveness)
+	mux.HandleFunc("GET /readyz", h.Health.Readiness)
+	mux.HandleFunc("GET /healthz", h.Health.Health)
+
+	mux.HandleFunc("POST /v1/auth/register", h.Auth.Register)
+	mux.HandleFunc("POST /v1/auth/login", h.Auth.Login)
+
+	mux.Handle("GET /v1/users/{username}", protected(h.User.Profile))
+	mux.Handle("PATCH /v1/me", protected(h.User.UpdateProfile))
+
+	mux.Handle("POST /v1/media", protected(h.Media.Upload))
+	if h.Blobs != nil {
+		mux.Handle("GET /blobs/{key...}", h.Blobs)
+	}
+
+	mux.Handle("POST /v1/posts", protected(h.Post.Create))
+	mux.Handle("GET /v1/posts/{id}", protected(h.Post.Get))
+	mux.Handle("DELETE /v1/posts/{id}", protected(h.Post.Delete))
+	mux.Handle("GET /v1/posts/{id}/thread", protected(h.Post.Thread))
+	mux.Handle("GET /v1/posts/{id}/replies", protected(h.Post.Replies))
+	mux.Handle("POST /v1/posts/{id}/like", protected(h.Like.Like))
+	mux.Handle("DELETE /v1/posts/{id}/like", protected(h.Like.Unlike))
+	mux.Handle("GET /v1/posts/{id}/likes", protected(h.Like.LikedBy))
+	mux.Handle("POST /v1/posts/{id}/bookmark", protected(h.Bookmark.Bookmark))
+	mux.Handle("DELETE /v1/posts/{id}/bookmark", protected(h.Bookmark.Unbookmark))
+	mux.Handle("POST /v1/posts/{id}/poll/vote", protected(h.Poll.Vote))
+	mux.Handle("POST /v1/posts/{id}/repost", protected(h.Repost.Repost))
+	mux.Handle("DELETE /v1/posts/{id}/repost", protected(h.Repost.Unrepost))
+	mux.Handle("GET /v1/users/{username}/posts", protected(h.Post.ListByAuthor))
+	mux.Handle("GET /v1/users/{username}/likes", protected(h.Like.LikedPosts))
+
+	mux.Handle("POST /v1/users/{username}/follow", protected(h.Follow.Follow))
+	mux.Handle("DELETE /v1/users/{username}/follow", protected(h.Follow.Unfollow))
+	mux.Handle("GET /v1/users/{username}/relationship", protected(h.Follow.Relationship))
+	mux.Handle("GET /v1/users/{username}/followers", protected(h.Follow.Followers))
+	mux.Handle("GET /v1/users/{username}/following", protected(h.Follow.Following))
+	mux.Handle("GET /v1/users/{username}/mutuals", protected(h.Follow.Mutuals))
+	mux.Handle("GET /v1/follow_requests", protected(h.Follow.Requests))
+	mux.Handle("POST /v1/follow_requests/{username}/approve", protected(h.Follow.Approve))
+	mux.Handle("POST /v1/follow_requests/{username}/reject", protected(h.Follow.Reject))
+
+	mux.Handle("POST /v1/users/{username}/block", protected(h.Block.Block))
+	mux.Handle("DELETE /v1/users/{username}/block", protected(h.Block.Unblock))
+	mux.Handle("POST /v1/users/{username}/mute", protected(h.Block.Mute))
+	mux.Handle("DELETE /v1/users/{username}/mute", protected(h.Block.Unmute))
+	mux.Handle("GET /v1/blocks", protected(h.Block.Blocked))
+	mux.Handle("GET /v1/mutes", protected(h.Block.Muted))
+	mux.Handle("GET /v1/muted_words", protected(h.MutedWord.List))
+	mux.Handle("POST /v1/muted_words", protected(h.MutedWord.Mute))
+	mux.Handle("DELETE /v1/muted_words/{id}", protected(h.MutedWord.Unmute))
+
+	mux.Handle("GET /v1/timeline/home", protected(h.Timeline.Home))
+
+	mux.Handle("POST /v1/lists", protected(h.List.Create))
+	mux.Handle("GET /v1/lists/subscribed", protected(h.List.Subscribed))
+	mux.Handle("GET /v1/lists/{id}", protected(h.List.Get))
+	mux.Handle("PATCH /v1/lists/{id}", protected(h.List.Update))
+	mux.Handle("DELETE /v1/lists/{id}", protected(h.List.Delete))
+	mux.Handle("GET /v1/lists/{id}/timeline", protected(h.List.Timeline))
+	mux.Handle("GET /v1/lists/{id}/members", protected(h.List.Members))
+	mux.Handle("POST /v1/lists/{id}/members/{username}", protected(h.List.AddMember))
+	mux.Handle("DELETE /v1/lists/{id}/members/{username}", protected(h.List.RemoveMember))
+	mux.Handle("GET /v1/lists/{id}/subscribers", protected(h.List.Subscribers))
+	mux.Handle("POST /v1/lists/{id}/subscribe", protected(h.List.Subscribe))
+	mux.Handle("DELETE /v1/lists/{id}/subscribe", protected(h.List.Unsubscribe))
+	mux.Handle("GET /v1/users/{username}/lists", protected(h.List.Owned))
+
+	mux.Handle("GET /v1/bookmarks", protected(h.Bookmark.List))
+	mux.Handle("GET /v1/bookmarks/folders", protected(h.Bookmark.ListFolders))
+	mux.Handle("POST /v1/bookmarks/folders", protected(h.Bookmark.CreateFolder))
+	mux.Handle("GET /v1/bookmarks/folders/{id}", protected(h.Bookmark.ListFolder))
+	mux.Handle("PATCH /v1/bookmarks/folders/{id}", protected(h.Bookmark.RenameFolder))
+	mux.Handle("DELETE /v1/bookmarks/folders/{id}", protected(h.Bookmark.DeleteFolder))
+
+	mux.Handle("GET /v1/notifications", protected(h.Notification.List))
+	mux.Handle("GET /v1/notifications/unread_count", protected(h.Notification.UnreadCount))
+	mux.Handle("POST /v1/notifications/read", protected(h.Notification.MarkAllRead))
+	mux.Handle("POST /v1/notifications/{id}/read", protected(h.Notification.MarkRead))
+	mux.Handle("GET /v1/notifications/preferences", protected(h.Notification.Preferences))
+	mux.Handle("PUT /v1/notifications/preferences", protected(h.Notification.UpdatePreferences))
+
+	mux.Handle("POST /v1/conversations", protected(h.Message.CreateConversation))
+	mux.Handle("GET /v1/conversations", protected(h.Message.ListConversations))
+	mux.Handle("GET /v1/conversations/{id}", protected(h.Message.GetConversation))
+	mux.Handle("DELETE /v1/conversations/{id}", protected(h.Message.DeleteConversation))
+	mux.Handle("GET /v1/conversations/{id}/messages", protected(h.Message.ListMessages))
+	mux.Handle("POST /v1/conversations/{id}/messages", protected(h.Message.Send))
+	mux.Handle("POST /v1/conversations/{id}/messages/{message_id}/read", protected(h.Message.MarkRead))
+	mux.Handle("GET /v1/messages/settings", protected(h.Message.Settings))
+	mux.Handle("PUT /v1/messages/settings", protected(h.Message.UpdateSettings))
+
+	mux.Handle("GET /v1/stream", h.AuthenticateStream(http.HandlerFunc(h.Stream.Events)))
+	mux.Handle("GET /v1/stream/ws", h.AuthenticateStream(http.HandlerFunc(h.Stream.WebSocket)))
+
+	return middleware.RequestID(
+		tracing.Middleware(
+			middleware.Logger(h.Logger)(
+				h.Metrics.Middleware(
+					middleware.CaptureRoute(mux),
+				),
+			),
+		),
+	)
+}
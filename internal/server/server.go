@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+)
+
+type Server struct {
+	Host            string
+	Port            string
+	Handler         http.Handler
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	Logger          *slog.Logger
+}
+
+type Options func(*Server)
+
+func WithHost(host string) Options {
+	return func(s *Server) {
+		s.Host = host
+	}
+}
+
+func WithPort(port string) Options {
+	return func(s *Server) {
+		s.Port = port
+	}
+}
+
+func WithHandler(handler http.Handler) Options {
+	return func(s *Server) {
+		s.Handler = handler
+	}
+}
+
+func WithReadTimeout(timeout time.Duration) Options {
+	return func(s *Server) {
+		s.ReadTimeout = timeout
+	}
+}
+
+func WithWriteTimeout(timeout time.Duration) Options {
+	return func(s *Server) {
+		s.WriteTimeout = timeout
+	}
+}
+
+func WithIdleTimeout(timeout time.Duration) Options {
+	return func(s *Server) {
+		s.IdleTimeout = timeout
+	}
+}
+
+func WithShutdownTimeout(timeout time.Duration) Options {
+	return func(s *Server) {
+		s.ShutdownTimeout = timeout
+	}
+}
+
+func WithLogger(logger *slog.Logger) Options {
+	return func(s *Server) {
+		s.Logger = logger
+	}
+}
+
+// Run serves HTTP until ctx is cancelled, then shuts down gracefully.
+func (s *Server) Run(ctx context.Context) error {
+	srv := &http.Server{
+		Addr:         net.JoinHostPort(s.Host, s.Port),
+		Handler:      s.Handler,
+		ReadTimeout:  s.ReadTimeout,
+		WriteTimeout: s.WriteTimeout,
+		IdleTimeout:  s.IdleTimeout,
+		ErrorLog:     slog.NewLogLogger(s.Logger.Handler(), slog.LevelError),
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		s.Logger.Info("starting http server", "addr", srv.Addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("http server failed: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.Logger.Info("shutting down http server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to shutdown http server: %w", err)
+	}
+	return <-errCh
+}
+
+func New(opts ...Options) *Server {
+	s := &Server{
+		Port:            "8080",
+		Handler:         http.NotFoundHandler(),
+		ShutdownTimeout: 15 * time.Second,
+		Logger:          slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/saleh-ghazimoradi/X/internal/dto"
 	"github.com/saleh-ghazimoradi/X/internal/repository"
 	"golang.org/x/crypto/bcrypt"
+	"log/slog"
+	"time"
 )
 
 var passwordCost = bcrypt.DefaultCost
@@ -19,7 +21,25 @@ type AuthService interface {
 }
 
 type authService struct {
-	userRepository repository.UserRepository
+	userRepository      repository.UserRepository
+	tokenService        TokenService
+	observePasswordHash func(time.Duration)
+	logger              *slog.Logger
+}
+
+type AuthOptions func(*authService)
+
+func WithAuthLogger(logger *slog.Logger) AuthOptions {
+	return func(a *authService) {
+		a.logger = logger
+	}
+}
+
+// WithPasswordHashObserver reports how long each bcrypt hash or comparison took.
+func WithPasswordHashObserver(observe func(time.Duration)) AuthOptions {
+	return func(a *authService) {
+		a.observePasswordHash = observe
+	}
 }
 
 func (a *authService) Register(ctx context.Context, input *dto.AuthenticationInput) (*dto.AuthenticationResponse, error) {
@@ -37,7 +57,9 @@ func (a *authService) Register(ctx context.Context, input *dto.AuthenticationInp
 		return nil, customErr.ErrEmailTaken
 	}
 
+	start := time.Now()
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(input.Password), passwordCost)
+	a.observePasswordHash(time.Since(start))
 	if err != nil {
 		return nil, fmt.Errorf("error hashing password: %v", err)
 	}
@@ -52,9 +74,16 @@ func (a *authService) Register(ctx context.Context, input *dto.AuthenticationInp
 		return nil, fmt.Errorf("error creating user: %v", err)
 	}
 
+	a.logger.InfoContext(ctx, "user registered", "registered_user_id", user.Id)
+
+	accessToken, err := a.tokenService.GenerateAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dto.AuthenticationResponse{
-		AccessToken: "a token",
-		User:        user,
+		AccessToken: accessToken,
+		User:        dto.NewSelfUser(user),
 	}, nil
 }
 
@@ -68,23 +97,45 @@ func (a *authService) Login(ctx context.Context, input *dto.Login) (*dto.Authent
 	if err != nil {
 		switch {
 		case errors.Is(err, customErr.ErrNotFound):
+			a.logger.InfoContext(ctx, "login failed", "reason", "unknown email", "email", input.Email)
 			return nil, customErr.ErrBadCredential
 		default:
 			return nil, err
 		}
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password)); err != nil {
+	if user.IsDeleted() {
+		a.logger.InfoContext(ctx, "login failed", "reason", "deactivated", "login_user_id", user.Id)
+		return nil, customErr.ErrBadCredential
+	}
+
+	start := time.Now()
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(input.Password))
+	a.observePasswordHash(time.Since(start))
+	if err != nil {
+		a.logger.InfoContext(ctx, "login failed", "reason", "wrong password", "login_user_id", user.Id)
 		return nil, customErr.ErrBadCredential
 	}
 
+	accessToken, err := a.tokenService.GenerateAccessToken(user)
+	if err != nil {
+		return nil, err
+	}
+
 	return &dto.AuthenticationResponse{
-		AccessToken: "a token",
-		User:        user,
+		AccessToken: accessToken,
+		User:        dto.NewSelfUser(user),
 	}, nil
 }
 
-func NewAuthService(userRepository repository.UserRepository) AuthService {
-	return &authService{
-		userRepository: userRepository,
+func NewAuthService(userRepository repository.UserRepository, tokenService TokenService, opts ...AuthOptions) AuthService {
+	a := &authService{
+		userRepository:      userRepository,
+		tokenService:        tokenService,
+		observePasswordHash: func(time.Duration) {},
+		logger:              slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(a)
 	}
+	return a
 }
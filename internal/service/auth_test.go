@@ -32,7 +32,7 @@ func TestAuthService_Register(t *testing.T) {
 			Username: validInput.Username,
 			Email:    validInput.Email,
 		}, nil)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		res, err := service.Register(ctx, validInput)
 
 		require.NoError(t, err)
@@ -50,7 +50,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepository := &mocks.UserRepositoryMock{}
 
 		userRepository.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, nil)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 
 		_, err := service.Register(ctx, validInput)
 		require.ErrorIs(t, err, customErr.ErrUserNameTaken)
@@ -64,7 +64,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepository := &mocks.UserRepositoryMock{}
 		userRepository.On("GetByUsername", mock.Anything, mock.Anything).Return(nil, customErr.ErrNotFound)
 		userRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, nil)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Register(ctx, validInput)
 		require.ErrorIs(t, err, customErr.ErrEmailTaken)
 		userRepository.AssertNotCalled(t, "Create")
@@ -79,7 +79,7 @@ func TestAuthService_Register(t *testing.T) {
 		userRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, customErr.ErrNotFound)
 		userRepository.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("something"))
 
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Register(ctx, validInput)
 		require.Error(t, err)
 		userRepository.AssertExpectations(t)
@@ -89,7 +89,7 @@ func TestAuthService_Register(t *testing.T) {
 		t.Parallel()
 		ctx := context.Background()
 		userRepository := &mocks.UserRepositoryMock{}
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Register(ctx, &dto.AuthenticationInput{})
 		require.ErrorIs(t, err, customErr.ErrValidation)
 		userRepository.AssertNotCalled(t, "GetByUsername")
@@ -115,7 +115,7 @@ func TestAuthService_Login(t *testing.T) {
 			Email:    validInput.Email,
 			Password: faker.Password,
 		}, nil)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Login(ctx, validInput)
 		require.NoError(t, err)
 		userRepository.AssertExpectations(t)
@@ -130,7 +130,7 @@ func TestAuthService_Login(t *testing.T) {
 			Email:    validInput.Email,
 			Password: faker.Password,
 		}, nil)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		validInput.Password = "something"
 		_, err := service.Login(ctx, validInput)
 		require.ErrorIs(t, err, customErr.ErrBadCredential)
@@ -143,7 +143,7 @@ func TestAuthService_Login(t *testing.T) {
 		userRepository := &mocks.UserRepositoryMock{}
 
 		userRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, customErr.ErrNotFound)
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Login(ctx, validInput)
 		require.ErrorIs(t, err, customErr.ErrBadCredential)
 		userRepository.AssertExpectations(t)
@@ -155,7 +155,7 @@ func TestAuthService_Login(t *testing.T) {
 		userRepository := &mocks.UserRepositoryMock{}
 
 		userRepository.On("GetByEmail", mock.Anything, mock.Anything).Return(nil, errors.New("something"))
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 		_, err := service.Login(ctx, validInput)
 		require.Error(t, err)
 		userRepository.AssertExpectations(t)
@@ -165,7 +165,7 @@ func TestAuthService_Login(t *testing.T) {
 		t.Parallel()
 		ctx := context.Background()
 		userRepository := &mocks.UserRepositoryMock{}
-		service := NewAuthService(userRepository)
+		service := NewAuthService(userRepository, testTokenService)
 
 		_, err := service.Login(ctx, &dto.Login{
 			Email:    "bob",
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+)
+
+// BlockChecker tells whether two users may interact.
+type BlockChecker interface {
+	// Blocked reports whether either user blocked the other.
+	Blocked(ctx context.Context, userId, otherId string) (bool, error)
+}
+
+// BlockService manages blocks and mutes. Listings do not go through it: the
+// repositories filter blocked and muted accounts in every query reading
+// content, so the rules cannot drift between read paths.
+type BlockService interface {
+	BlockChecker
+	Block(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	Unblock(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	Mute(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	Unmute(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	ListBlocked(ctx context.Context, userId string, input *dto.PageInput) (*dto.BlockListResponse, error)
+	ListMuted(ctx context.Context, userId string, input *dto.PageInput) (*dto.BlockListResponse, error)
+}
+
+type blockService struct {
+	blockRepository  repository.BlockRepository
+	userRepository   repository.UserRepository
+	followRepository repository.FollowRepository
+	timeline         TimelineFanOut
+	logger           *slog.Logger
+}
+
+type BlockOptions func(*blockService)
+
+func WithBlockTimeline(timeline TimelineFanOut) BlockOptions {
+	return func(b *blockService) {
+		b.timeline = timeline
+	}
+}
+
+func WithBlockLogger(logger *slog.Logger) BlockOptions {
+	return func(b *blockService) {
+		b.logger = logger
+	}
+}
+
+// target resolves the account to block or mute; users cannot block or mute
+// themselves.
+func (b *blockService) target(ctx context.Context, userId, username string) (*domain.User, error) {
+	user, err := b.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Id == userId {
+		return nil, fmt.Errorf("%w: cannot block or mute yourself", customErr.ErrValidation)
+	}
+	return user, nil
+}
+
+// Block is idempotent. A new block removes the follows between both accounts
+// and what each brought into the other's home timeline.
+func (b *blockService) Block(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	target, err := b.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := b.blockRepository.Block(ctx, userId, target.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		for _, pair := range [][2]string{{userId, target.Id}, {target.Id, userId}} {
+			if err := b.timeline.Unfollowed(ctx, pair[0], pair[1]); err != nil {
+				b.logger.ErrorContext(ctx, "timeline removal failed", "user_id", pair[0], "author_id", pair[1], "err", err.Error())
+			}
+		}
+	}
+	return b.relationship(ctx, userId, target.Id)
+}
+
+// Unblock is idempotent and does not restore the follows the block removed.
+func (b *blockService) Unblock(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	return b.toggle(ctx, userId, username, b.blockRepository.Unblock)
+}
+
+// Mute is idempotent. The muted account is not told and keeps seeing the user.
+func (b *blockService) Mute(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	return b.toggle(ctx, userId, username, b.blockRepository.Mute)
+}
+
+func (b *blockService) Unmute(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	return b.toggle(ctx, userId, username, b.blockRepository.Unmute)
+}
+
+func (b *blockService) toggle(ctx context.Context, userId, username string, toggle func(ctx context.Context, userId, targetId string) (bool, error)) (*dto.RelationshipResponse, error) {
+	target, err := b.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := toggle(ctx, userId, target.Id); err != nil {
+		return nil, err
+	}
+	return b.relationship(ctx, userId, target.Id)
+}
+
+func (b *blockService) relationship(ctx context.Context, userId, otherId string) (*dto.RelationshipResponse, error) {
+	relationship, err := b.followRepository.GetRelationship(ctx, userId, otherId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewRelationshipResponse(relationship), nil
+}
+
+func (b *blockService) Blocked(ctx context.Context, userId, otherId string) (bool, error) {
+	return b.blockRepository.Blocked(ctx, userId, otherId)
+}
+
+type listBlocks func(ctx context.Context, userId string, cursor *domain.Cursor, limit int) ([]*domain.Block, error)
+
+func (b *blockService) list(ctx context.Context, userId string, input *dto.PageInput, list listBlocks) (*dto.BlockListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	blocks, err := list(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewBlockListResponse(blocks, input.Limit), nil
+}
+
+func (b *blockService) ListBlocked(ctx context.Context, userId string, input *dto.PageInput) (*dto.BlockListResponse, error) {
+	return b.list(ctx, userId, input, b.blockRepository.ListBlocked)
+}
+
+func (b *blockService) ListMuted(ctx context.Context, userId string, input *dto.PageInput) (*dto.BlockListResponse, error) {
+	return b.list(ctx, userId, input, b.blockRepository.ListMuted)
+}
+
+func NewBlockService(blockRepository repository.BlockRepository, userRepository repository.UserRepository, followRepository repository.FollowRepository, opts ...BlockOptions) BlockService {
+	b := &blockService{
+		blockRepository:  blockRepository,
+		userRepository:   userRepository,
+		followRepository: followRepository,
+		timeline:         nopTimeline{},
+		logger:           slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// nopBlocks is used when no block checker is wired in: nobody is blocked.
+type nopBlocks struct{}
+
+func (nopBlocks) Blocked(context.Context, string, string) (bool, error) { return false, nil }
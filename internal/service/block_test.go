@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// unfollowRecorder records the timeline removals.
+type unfollowRecorder struct {
+	nopTimeline
+	removed [][2]string
+}
+
+func (u *unfollowRecorder) Unfollowed(_ context.Context, followerId, followeeId string) error {
+	u.removed = append(u.removed, [2]string{followerId, followeeId})
+	return nil
+}
+
+func TestBlockService_Block(t *testing.T) {
+	testCases := []struct {
+		name    string
+		created bool
+		removed [][2]string
+	}{
+		{name: "a new block clears both timelines", created: true, removed: [][2]string{{authorId, followeeId}, {followeeId, authorId}}},
+		{name: "blocking twice is a no-op", created: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			blockRepository := &mocks.BlockRepositoryMock{}
+			userRepository := &mocks.UserRepositoryMock{}
+			followRepository := &mocks.FollowRepositoryMock{}
+			timeline := &unfollowRecorder{}
+
+			userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+			blockRepository.On("Block", mock.Anything, authorId, followeeId).Return(tc.created, nil)
+			followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Blocking: true}, nil)
+
+			service := NewBlockService(blockRepository, userRepository, followRepository, WithBlockTimeline(timeline))
+			res, err := service.Block(context.Background(), authorId, "alice")
+			require.NoError(t, err)
+			require.True(t, res.Blocking)
+			require.False(t, res.Following)
+			require.Equal(t, tc.removed, timeline.removed)
+		})
+	}
+
+	t.Run("not yourself", func(t *testing.T) {
+		t.Parallel()
+		blockRepository := &mocks.BlockRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId}, nil)
+
+		service := NewBlockService(blockRepository, userRepository, &mocks.FollowRepositoryMock{})
+		_, err := service.Block(context.Background(), authorId, "bob")
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		blockRepository.AssertNotCalled(t, "Block")
+	})
+}
+
+func TestBlockService_Mute(t *testing.T) {
+	t.Parallel()
+	blockRepository := &mocks.BlockRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+	followRepository := &mocks.FollowRepositoryMock{}
+	timeline := &unfollowRecorder{}
+
+	userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+	blockRepository.On("Mute", mock.Anything, authorId, followeeId).Return(true, nil)
+	followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: true, Muting: true}, nil)
+
+	service := NewBlockService(blockRepository, userRepository, followRepository, WithBlockTimeline(timeline))
+	res, err := service.Mute(context.Background(), authorId, "alice")
+	require.NoError(t, err)
+	require.True(t, res.Muting)
+	require.True(t, res.Following, "a mute keeps the follow")
+	require.Empty(t, timeline.removed)
+}
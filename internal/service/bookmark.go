@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+)
+
+// BookmarkService manages the posts users saved for later. Bookmarks are
+// private: nothing here reads another user's bookmarks, they are not counted on
+// posts and saving one notifies nobody.
+type BookmarkService interface {
+	Bookmark(ctx context.Context, userId, postId string, input *dto.BookmarkInput) (*dto.BookmarkResponse, error)
+	Unbookmark(ctx context.Context, userId, postId string) (*dto.BookmarkResponse, error)
+	List(ctx context.Context, userId string, input *dto.PageInput) (*dto.PostListResponse, error)
+	ListFolder(ctx context.Context, userId, folderId string, input *dto.PageInput) (*dto.PostListResponse, error)
+	CreateFolder(ctx context.Context, userId string, input *dto.BookmarkFolderInput) (*dto.BookmarkFolderResponse, error)
+	ListFolders(ctx context.Context, userId string) (*dto.BookmarkFolderListResponse, error)
+	RenameFolder(ctx context.Context, userId, folderId string, input *dto.BookmarkFolderInput) (*dto.BookmarkFolderResponse, error)
+	DeleteFolder(ctx context.Context, userId, folderId string) error
+}
+
+type bookmarkService struct {
+	bookmarkRepository repository.BookmarkRepository
+	postRepository     repository.PostRepository
+}
+
+// Bookmark is idempotent: saving a post again only files it in the given
+// folder. A post the user may not see cannot be found.
+func (b *bookmarkService) Bookmark(ctx context.Context, userId, postId string, input *dto.BookmarkInput) (*dto.BookmarkResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := b.postRepository.GetById(ctx, userId, postId); err != nil {
+		return nil, err
+	}
+
+	bookmark := &domain.Bookmark{UserId: userId, PostId: postId, FolderId: input.FolderId}
+	if _, err := b.bookmarkRepository.Bookmark(ctx, bookmark); err != nil {
+		return nil, err
+	}
+	return &dto.BookmarkResponse{Bookmarked: true, FolderId: bookmark.FolderId}, nil
+}
+
+// Unbookmark is idempotent and also works once the post can no longer be seen.
+func (b *bookmarkService) Unbookmark(ctx context.Context, userId, postId string) (*dto.BookmarkResponse, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := b.bookmarkRepository.Unbookmark(ctx, userId, postId); err != nil {
+		return nil, err
+	}
+	return &dto.BookmarkResponse{Bookmarked: false}, nil
+}
+
+func (b *bookmarkService) List(ctx context.Context, userId string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	return b.list(ctx, userId, nil, input)
+}
+
+func (b *bookmarkService) ListFolder(ctx context.Context, userId, folderId string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	if !dto.IsValidId(folderId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := b.bookmarkRepository.GetFolder(ctx, userId, folderId); err != nil {
+		return nil, err
+	}
+	return b.list(ctx, userId, &folderId, input)
+}
+
+func (b *bookmarkService) list(ctx context.Context, userId string, folderId *string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	bookmarks, err := b.bookmarkRepository.List(ctx, userId, folderId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewBookmarkedPostListResponse(bookmarks, input.Limit), nil
+}
+
+func (b *bookmarkService) CreateFolder(ctx context.Context, userId string, input *dto.BookmarkFolderInput) (*dto.BookmarkFolderResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	folder, err := b.bookmarkRepository.CreateFolder(ctx, &domain.BookmarkFolder{UserId: userId, Name: input.Name})
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewBookmarkFolderResponse(folder), nil
+}
+
+func (b *bookmarkService) ListFolders(ctx context.Context, userId string) (*dto.BookmarkFolderListResponse, error) {
+	folders, err := b.bookmarkRepository.ListFolders(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewBookmarkFolderListResponse(folders), nil
+}
+
+func (b *bookmarkService) RenameFolder(ctx context.Context, userId, folderId string, input *dto.BookmarkFolderInput) (*dto.BookmarkFolderResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !dto.IsValidId(folderId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	folder, err := b.bookmarkRepository.RenameFolder(ctx, userId, folderId, input.Name)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewBookmarkFolderResponse(folder), nil
+}
+
+// DeleteFolder keeps the bookmarks filed in the folder, unfiled.
+func (b *bookmarkService) DeleteFolder(ctx context.Context, userId, folderId string) error {
+	if !dto.IsValidId(folderId) {
+		return customErr.ErrNotFound
+	}
+	return b.bookmarkRepository.DeleteFolder(ctx, userId, folderId)
+}
+
+func NewBookmarkService(bookmarkRepository repository.BookmarkRepository, postRepository repository.PostRepository) BookmarkService {
+	return &bookmarkService{
+		bookmarkRepository: bookmarkRepository,
+		postRepository:     postRepository,
+	}
+}
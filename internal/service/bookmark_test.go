@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const folderId = "6ba7b815-9dad-11d1-80b4-00c04fd430c8"
+
+func TestBookmarkService_Bookmark(t *testing.T) {
+	t.Run("files the bookmark in the folder", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		folder := folderId
+
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId}, nil)
+		bookmarkRepository.On("Bookmark", mock.Anything, &domain.Bookmark{UserId: authorId, PostId: postId, FolderId: &folder}).Return(true, nil)
+
+		service := NewBookmarkService(bookmarkRepository, postRepository)
+		res, err := service.Bookmark(context.Background(), authorId, postId, &dto.BookmarkInput{FolderId: &folder})
+		require.NoError(t, err)
+		require.True(t, res.Bookmarked)
+		require.Equal(t, folderId, *res.FolderId)
+		bookmarkRepository.AssertExpectations(t)
+	})
+
+	t.Run("post the user may not see", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(nil, customErr.ErrNotFound)
+
+		service := NewBookmarkService(bookmarkRepository, postRepository)
+		_, err := service.Bookmark(context.Background(), authorId, postId, &dto.BookmarkInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		bookmarkRepository.AssertNotCalled(t, "Bookmark")
+	})
+
+	t.Run("folder of another user", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		folder := folderId
+
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId}, nil)
+		bookmarkRepository.On("Bookmark", mock.Anything, mock.Anything).Return(false, customErr.ErrNotFound)
+
+		service := NewBookmarkService(bookmarkRepository, postRepository)
+		_, err := service.Bookmark(context.Background(), authorId, postId, &dto.BookmarkInput{FolderId: &folder})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
+
+func TestBookmarkService_Unbookmark(t *testing.T) {
+	t.Parallel()
+	bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+	bookmarkRepository.On("Unbookmark", mock.Anything, authorId, postId).Return(false, nil)
+
+	service := NewBookmarkService(bookmarkRepository, &mocks.PostRepositoryMock{})
+	res, err := service.Unbookmark(context.Background(), authorId, postId)
+	require.NoError(t, err)
+	require.False(t, res.Bookmarked)
+}
+
+func TestBookmarkService_List(t *testing.T) {
+	now := time.Now()
+	bookmarks := []*domain.Bookmark{
+		{UserId: authorId, PostId: postId, CreatedAt: now, Post: &domain.Post{Id: postId, Author: &domain.User{Id: followeeId}}},
+		{UserId: authorId, PostId: followeeId, CreatedAt: now.Add(-time.Minute), Post: &domain.Post{Id: followeeId, Author: &domain.User{Id: followeeId}}},
+	}
+
+	t.Run("pages by bookmark time", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		bookmarkRepository.On("List", mock.Anything, authorId, (*string)(nil), (*domain.Cursor)(nil), 2).Return(bookmarks, nil)
+
+		service := NewBookmarkService(bookmarkRepository, &mocks.PostRepositoryMock{})
+		res, err := service.List(context.Background(), authorId, &dto.PageInput{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, res.Posts, 1)
+
+		cursor, err := dto.DecodeCursor(res.NextCursor)
+		require.NoError(t, err)
+		require.Equal(t, postId, cursor.Id)
+		require.True(t, now.Equal(cursor.Time))
+	})
+
+	t.Run("folder of another user", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		bookmarkRepository.On("GetFolder", mock.Anything, authorId, folderId).Return(nil, customErr.ErrNotFound)
+
+		service := NewBookmarkService(bookmarkRepository, &mocks.PostRepositoryMock{})
+		_, err := service.ListFolder(context.Background(), authorId, folderId, &dto.PageInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		bookmarkRepository.AssertNotCalled(t, "List")
+	})
+}
+
+func TestBookmarkService_CreateFolder(t *testing.T) {
+	t.Run("stores the sanitized name", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		want := &domain.BookmarkFolder{UserId: authorId, Name: "Read later"}
+		bookmarkRepository.On("CreateFolder", mock.Anything, want).Return(&domain.BookmarkFolder{Id: folderId, UserId: authorId, Name: "Read later"}, nil)
+
+		service := NewBookmarkService(bookmarkRepository, &mocks.PostRepositoryMock{})
+		res, err := service.CreateFolder(context.Background(), authorId, &dto.BookmarkFolderInput{Name: " Read  later "})
+		require.NoError(t, err)
+		require.Equal(t, folderId, res.Id)
+		require.Equal(t, "Read later", res.Name)
+	})
+
+	t.Run("name taken", func(t *testing.T) {
+		t.Parallel()
+		bookmarkRepository := &mocks.BookmarkRepositoryMock{}
+		bookmarkRepository.On("CreateFolder", mock.Anything, mock.Anything).Return(nil, customErr.ErrFolderTaken)
+
+		service := NewBookmarkService(bookmarkRepository, &mocks.PostRepositoryMock{})
+		_, err := service.CreateFolder(context.Background(), authorId, &dto.BookmarkFolderInput{Name: "Recipes"})
+		require.ErrorIs(t, err, customErr.ErrFolderTaken)
+	})
+}
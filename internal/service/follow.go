@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+)
+
+type FollowService interface {
+	Follow(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	Unfollow(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	GetRelationship(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	ListFollowers(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	ListFollowing(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	ListMutuals(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	FollowRequestApprover
+	ListRequests(ctx context.Context, userId string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	Approve(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+	Reject(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error)
+}
+
+type followService struct {
+	followRepository repository.FollowRepository
+	userRepository   repository.UserRepository
+	blocks           BlockChecker
+	timeline         TimelineFanOut
+	notifier         Notifier
+	logger           *slog.Logger
+}
+
+type FollowOptions func(*followService)
+
+func WithFollowBlocks(blocks BlockChecker) FollowOptions {
+	return func(f *followService) {
+		f.blocks = blocks
+	}
+}
+
+func WithFollowTimeline(timeline TimelineFanOut) FollowOptions {
+	return func(f *followService) {
+		f.timeline = timeline
+	}
+}
+
+func WithFollowNotifier(notifier Notifier) FollowOptions {
+	return func(f *followService) {
+		f.notifier = notifier
+	}
+}
+
+func WithFollowLogger(logger *slog.Logger) FollowOptions {
+	return func(f *followService) {
+		f.logger = logger
+	}
+}
+
+// target resolves the other end of a follow edge; deactivated accounts cannot be
+// followed and following oneself is rejected.
+func (f *followService) target(ctx context.Context, userId, username string) (*domain.User, error) {
+	user, err := f.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Id == userId {
+		return nil, customErr.ErrSelfFollow
+	}
+	return user, nil
+}
+
+// Follow is idempotent: following an account that is already followed succeeds
+// without changing anything. Nobody can follow across a block. Following a
+// protected account only requests to follow it, until the account approves.
+func (f *followService) Follow(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	followee, err := f.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if followee.IsDeleted() {
+		return nil, customErr.ErrUserDeleted
+	}
+
+	blocked, err := f.blocks.Blocked(ctx, userId, followee.Id)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		return nil, fmt.Errorf("%w: @%s cannot be followed", customErr.ErrForbidden, followee.Username)
+	}
+
+	outcome, err := f.followRepository.Follow(ctx, userId, followee.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch outcome {
+	case domain.FollowCreated:
+		if err := f.timeline.Followed(ctx, userId, followee); err != nil {
+			f.logger.ErrorContext(ctx, "timeline backfill failed", "followee_id", followee.Id, "err", err.Error())
+		}
+		f.notifier.Notify(ctx, &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: followee.Id, ActorId: userId})
+	case domain.FollowRequested:
+		f.notifier.Notify(ctx, &domain.NotificationEvent{Type: domain.NotificationFollowRequest, RecipientId: followee.Id, ActorId: userId})
+	}
+	return f.relationship(ctx, userId, followee.Id)
+}
+
+// Unfollow is idempotent. It withdraws a pending request to follow as well.
+func (f *followService) Unfollow(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	followee, err := f.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := f.followRepository.Unfollow(ctx, userId, followee.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		if err := f.timeline.Unfollowed(ctx, userId, followee.Id); err != nil {
+			f.logger.ErrorContext(ctx, "timeline removal failed", "followee_id", followee.Id, "err", err.Error())
+		}
+	}
+	return f.relationship(ctx, userId, followee.Id)
+}
+
+func (f *followService) GetRelationship(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	other, err := f.active(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	return f.relationship(ctx, userId, other.Id)
+}
+
+func (f *followService) relationship(ctx context.Context, userId, otherId string) (*dto.RelationshipResponse, error) {
+	relationship, err := f.followRepository.GetRelationship(ctx, userId, otherId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewRelationshipResponse(relationship), nil
+}
+
+// active resolves a username to an account that has not been deactivated.
+func (f *followService) active(ctx context.Context, username string) (*domain.User, error) {
+	user, err := f.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, customErr.ErrNotFound
+	}
+	return user, nil
+}
+
+type listFollows func(ctx context.Context, viewerId, userId string, cursor *domain.Cursor, limit int) ([]*domain.Follow, error)
+
+// list pages through the follows of the named account as seen by the user.
+func (f *followService) list(ctx context.Context, userId, username string, input *dto.PageInput, list listFollows) (*dto.FollowListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := f.active(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	follows, err := list(ctx, userId, user.Id, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewFollowListResponse(follows, input.Limit), nil
+}
+
+func (f *followService) ListFollowers(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	return f.list(ctx, userId, username, input, f.followRepository.ListFollowers)
+}
+
+func (f *followService) ListFollowing(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	return f.list(ctx, userId, username, input, f.followRepository.ListFollowing)
+}
+
+func (f *followService) ListMutuals(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	return f.list(ctx, userId, username, input, f.followRepository.ListMutuals)
+}
+
+func (f *followService) ListRequests(ctx context.Context, userId string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	requests, err := f.followRepository.ListRequests(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewFollowListResponse(requests, input.Limit), nil
+}
+
+// Approve makes the requester a follower. The relationship is returned as seen
+// by the user approving.
+func (f *followService) Approve(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	requester, err := f.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := f.followRepository.Approve(ctx, userId, requester.Id)
+	if err != nil {
+		return nil, err
+	}
+	if !approved {
+		return nil, fmt.Errorf("%w: no pending request from @%s", customErr.ErrNotFound, requester.Username)
+	}
+
+	f.approved(ctx, userId, []string{requester.Id})
+	return f.relationship(ctx, userId, requester.Id)
+}
+
+// Reject is idempotent; the requester is not told and may request again.
+func (f *followService) Reject(ctx context.Context, userId, username string) (*dto.RelationshipResponse, error) {
+	requester, err := f.target(ctx, userId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := f.followRepository.Reject(ctx, userId, requester.Id); err != nil {
+		return nil, err
+	}
+	return f.relationship(ctx, userId, requester.Id)
+}
+
+// ApproveAll approves the pending requests of a user who switched to public.
+func (f *followService) ApproveAll(ctx context.Context, userId string) error {
+	approved, err := f.followRepository.ApproveAll(ctx, userId)
+	if err != nil {
+		return err
+	}
+
+	f.approved(ctx, userId, approved)
+	return nil
+}
+
+// approved brings the posts of the user into the home timelines of the new
+// followers. The user approved them, so is not notified of the follows.
+func (f *followService) approved(ctx context.Context, userId string, followerIds []string) {
+	if len(followerIds) == 0 {
+		return
+	}
+
+	followee, err := f.userRepository.GetById(ctx, userId)
+	if err != nil {
+		f.logger.ErrorContext(ctx, "timeline backfill failed", "followee_id", userId, "err", err.Error())
+		return
+	}
+
+	for _, followerId := range followerIds {
+		if err := f.timeline.Followed(ctx, followerId, followee); err != nil {
+			f.logger.ErrorContext(ctx, "timeline backfill failed", "followee_id", followee.Id, "err", err.Error())
+		}
+	}
+}
+
+func NewFollowService(followRepository repository.FollowRepository, userRepository repository.UserRepository, opts ...FollowOptions) FollowService {
+	f := &followService{
+		followRepository: followRepository,
+		userRepository:   userRepository,
+		blocks:           nopBlocks{},
+		timeline:         nopTimeline{},
+		notifier:         nopNotifier{},
+		logger:           slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
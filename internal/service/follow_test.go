@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const followeeId = "6ba7b814-9dad-11d1-80b4-00c04fd430c8"
+
+func TestFollowService_Follow(t *testing.T) {
+	alice := &domain.User{Id: followeeId, Username: "alice"}
+
+	t.Run("can follow", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(alice, nil)
+		followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowCreated, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: true}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		res, err := service.Follow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		require.True(t, res.Following)
+		require.False(t, res.FollowedBy)
+		followRepository.AssertExpectations(t)
+	})
+
+	t.Run("following twice is a no-op", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(alice, nil)
+		followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowUnchanged, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: true}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		res, err := service.Follow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		require.True(t, res.Following)
+	})
+
+	t.Run("protected accounts are requested", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+		notifier := &notificationRecorder{}
+
+		// alice was public when looked up and turned protected before the follow.
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(alice, nil)
+		followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowRequested, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Requested: true}, nil)
+
+		service := NewFollowService(followRepository, userRepository, WithFollowNotifier(notifier), WithFollowTimeline(NewTimelineService(timelineRepository)))
+		res, err := service.Follow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		require.False(t, res.Following)
+		require.True(t, res.Requested)
+		require.Equal(t, []string{followeeId}, notifier.recipients(domain.NotificationFollowRequest))
+		require.Empty(t, notifier.recipients(domain.NotificationFollow))
+		timelineRepository.AssertNotCalled(t, "Backfill")
+	})
+
+	t.Run("self follow", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId, Username: "bob"}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		_, err := service.Follow(context.Background(), authorId, "bob")
+		require.ErrorIs(t, err, customErr.ErrSelfFollow)
+		followRepository.AssertNotCalled(t, "Follow")
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		deletedAt := time.Now()
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, DeletedAt: &deletedAt}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		_, err := service.Follow(context.Background(), authorId, "alice")
+		require.ErrorIs(t, err, customErr.ErrUserDeleted)
+		followRepository.AssertNotCalled(t, "Follow")
+	})
+
+	t.Run("not across a block", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(alice, nil)
+
+		service := NewFollowService(followRepository, userRepository, WithFollowBlocks(blockList{{followeeId, authorId}: true}))
+		_, err := service.Follow(context.Background(), authorId, "alice")
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		followRepository.AssertNotCalled(t, "Follow")
+	})
+
+	t.Run("deleted while following", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(alice, nil)
+		followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowOutcome(""), customErr.ErrUserDeleted)
+
+		service := NewFollowService(followRepository, userRepository)
+		_, err := service.Follow(context.Background(), authorId, "alice")
+		require.ErrorIs(t, err, customErr.ErrUserDeleted)
+	})
+}
+
+func TestFollowService_Unfollow(t *testing.T) {
+	t.Run("can unfollow", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+		followRepository.On("Unfollow", mock.Anything, authorId, followeeId).Return(true, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		res, err := service.Unfollow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		require.False(t, res.Following)
+		followRepository.AssertExpectations(t)
+	})
+
+	t.Run("self unfollow", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId}, nil)
+
+		service := NewFollowService(&mocks.FollowRepositoryMock{}, userRepository)
+		_, err := service.Unfollow(context.Background(), authorId, "bob")
+		require.ErrorIs(t, err, customErr.ErrSelfFollow)
+	})
+}
+
+func TestFollowService_Approve(t *testing.T) {
+	t.Run("approved followers get a backfilled timeline", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId, Protected: true}, nil)
+		followRepository.On("Approve", mock.Anything, authorId, followeeId).Return(true, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{FollowedBy: true}, nil)
+		timelineRepository.On("Backfill", mock.Anything, followeeId, authorId, mock.Anything).Return(nil)
+
+		service := NewFollowService(followRepository, userRepository, WithFollowTimeline(NewTimelineService(timelineRepository)))
+		res, err := service.Approve(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		require.True(t, res.FollowedBy)
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("no pending request", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+		followRepository.On("Approve", mock.Anything, authorId, followeeId).Return(false, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		_, err := service.Approve(context.Background(), authorId, "alice")
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
+
+func TestFollowService_Reject(t *testing.T) {
+	t.Parallel()
+	followRepository := &mocks.FollowRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+
+	userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+	followRepository.On("Reject", mock.Anything, authorId, followeeId).Return(false, nil)
+	followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{}, nil)
+
+	service := NewFollowService(followRepository, userRepository)
+	res, err := service.Reject(context.Background(), authorId, "alice")
+	require.NoError(t, err)
+	require.False(t, res.FollowedBy)
+}
+
+func TestFollowService_ApproveAll(t *testing.T) {
+	t.Parallel()
+	followRepository := &mocks.FollowRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+	timelineRepository := &mocks.TimelineRepositoryMock{}
+
+	followRepository.On("ApproveAll", mock.Anything, authorId).Return([]string{followeeId, postId}, nil)
+	userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId}, nil)
+	timelineRepository.On("Backfill", mock.Anything, mock.Anything, authorId, mock.Anything).Return(nil)
+
+	service := NewFollowService(followRepository, userRepository, WithFollowTimeline(NewTimelineService(timelineRepository)))
+	require.NoError(t, service.ApproveAll(context.Background(), authorId))
+	timelineRepository.AssertNumberOfCalls(t, "Backfill", 2)
+}
+
+func TestFollowService_ListFollowers(t *testing.T) {
+	t.Run("asks for one more than the page", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		now := time.Now()
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		followRepository.On("ListFollowers", mock.Anything, authorId, followeeId, (*domain.Cursor)(nil), 2).Return([]*domain.Follow{
+			{CreatedAt: now, User: &domain.User{Id: authorId, Username: "bob", FollowingCount: 1}},
+			{CreatedAt: now.Add(-time.Second), User: &domain.User{Id: postId, Username: "carol"}},
+		}, nil)
+
+		service := NewFollowService(followRepository, userRepository)
+		res, err := service.ListFollowers(context.Background(), authorId, "alice", &dto.PageInput{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, res.Users, 1)
+		require.Equal(t, "bob", res.Users[0].Username)
+		require.Equal(t, 1, res.Users[0].FollowingCount)
+		require.Equal(t, dto.EncodeCursor(&domain.Cursor{Time: now, Id: authorId}), res.NextCursor)
+	})
+
+	t.Run("deleted user is not found", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		deletedAt := time.Now()
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, DeletedAt: &deletedAt}, nil)
+
+		service := NewFollowService(&mocks.FollowRepositoryMock{}, userRepository)
+		_, err := service.ListFollowers(context.Background(), authorId, "alice", &dto.PageInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+)
+
+type LikeService interface {
+	Like(ctx context.Context, userId, postId string) (*dto.LikeResponse, error)
+	Unlike(ctx context.Context, userId, postId string) (*dto.LikeResponse, error)
+	ListLikedBy(ctx context.Context, userId, postId string, input *dto.PageInput) (*dto.LikeListResponse, error)
+	ListLikedPosts(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.PostListResponse, error)
+}
+
+type likeService struct {
+	likeRepository repository.LikeRepository
+	postRepository repository.PostRepository
+	userRepository repository.UserRepository
+	notifier       Notifier
+}
+
+type LikeOptions func(*likeService)
+
+func WithLikeNotifier(notifier Notifier) LikeOptions {
+	return func(l *likeService) {
+		l.notifier = notifier
+	}
+}
+
+// Like is idempotent: liking a post twice counts once. A post of an account
+// the user blocked or was blocked by cannot be found.
+func (l *likeService) Like(ctx context.Context, userId, postId string) (*dto.LikeResponse, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	post, err := l.postRepository.GetById(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := l.likeRepository.Like(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		l.notifier.Notify(ctx, &domain.NotificationEvent{Type: domain.NotificationLike, RecipientId: post.AuthorId, ActorId: userId, PostId: &post.Id})
+	}
+	return l.response(ctx, postId, true)
+}
+
+// Unlike is idempotent and also works once the post has been deleted.
+func (l *likeService) Unlike(ctx context.Context, userId, postId string) (*dto.LikeResponse, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := l.likeRepository.Unlike(ctx, userId, postId); err != nil {
+		return nil, err
+	}
+	return l.response(ctx, postId, false)
+}
+
+func (l *likeService) response(ctx context.Context, postId string, liked bool) (*dto.LikeResponse, error) {
+	count, err := l.likeRepository.CountLikes(ctx, postId)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LikeResponse{Liked: liked, LikeCount: count}, nil
+}
+
+func (l *likeService) ListLikedBy(ctx context.Context, userId, postId string, input *dto.PageInput) (*dto.LikeListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := l.postRepository.GetById(ctx, userId, postId); err != nil {
+		return nil, err
+	}
+
+	likes, err := l.likeRepository.ListLikedBy(ctx, userId, postId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewLikeListResponse(likes, input.Limit), nil
+}
+
+func (l *likeService) ListLikedPosts(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := l.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, customErr.ErrNotFound
+	}
+
+	likes, err := l.likeRepository.ListLikedPosts(ctx, userId, user.Id, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewLikedPostListResponse(likes, input.Limit), nil
+}
+
+func NewLikeService(likeRepository repository.LikeRepository, postRepository repository.PostRepository, userRepository repository.UserRepository, opts ...LikeOptions) LikeService {
+	l := &likeService{
+		likeRepository: likeRepository,
+		postRepository: postRepository,
+		userRepository: userRepository,
+		notifier:       nopNotifier{},
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestLikeService_Like(t *testing.T) {
+	t.Run("can like", func(t *testing.T) {
+		t.Parallel()
+		likeRepository := &mocks.LikeRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId}, nil)
+		likeRepository.On("Like", mock.Anything, authorId, postId).Return(true, nil)
+		likeRepository.On("CountLikes", mock.Anything, postId).Return(int64(7), nil)
+
+		service := NewLikeService(likeRepository, postRepository, &mocks.UserRepositoryMock{})
+		res, err := service.Like(context.Background(), authorId, postId)
+		require.NoError(t, err)
+		require.True(t, res.Liked)
+		require.Equal(t, int64(7), res.LikeCount)
+		likeRepository.AssertExpectations(t)
+	})
+
+	t.Run("liking twice is a no-op", func(t *testing.T) {
+		t.Parallel()
+		likeRepository := &mocks.LikeRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId}, nil)
+		likeRepository.On("Like", mock.Anything, authorId, postId).Return(false, nil)
+		likeRepository.On("CountLikes", mock.Anything, postId).Return(int64(1), nil)
+
+		service := NewLikeService(likeRepository, postRepository, &mocks.UserRepositoryMock{})
+		res, err := service.Like(context.Background(), authorId, postId)
+		require.NoError(t, err)
+		require.True(t, res.Liked)
+	})
+
+	t.Run("deleted post", func(t *testing.T) {
+		t.Parallel()
+		likeRepository := &mocks.LikeRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(nil, customErr.ErrNotFound)
+
+		service := NewLikeService(likeRepository, postRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Like(context.Background(), authorId, postId)
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		likeRepository.AssertNotCalled(t, "Like")
+	})
+}
+
+func TestLikeService_Unlike(t *testing.T) {
+	likeRepository := &mocks.LikeRepositoryMock{}
+	likeRepository.On("Unlike", mock.Anything, authorId, postId).Return(false, nil)
+	likeRepository.On("CountLikes", mock.Anything, postId).Return(int64(0), nil)
+
+	service := NewLikeService(likeRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{})
+	res, err := service.Unlike(context.Background(), authorId, postId)
+	require.NoError(t, err)
+	require.False(t, res.Liked)
+	likeRepository.AssertExpectations(t)
+}
+
+func TestLikeService_ListLikedPosts(t *testing.T) {
+	likeRepository := &mocks.LikeRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+	likedAt := time.Now()
+
+	userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId}, nil)
+	likeRepository.On("ListLikedPosts", mock.Anything, followeeId, authorId, (*domain.Cursor)(nil), 2).Return([]*domain.Like{
+		{PostId: postId, CreatedAt: likedAt, Post: &domain.Post{Id: postId, CreatedAt: likedAt.Add(-time.Hour), LikeCount: 3}},
+		{PostId: followeeId, CreatedAt: likedAt.Add(-time.Second), Post: &domain.Post{Id: followeeId}},
+	}, nil)
+
+	service := NewLikeService(likeRepository, &mocks.PostRepositoryMock{}, userRepository)
+	res, err := service.ListLikedPosts(context.Background(), followeeId, "bob", &dto.PageInput{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Posts, 1)
+	require.Equal(t, int64(3), res.Posts[0].LikeCount)
+	require.Equal(t, dto.EncodeCursor(&domain.Cursor{Time: likedAt, Id: postId}), res.NextCursor)
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+)
+
+// ListService manages lists. Only the owner of a list may change it or its
+// members; anyone who may see a public list may subscribe to it and read its
+// timeline.
+type ListService interface {
+	Create(ctx context.Context, userId string, input *dto.CreateListInput) (*dto.ListResponse, error)
+	Get(ctx context.Context, userId, listId string) (*dto.ListResponse, error)
+	Update(ctx context.Context, userId, listId string, input *dto.UpdateListInput) (*dto.ListResponse, error)
+	Delete(ctx context.Context, userId, listId string) error
+	ListOwned(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.ListsResponse, error)
+	ListSubscribed(ctx context.Context, userId string, input *dto.PageInput) (*dto.ListsResponse, error)
+	AddMember(ctx context.Context, userId, listId, username string) (*dto.ListResponse, error)
+	RemoveMember(ctx context.Context, userId, listId, username string) (*dto.ListResponse, error)
+	ListMembers(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	Subscribe(ctx context.Context, userId, listId string) (*dto.ListSubscriptionResponse, error)
+	Unsubscribe(ctx context.Context, userId, listId string) (*dto.ListSubscriptionResponse, error)
+	ListSubscribers(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.FollowListResponse, error)
+	Timeline(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.PostListResponse, error)
+}
+
+type listService struct {
+	listRepository repository.ListRepository
+	userRepository repository.UserRepository
+}
+
+func (l *listService) Create(ctx context.Context, userId string, input *dto.CreateListInput) (*dto.ListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	owner, err := l.userRepository.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.IsDeleted() {
+		return nil, customErr.ErrUserDeleted
+	}
+
+	list, err := l.listRepository.Create(ctx, input.List(owner.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	list.Owner = owner
+	return dto.NewListResponse(list), nil
+}
+
+// get resolves a list the user may see; private lists of others, and lists
+// across a block, cannot be found.
+func (l *listService) get(ctx context.Context, userId, listId string) (*domain.List, error) {
+	if !dto.IsValidId(listId) {
+		return nil, customErr.ErrNotFound
+	}
+	return l.listRepository.GetById(ctx, userId, listId)
+}
+
+// owned resolves a list the user may change.
+func (l *listService) owned(ctx context.Context, userId, listId string) (*domain.List, error) {
+	list, err := l.get(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.OwnerId != userId {
+		return nil, fmt.Errorf("%w: only the owner may change the list", customErr.ErrForbidden)
+	}
+	return list, nil
+}
+
+func (l *listService) Get(ctx context.Context, userId, listId string) (*dto.ListResponse, error) {
+	list, err := l.get(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListResponse(list), nil
+}
+
+// Update makes the list private as well, which drops its subscribers.
+func (l *listService) Update(ctx context.Context, userId, listId string, input *dto.UpdateListInput) (*dto.ListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := l.owned(ctx, userId, listId); err != nil {
+		return nil, err
+	}
+
+	list, err := l.listRepository.Update(ctx, userId, listId, input.ListUpdate())
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListResponse(list), nil
+}
+
+func (l *listService) Delete(ctx context.Context, userId, listId string) error {
+	if _, err := l.owned(ctx, userId, listId); err != nil {
+		return err
+	}
+	return l.listRepository.Delete(ctx, userId, listId)
+}
+
+func (l *listService) ListOwned(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.ListsResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	owner, err := l.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if owner.IsDeleted() {
+		return nil, customErr.ErrNotFound
+	}
+
+	lists, err := l.listRepository.ListOwned(ctx, userId, owner.Id, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListsResponse(lists, input.Limit), nil
+}
+
+func (l *listService) ListSubscribed(ctx context.Context, userId string, input *dto.PageInput) (*dto.ListsResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := l.listRepository.ListSubscribed(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewSubscribedListsResponse(subscriptions, input.Limit), nil
+}
+
+// AddMember is idempotent. Deactivated accounts cannot be added, and neither can
+// accounts that blocked the owner or were blocked by them.
+func (l *listService) AddMember(ctx context.Context, userId, listId, username string) (*dto.ListResponse, error) {
+	list, err := l.owned(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := l.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if member.IsDeleted() {
+		return nil, customErr.ErrUserDeleted
+	}
+
+	if _, err := l.listRepository.AddMember(ctx, list.Id, member.Id); err != nil {
+		return nil, err
+	}
+	return l.Get(ctx, userId, list.Id)
+}
+
+// RemoveMember is idempotent and also lets owners drop accounts that have since
+// been deactivated.
+func (l *listService) RemoveMember(ctx context.Context, userId, listId, username string) (*dto.ListResponse, error) {
+	list, err := l.owned(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := l.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := l.listRepository.RemoveMember(ctx, list.Id, member.Id); err != nil {
+		return nil, err
+	}
+	return l.Get(ctx, userId, list.Id)
+}
+
+func (l *listService) ListMembers(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	return l.listUsers(ctx, userId, listId, input, l.listRepository.ListMembers)
+}
+
+func (l *listService) ListSubscribers(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.FollowListResponse, error) {
+	return l.listUsers(ctx, userId, listId, input, l.listRepository.ListSubscribers)
+}
+
+type listMemberships func(ctx context.Context, viewerId, listId string, cursor *domain.Cursor, limit int) ([]*domain.ListMembership, error)
+
+// listUsers pages through the members or subscribers of a list the user may see.
+func (l *listService) listUsers(ctx context.Context, userId, listId string, input *dto.PageInput, list listMemberships) (*dto.FollowListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := l.get(ctx, userId, listId); err != nil {
+		return nil, err
+	}
+
+	memberships, err := list(ctx, userId, listId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewListUserListResponse(memberships, input.Limit), nil
+}
+
+// Subscribe is idempotent. Owners read their lists without subscribing to them.
+func (l *listService) Subscribe(ctx context.Context, userId, listId string) (*dto.ListSubscriptionResponse, error) {
+	list, err := l.get(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.OwnerId == userId {
+		return nil, fmt.Errorf("%w: owners cannot subscribe to their own lists", customErr.ErrValidation)
+	}
+
+	if _, err := l.listRepository.Subscribe(ctx, list.Id, userId); err != nil {
+		return nil, err
+	}
+	return &dto.ListSubscriptionResponse{Subscribed: true}, nil
+}
+
+// Unsubscribe is idempotent and also works once the list can no longer be seen.
+func (l *listService) Unsubscribe(ctx context.Context, userId, listId string) (*dto.ListSubscriptionResponse, error) {
+	if !dto.IsValidId(listId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	if _, err := l.listRepository.Unsubscribe(ctx, listId, userId); err != nil {
+		return nil, err
+	}
+	return &dto.ListSubscriptionResponse{Subscribed: false}, nil
+}
+
+// Timeline returns the posts of the members of the list, newest first.
+func (l *listService) Timeline(ctx context.Context, userId, listId string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	list, err := l.get(ctx, userId, listId)
+	if err != nil {
+		return nil, err
+	}
+
+	posts, err := l.listRepository.Timeline(ctx, userId, list.Id, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPostListResponse(posts, input.Limit), nil
+}
+
+func NewListService(listRepository repository.ListRepository, userRepository repository.UserRepository) ListService {
+	return &listService{
+		listRepository: listRepository,
+		userRepository: userRepository,
+	}
+}
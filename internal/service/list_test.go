@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const listId = "6ba7b816-9dad-11d1-80b4-00c04fd430c8"
+
+func TestListService_Create(t *testing.T) {
+	t.Run("stores the sanitized list", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		owner := &domain.User{Id: authorId, Username: "bob"}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(owner, nil)
+		listRepository.On("Create", mock.Anything, &domain.List{OwnerId: authorId, Name: "Gophers", Private: true}).
+			Return(&domain.List{Id: listId, OwnerId: authorId, Name: "Gophers", Private: true}, nil)
+
+		service := NewListService(listRepository, userRepository)
+		res, err := service.Create(context.Background(), authorId, &dto.CreateListInput{Name: " Gophers ", Private: true})
+		require.NoError(t, err)
+		require.Equal(t, listId, res.Id)
+		require.Equal(t, "bob", res.Owner.Username)
+		require.True(t, res.Private)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Create(context.Background(), authorId, &dto.CreateListInput{Name: " "})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		listRepository.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestListService_Update(t *testing.T) {
+	name := "Rustaceans"
+
+	t.Run("owner", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(&domain.List{Id: listId, OwnerId: authorId}, nil)
+		listRepository.On("Update", mock.Anything, authorId, listId, &domain.ListUpdate{Name: &name}).
+			Return(&domain.List{Id: listId, OwnerId: authorId, Name: name}, nil)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		res, err := service.Update(context.Background(), authorId, listId, &dto.UpdateListInput{Name: &name})
+		require.NoError(t, err)
+		require.Equal(t, name, res.Name)
+	})
+
+	t.Run("someone else", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(&domain.List{Id: listId, OwnerId: followeeId}, nil)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Update(context.Background(), authorId, listId, &dto.UpdateListInput{Name: &name})
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		listRepository.AssertNotCalled(t, "Update")
+
+		require.ErrorIs(t, service.Delete(context.Background(), authorId, listId), customErr.ErrForbidden)
+		listRepository.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("list the user may not see", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(nil, customErr.ErrNotFound)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Update(context.Background(), authorId, listId, &dto.UpdateListInput{Name: &name})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
+
+func TestListService_AddMember(t *testing.T) {
+	owned := &domain.List{Id: listId, OwnerId: authorId}
+
+	t.Run("adds the member", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(owned, nil)
+		listRepository.On("AddMember", mock.Anything, listId, followeeId).Return(true, nil)
+
+		service := NewListService(listRepository, userRepository)
+		_, err := service.AddMember(context.Background(), authorId, listId, "alice")
+		require.NoError(t, err)
+		listRepository.AssertExpectations(t)
+	})
+
+	t.Run("deactivated account", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		deletedAt := time.Now()
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, DeletedAt: &deletedAt}, nil)
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(owned, nil)
+
+		service := NewListService(listRepository, userRepository)
+		_, err := service.AddMember(context.Background(), authorId, listId, "alice")
+		require.ErrorIs(t, err, customErr.ErrUserDeleted)
+		listRepository.AssertNotCalled(t, "AddMember")
+	})
+
+	t.Run("account that blocked the owner", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(owned, nil)
+		listRepository.On("AddMember", mock.Anything, listId, followeeId).
+			Return(false, fmt.Errorf("%w: the account cannot be added to the list", customErr.ErrForbidden))
+
+		service := NewListService(listRepository, userRepository)
+		_, err := service.AddMember(context.Background(), authorId, listId, "alice")
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+	})
+
+	t.Run("list of someone else", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, followeeId, listId).Return(owned, nil)
+
+		service := NewListService(listRepository, userRepository)
+		_, err := service.AddMember(context.Background(), followeeId, listId, "alice")
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		userRepository.AssertNotCalled(t, "GetByUsername")
+	})
+}
+
+func TestListService_Subscribe(t *testing.T) {
+	t.Run("subscribes", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, followeeId, listId).Return(&domain.List{Id: listId, OwnerId: authorId}, nil)
+		listRepository.On("Subscribe", mock.Anything, listId, followeeId).Return(true, nil)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		res, err := service.Subscribe(context.Background(), followeeId, listId)
+		require.NoError(t, err)
+		require.True(t, res.Subscribed)
+	})
+
+	t.Run("owner", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(&domain.List{Id: listId, OwnerId: authorId}, nil)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Subscribe(context.Background(), authorId, listId)
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		listRepository.AssertNotCalled(t, "Subscribe")
+	})
+}
+
+func TestListService_Timeline(t *testing.T) {
+	now := time.Now()
+	posts := []*domain.Post{
+		{Id: postId, CreatedAt: now, Author: &domain.User{Id: followeeId}},
+		{Id: folderId, CreatedAt: now.Add(-time.Minute), Author: &domain.User{Id: followeeId}},
+	}
+
+	t.Run("pages by post time", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+		listRepository.On("GetById", mock.Anything, authorId, listId).Return(&domain.List{Id: listId, OwnerId: followeeId}, nil)
+		listRepository.On("Timeline", mock.Anything, authorId, listId, (*domain.Cursor)(nil), 2).Return(posts, nil)
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		res, err := service.Timeline(context.Background(), authorId, listId, &dto.PageInput{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, res.Posts, 1)
+		require.NotEmpty(t, res.NextCursor)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		t.Parallel()
+		listRepository := &mocks.ListRepositoryMock{}
+
+		service := NewListService(listRepository, &mocks.UserRepositoryMock{})
+		_, err := service.Timeline(context.Background(), authorId, "list", &dto.PageInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		listRepository.AssertNotCalled(t, "GetById")
+	})
+}
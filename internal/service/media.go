@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/imaging"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"github.com/saleh-ghazimoradi/X/internal/storage"
+	"io"
+	"log/slog"
+	"time"
+)
+
+// MediaService stores uploaded images until they are attached to a post, which
+// the post service does when creating the post.
+type MediaService interface {
+	Upload(ctx context.Context, userId string, body io.Reader) (*dto.MediaResponse, error)
+	// Run removes the uploads never attached to a post and the stored images
+	// nothing refers to anymore, until ctx is done.
+	Run(ctx context.Context)
+}
+
+// mediaCleanupBatch is the number of unused blobs removed per transaction.
+const mediaCleanupBatch = 50
+
+type mediaService struct {
+	mediaRepository repository.MediaRepository
+	store           storage.BlobStore
+	maxBytes        int64
+	orphanTTL       time.Duration
+	cleanupInterval time.Duration
+	logger          *slog.Logger
+}
+
+type MediaOptions func(*mediaService)
+
+func WithMediaMaxBytes(maxBytes int64) MediaOptions {
+	return func(m *mediaService) {
+		m.maxBytes = maxBytes
+	}
+}
+
+// WithMediaOrphanTTL sets how long an upload may wait to be attached to a post
+// before it is removed.
+func WithMediaOrphanTTL(orphanTTL time.Duration) MediaOptions {
+	return func(m *mediaService) {
+		m.orphanTTL = orphanTTL
+	}
+}
+
+func WithMediaLogger(logger *slog.Logger) MediaOptions {
+	return func(m *mediaService) {
+		m.logger = logger
+	}
+}
+
+// Upload stores an image. Its type is sniffed from its content, whatever the
+// client claimed. Content uploaded before, by anyone, is stored only once: the
+// upload then reuses the stored renditions.
+func (m *mediaService) Upload(ctx context.Context, userId string, body io.Reader) (*dto.MediaResponse, error) {
+	data, err := io.ReadAll(io.LimitReader(body, m.maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(data)) > m.maxBytes {
+		return nil, fmt.Errorf("%w: image too large, (%d) bytes at most", customErr.ErrValidation, m.maxBytes)
+	}
+
+	if contentType, ok := imaging.Sniff(data); !ok {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and GIF images are accepted, got %s", customErr.ErrValidation, contentType)
+	}
+
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])
+
+	media, err := m.mediaRepository.Reuse(ctx, userId, hash)
+	switch {
+	case errors.Is(err, customErr.ErrNotFound):
+		if media, err = m.create(ctx, userId, hash, data); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	}
+	return dto.NewMediaResponse(media), nil
+}
+
+// create processes the image and writes its renditions before recording them.
+func (m *mediaService) create(ctx context.Context, userId, hash string, data []byte) (*domain.Media, error) {
+	img, err := imaging.Process(data)
+	if err != nil {
+		if errors.Is(err, imaging.ErrUnsupported) {
+			return nil, fmt.Errorf("%w: %v", customErr.ErrValidation, err)
+		}
+		return nil, err
+	}
+
+	media := &domain.Media{
+		UserId: userId,
+		MediaBlob: domain.MediaBlob{
+			Hash:        hash,
+			ContentType: img.ContentType,
+			Width:       img.Width,
+			Height:      img.Height,
+			Size:        int64(len(data)),
+			Blurhash:    img.Blurhash,
+		},
+	}
+	for _, variant := range img.Variants {
+		key := fmt.Sprintf("media/%s/%s/%s%s", hash[:2], hash, variant.Name, variant.Ext)
+		if err := m.store.Put(ctx, key, variant.ContentType, bytes.NewReader(variant.Data)); err != nil {
+			return nil, fmt.Errorf("error storing %s variant: %w", variant.Name, err)
+		}
+		media.Variants = append(media.Variants, &domain.MediaVariant{
+			Name:        variant.Name,
+			Key:         key,
+			URL:         m.store.URL(key),
+			ContentType: variant.ContentType,
+			Width:       variant.Width,
+			Height:      variant.Height,
+		})
+	}
+	return m.mediaRepository.Create(ctx, media)
+}
+
+func (m *mediaService) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.cleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.cleanup(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// cleanup removes the orphaned uploads first, so the blobs only they used are
+// removed in the same pass.
+func (m *mediaService) cleanup(ctx context.Context) {
+	before := time.Now().Add(-m.orphanTTL)
+	if _, err := m.mediaRepository.DeleteOrphans(ctx, before); err != nil {
+		m.logger.ErrorContext(ctx, "media orphan removal failed", "err", err.Error())
+		return
+	}
+
+	for ctx.Err() == nil {
+		removed, err := m.mediaRepository.DeleteUnusedBlobs(ctx, before, mediaCleanupBatch, m.removeBlob(ctx))
+		if err != nil {
+			m.logger.ErrorContext(ctx, "media blob removal failed", "err", err.Error())
+			return
+		}
+		if removed < mediaCleanupBatch {
+			return
+		}
+	}
+}
+
+func (m *mediaService) removeBlob(ctx context.Context) func(blob *domain.MediaBlob) error {
+	return func(blob *domain.MediaBlob) error {
+		for _, variant := range blob.Variants {
+			if err := m.store.Delete(ctx, variant.Key); err != nil {
+				return fmt.Errorf("error removing %s: %w", variant.Key, err)
+			}
+		}
+		return nil
+	}
+}
+
+func NewMediaService(mediaRepository repository.MediaRepository, store storage.BlobStore, opts ...MediaOptions) MediaService {
+	m := &mediaService{
+		mediaRepository: mediaRepository,
+		store:           store,
+		maxBytes:        5 << 20,
+		orphanTTL:       24 * time.Hour,
+		cleanupInterval: time.Hour,
+		logger:          slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
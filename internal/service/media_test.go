@@ -0,0 +1,126 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/saleh-ghazimoradi/X/internal/storage"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	img.Set(0, 0, color.Black)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestMediaService_Upload(t *testing.T) {
+	data := testPNG(t, 1000, 500)
+
+	t.Run("stores new content", func(t *testing.T) {
+		t.Parallel()
+		mediaRepository := &mocks.MediaRepositoryMock{}
+		store := storage.NewLocalStore(t.TempDir())
+		mediaRepository.On("Reuse", mock.Anything, authorId, mock.Anything).Return(nil, customErr.ErrNotFound)
+		mediaRepository.On("Create", mock.Anything, mock.Anything).Return(func(_ context.Context, media *domain.Media) (*domain.Media, error) {
+			media.Id = postId
+			return media, nil
+		})
+
+		service := NewMediaService(mediaRepository, store)
+		res, err := service.Upload(context.Background(), authorId, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, postId, res.Id)
+		require.Equal(t, "image/png", res.ContentType)
+		require.NotEmpty(t, res.Blurhash)
+		require.Equal(t, 680, res.Variants["small"].Width)
+		require.Equal(t, 75, res.Variants["thumb"].Height)
+
+		media := mediaRepository.Calls[1].Arguments.Get(1).(*domain.Media)
+		require.Len(t, media.Hash, 64)
+		require.Equal(t, int64(len(data)), media.Size)
+		for _, variant := range media.Variants {
+			require.True(t, strings.HasPrefix(variant.Key, "media/"+media.Hash[:2]+"/"+media.Hash+"/"), variant.Key)
+			require.Equal(t, "/blobs/"+variant.Key, variant.URL)
+			exists, err := store.Exists(context.Background(), variant.Key)
+			require.NoError(t, err)
+			require.True(t, exists)
+		}
+	})
+
+	t.Run("reuses stored content", func(t *testing.T) {
+		t.Parallel()
+		mediaRepository := &mocks.MediaRepositoryMock{}
+		mediaRepository.On("Reuse", mock.Anything, authorId, mock.Anything).Return(&domain.Media{Id: postId, MediaBlob: domain.MediaBlob{ContentType: "image/png"}}, nil)
+
+		service := NewMediaService(mediaRepository, storage.NewLocalStore(t.TempDir()))
+		res, err := service.Upload(context.Background(), authorId, bytes.NewReader(data))
+		require.NoError(t, err)
+		require.Equal(t, postId, res.Id)
+		mediaRepository.AssertNotCalled(t, "Create")
+	})
+
+	tests := []struct {
+		name string
+		body []byte
+	}{
+		{name: "too large", body: data},
+		{name: "not an image", body: []byte("GIF89a is not enough to be an image")},
+		{name: "webp", body: append([]byte("RIFF\x00\x00\x00\x00WEBPVP8 "), make([]byte, 32)...)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mediaRepository := &mocks.MediaRepositoryMock{}
+			mediaRepository.On("Reuse", mock.Anything, authorId, mock.Anything).Return(nil, customErr.ErrNotFound)
+
+			service := NewMediaService(mediaRepository, storage.NewLocalStore(t.TempDir()), WithMediaMaxBytes(int64(len(data)-1)))
+			_, err := service.Upload(context.Background(), authorId, bytes.NewReader(tt.body))
+			require.ErrorIs(t, err, customErr.ErrValidation)
+			mediaRepository.AssertNotCalled(t, "Create")
+		})
+	}
+}
+
+func TestMediaService_Cleanup(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocalStore(t.TempDir())
+	blob := &domain.MediaBlob{Variants: []*domain.MediaVariant{{Key: "media/ab/original.png"}, {Key: "media/ab/thumb.png"}}}
+	for _, variant := range blob.Variants {
+		require.NoError(t, store.Put(ctx, variant.Key, "image/png", strings.NewReader("png")))
+	}
+
+	mediaRepository := &mocks.MediaRepositoryMock{}
+	mediaRepository.On("DeleteOrphans", mock.Anything, mock.Anything).Return(int64(1), nil)
+	mediaRepository.On("DeleteUnusedBlobs", mock.Anything, mock.Anything, mediaCleanupBatch, mock.Anything).
+		Return(func(_ context.Context, before time.Time, _ int, remove func(*domain.MediaBlob) error) (int, error) {
+			require.WithinDuration(t, time.Now().Add(-time.Hour), before, time.Minute)
+			return 1, remove(blob)
+		})
+
+	service := NewMediaService(mediaRepository, store, WithMediaOrphanTTL(time.Hour)).(*mediaService)
+	service.cleanup(ctx)
+
+	for _, variant := range blob.Variants {
+		exists, err := store.Exists(ctx, variant.Key)
+		require.NoError(t, err)
+		require.False(t, exists)
+	}
+	mediaRepository.AssertNumberOfCalls(t, "DeleteUnusedBlobs", 1)
+}
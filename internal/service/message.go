@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"slices"
+)
+
+type MessageService interface {
+	CreateConversation(ctx context.Context, userId string, input *dto.CreateConversationInput) (*dto.ConversationResponse, error)
+	GetConversation(ctx context.Context, userId, id string) (*dto.ConversationResponse, error)
+	ListConversations(ctx context.Context, userId string, input *dto.PageInput) (*dto.ConversationListResponse, error)
+	DeleteConversation(ctx context.Context, userId, id string) error
+	Send(ctx context.Context, userId, conversationId string, input *dto.SendMessageInput) (*dto.MessageResponse, error)
+	ListMessages(ctx context.Context, userId, conversationId string, input *dto.PageInput) (*dto.MessageListResponse, error)
+	MarkRead(ctx context.Context, userId, conversationId, messageId string) error
+	GetSettings(ctx context.Context, userId string) (*dto.MessageSettings, error)
+	UpdateSettings(ctx context.Context, userId string, input *dto.MessageSettings) (*dto.MessageSettings, error)
+}
+
+type messageService struct {
+	messageRepository repository.MessageRepository
+	userRepository    repository.UserRepository
+	followRepository  repository.FollowRepository
+	blocks            BlockChecker
+	stream            StreamPublisher
+	logger            *slog.Logger
+}
+
+type MessageOptions func(*messageService)
+
+func WithMessageBlocks(blocks BlockChecker) MessageOptions {
+	return func(m *messageService) {
+		m.blocks = blocks
+	}
+}
+
+// WithMessageStream pushes messages and read receipts to the participants'
+// connected clients.
+func WithMessageStream(stream StreamPublisher) MessageOptions {
+	return func(m *messageService) {
+		m.stream = stream
+	}
+}
+
+func WithMessageLogger(logger *slog.Logger) MessageOptions {
+	return func(m *messageService) {
+		m.logger = logger
+	}
+}
+
+// CreateConversation starts a conversation with the named users, or returns the
+// existing one-to-one conversation with a single user. Every recipient must
+// accept messages from the user, and no two recipients of a group may have
+// blocked one another; the error does not tell a block apart from the
+// recipient's privacy setting.
+func (m *messageService) CreateConversation(ctx context.Context, userId string, input *dto.CreateConversationInput) (*dto.ConversationResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	participantIds := []string{userId}
+	recipients := make([]*domain.User, 0, len(input.Participants))
+	for _, username := range input.Participants {
+		recipient, err := m.userRepository.GetByUsername(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case recipient.IsDeleted():
+			return nil, customErr.ErrUserDeleted
+		case recipient.Id == userId:
+			return nil, fmt.Errorf("%w: cannot start a conversation with yourself", customErr.ErrValidation)
+		}
+
+		if err := m.canStart(ctx, userId, recipient); err != nil {
+			return nil, err
+		}
+
+		for _, other := range recipients {
+			if err := m.notBlocked(ctx, other.Id, recipient); err != nil {
+				return nil, err
+			}
+		}
+		recipients = append(recipients, recipient)
+		participantIds = append(participantIds, recipient.Id)
+	}
+
+	conversation := &domain.Conversation{CreatedBy: &userId}
+	if len(participantIds) == 2 {
+		conversation.DirectKey = directKey(participantIds[0], participantIds[1])
+	}
+
+	id, err := m.messageRepository.CreateConversation(ctx, conversation, participantIds)
+	if err != nil {
+		return nil, err
+	}
+	return m.GetConversation(ctx, userId, id)
+}
+
+// canStart checks that the recipient accepts a new conversation with the user.
+func (m *messageService) canStart(ctx context.Context, userId string, recipient *domain.User) error {
+	if err := m.notBlocked(ctx, userId, recipient); err != nil {
+		return err
+	}
+
+	privacy, err := m.messageRepository.GetPrivacy(ctx, recipient.Id)
+	if err != nil {
+		return err
+	}
+
+	switch privacy {
+	case domain.MessageEveryone:
+		return nil
+	case domain.MessageFollowers:
+		relationship, err := m.followRepository.GetRelationship(ctx, userId, recipient.Id)
+		if err != nil {
+			return err
+		}
+		if relationship.Following {
+			return nil
+		}
+	}
+	return cannotMessage(recipient)
+}
+
+func (m *messageService) notBlocked(ctx context.Context, userId string, other *domain.User) error {
+	blocked, err := m.blocks.Blocked(ctx, userId, other.Id)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return cannotMessage(other)
+	}
+	return nil
+}
+
+func cannotMessage(user *domain.User) error {
+	return fmt.Errorf("%w: @%s cannot be messaged", customErr.ErrForbidden, user.Username)
+}
+
+// directKey identifies the one-to-one conversation between two users whichever
+// of them starts it.
+func directKey(userId, otherId string) *string {
+	ids := []string{userId, otherId}
+	slices.Sort(ids)
+	key := ids[0] + ":" + ids[1]
+	return &key
+}
+
+func (m *messageService) GetConversation(ctx context.Context, userId, id string) (*dto.ConversationResponse, error) {
+	conversation, err := m.conversation(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewConversationResponse(conversation), nil
+}
+
+// conversation loads a conversation the user takes part in; anyone else is told
+// it does not exist.
+func (m *messageService) conversation(ctx context.Context, userId, id string) (*domain.Conversation, error) {
+	if !dto.IsValidId(id) {
+		return nil, customErr.ErrNotFound
+	}
+	return m.messageRepository.GetConversation(ctx, userId, id)
+}
+
+func (m *messageService) ListConversations(ctx context.Context, userId string, input *dto.PageInput) (*dto.ConversationListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	conversations, err := m.messageRepository.ListConversations(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewConversationListResponse(conversations, input.Limit), nil
+}
+
+// DeleteConversation removes the conversation and its history for the user
+// only; it comes back when a new message arrives.
+func (m *messageService) DeleteConversation(ctx context.Context, userId, id string) error {
+	if !dto.IsValidId(id) {
+		return customErr.ErrNotFound
+	}
+	return m.messageRepository.DeleteConversation(ctx, userId, id)
+}
+
+// Send adds a message to the conversation. Privacy settings only decide who may
+// start a conversation, but a block also ends an existing one-to-one
+// conversation. A group goes on across a block made after it started: the
+// message is streamed to every participant but those the sender blocked or was
+// blocked by, and ListMessages leaves it out for them as well.
+func (m *messageService) Send(ctx context.Context, userId, conversationId string, input *dto.SendMessageInput) (*dto.MessageResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	conversation, err := m.conversation(ctx, userId, conversationId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !conversation.IsGroup() {
+		others := conversation.Others(userId)
+		if len(others) == 0 {
+			return nil, customErr.ErrUserDeleted
+		}
+		if err := m.notBlocked(ctx, userId, others[0].User); err != nil {
+			return nil, err
+		}
+	}
+
+	message, err := m.messageRepository.CreateMessage(ctx, &domain.Message{ConversationId: conversation.Id, SenderId: userId, Body: input.Body})
+	if err != nil {
+		return nil, err
+	}
+	message.Sender = participantUser(conversation, userId)
+
+	recipientIds, err := m.audience(ctx, userId, conversation)
+	if err == nil {
+		err = m.stream.MessageSent(ctx, message, recipientIds)
+	}
+	if err != nil {
+		m.logger.ErrorContext(ctx, "message stream failed", "conversation_id", conversation.Id, "err", err.Error())
+	}
+	return dto.NewMessageResponse(message), nil
+}
+
+func (m *messageService) ListMessages(ctx context.Context, userId, conversationId string, input *dto.PageInput) (*dto.MessageListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := m.conversation(ctx, userId, conversationId); err != nil {
+		return nil, err
+	}
+
+	messages, err := m.messageRepository.ListMessages(ctx, userId, conversationId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewMessageListResponse(messages, input.Limit), nil
+}
+
+// MarkRead moves the user's read cursor up to the message and streams the read
+// receipt to the participants. Reading an older message changes nothing.
+func (m *messageService) MarkRead(ctx context.Context, userId, conversationId, messageId string) error {
+	if !dto.IsValidId(messageId) {
+		return customErr.ErrNotFound
+	}
+
+	conversation, err := m.conversation(ctx, userId, conversationId)
+	if err != nil {
+		return err
+	}
+
+	receipt, err := m.messageRepository.MarkRead(ctx, userId, conversation.Id, messageId)
+	if err != nil {
+		return err
+	}
+
+	if receipt != nil {
+		recipientIds, err := m.audience(ctx, userId, conversation)
+		if err == nil {
+			err = m.stream.MessageRead(ctx, receipt, recipientIds)
+		}
+		if err != nil {
+			m.logger.ErrorContext(ctx, "read receipt stream failed", "conversation_id", conversation.Id, "err", err.Error())
+		}
+	}
+	return nil
+}
+
+// audience returns the participants who see what the user does in the
+// conversation: the user and everyone neither blocking nor blocked by the user.
+func (m *messageService) audience(ctx context.Context, userId string, conversation *domain.Conversation) ([]string, error) {
+	ids := make([]string, 0, len(conversation.Participants))
+	for _, participant := range conversation.Participants {
+		if participant.User.Id != userId {
+			blocked, err := m.blocks.Blocked(ctx, userId, participant.User.Id)
+			if err != nil {
+				return nil, err
+			}
+			if blocked {
+				continue
+			}
+		}
+		ids = append(ids, participant.User.Id)
+	}
+	return ids, nil
+}
+
+func participantUser(conversation *domain.Conversation, userId string) *domain.User {
+	for _, participant := range conversation.Participants {
+		if participant.User.Id == userId {
+			return participant.User
+		}
+	}
+	return nil
+}
+
+func (m *messageService) GetSettings(ctx context.Context, userId string) (*dto.MessageSettings, error) {
+	privacy, err := m.messageRepository.GetPrivacy(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewMessageSettings(privacy), nil
+}
+
+func (m *messageService) UpdateSettings(ctx context.Context, userId string, input *dto.MessageSettings) (*dto.MessageSettings, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	privacy := domain.MessagePrivacy(input.WhoCanMessage)
+	if err := m.messageRepository.SetPrivacy(ctx, userId, privacy); err != nil {
+		return nil, err
+	}
+	return dto.NewMessageSettings(privacy), nil
+}
+
+func NewMessageService(messageRepository repository.MessageRepository, userRepository repository.UserRepository, followRepository repository.FollowRepository, opts ...MessageOptions) MessageService {
+	m := &messageService{
+		messageRepository: messageRepository,
+		userRepository:    userRepository,
+		followRepository:  followRepository,
+		blocks:            nopBlocks{},
+		stream:            nopStream{},
+		logger:            slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	conversationId = "6ba7b815-9dad-11d1-80b4-00c04fd430c8"
+	messageId      = "6ba7b816-9dad-11d1-80b4-00c04fd430c8"
+)
+
+// blockList blocks the listed pairs of users, in either direction.
+type blockList map[[2]string]bool
+
+func (b blockList) Blocked(_ context.Context, userId, otherId string) (bool, error) {
+	return b[[2]string{userId, otherId}] || b[[2]string{otherId, userId}], nil
+}
+
+// messageStream records who the message events are streamed to.
+type messageStream struct {
+	nopStream
+	messages [][]string
+	receipts [][]string
+}
+
+func (m *messageStream) MessageSent(_ context.Context, _ *domain.Message, recipientIds []string) error {
+	m.messages = append(m.messages, recipientIds)
+	return nil
+}
+
+func (m *messageStream) MessageRead(_ context.Context, _ *domain.ReadReceipt, recipientIds []string) error {
+	m.receipts = append(m.receipts, recipientIds)
+	return nil
+}
+
+func directConversation() *domain.Conversation {
+	return &domain.Conversation{
+		Id:        conversationId,
+		DirectKey: directKey(authorId, followeeId),
+		Participants: []*domain.Participant{
+			{User: &domain.User{Id: authorId, Username: "bob"}},
+			{User: &domain.User{Id: followeeId, Username: "alice"}},
+		},
+	}
+}
+
+func TestMessageService_CreateConversation(t *testing.T) {
+	testCases := []struct {
+		name      string
+		privacy   domain.MessagePrivacy
+		following bool
+		blocks    blockList
+		wantErr   error
+	}{
+		{name: "anyone may message", privacy: domain.MessageEveryone},
+		{name: "followers may message", privacy: domain.MessageFollowers, following: true},
+		{name: "others may not", privacy: domain.MessageFollowers, wantErr: customErr.ErrForbidden},
+		{name: "nobody may message", privacy: domain.MessageNobody, following: true, wantErr: customErr.ErrForbidden},
+		{name: "blocked either way", privacy: domain.MessageEveryone, blocks: blockList{{followeeId, authorId}: true}, wantErr: customErr.ErrForbidden},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			messageRepository := &mocks.MessageRepositoryMock{}
+			userRepository := &mocks.UserRepositoryMock{}
+			followRepository := &mocks.FollowRepositoryMock{}
+
+			userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+			messageRepository.On("GetPrivacy", mock.Anything, followeeId).Return(tc.privacy, nil)
+			followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: tc.following}, nil)
+			messageRepository.On("CreateConversation", mock.Anything, mock.MatchedBy(func(conversation *domain.Conversation) bool {
+				return *conversation.DirectKey == *directKey(followeeId, authorId)
+			}), []string{authorId, followeeId}).Return(conversationId, nil)
+			messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(directConversation(), nil)
+
+			service := NewMessageService(messageRepository, userRepository, followRepository, WithMessageBlocks(tc.blocks))
+			res, err := service.CreateConversation(context.Background(), authorId, &dto.CreateConversationInput{Participants: []string{"@alice", "alice"}})
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				require.NotContains(t, err.Error(), "block")
+				messageRepository.AssertNotCalled(t, "CreateConversation")
+				return
+			}
+			require.NoError(t, err)
+			require.False(t, res.Group)
+			require.Len(t, res.Participants, 2)
+		})
+	}
+
+	t.Run("groups have no direct key", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		carolId := "6ba7b817-9dad-11d1-80b4-00c04fd430c8"
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		userRepository.On("GetByUsername", mock.Anything, "carol").Return(&domain.User{Id: carolId}, nil)
+		messageRepository.On("GetPrivacy", mock.Anything, mock.Anything).Return(domain.MessageEveryone, nil)
+		messageRepository.On("CreateConversation", mock.Anything, mock.MatchedBy(func(conversation *domain.Conversation) bool {
+			return conversation.IsGroup()
+		}), []string{authorId, followeeId, carolId}).Return(conversationId, nil)
+		messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(&domain.Conversation{Id: conversationId}, nil)
+
+		service := NewMessageService(messageRepository, userRepository, &mocks.FollowRepositoryMock{})
+		res, err := service.CreateConversation(context.Background(), authorId, &dto.CreateConversationInput{Participants: []string{"alice", "carol"}})
+		require.NoError(t, err)
+		require.True(t, res.Group)
+	})
+
+	t.Run("not with recipients blocking one another", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		carolId := "6ba7b817-9dad-11d1-80b4-00c04fd430c8"
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil)
+		userRepository.On("GetByUsername", mock.Anything, "carol").Return(&domain.User{Id: carolId, Username: "carol"}, nil)
+		messageRepository.On("GetPrivacy", mock.Anything, mock.Anything).Return(domain.MessageEveryone, nil)
+
+		service := NewMessageService(messageRepository, userRepository, &mocks.FollowRepositoryMock{},
+			WithMessageBlocks(blockList{{carolId, followeeId}: true}))
+		_, err := service.CreateConversation(context.Background(), authorId, &dto.CreateConversationInput{Participants: []string{"alice", "carol"}})
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		messageRepository.AssertNotCalled(t, "CreateConversation")
+	})
+
+	t.Run("not with yourself", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId}, nil)
+
+		service := NewMessageService(&mocks.MessageRepositoryMock{}, userRepository, &mocks.FollowRepositoryMock{})
+		_, err := service.CreateConversation(context.Background(), authorId, &dto.CreateConversationInput{Participants: []string{"bob"}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+	})
+}
+
+func TestMessageService_Send(t *testing.T) {
+	t.Run("streams the message to every participant", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		stream := &messageStream{}
+
+		messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(directConversation(), nil)
+		messageRepository.On("CreateMessage", mock.Anything, mock.Anything).Return(func(_ context.Context, message *domain.Message) (*domain.Message, error) {
+			message.Id, message.CreatedAt = messageId, time.Now()
+			return message, nil
+		})
+
+		service := NewMessageService(messageRepository, &mocks.UserRepositoryMock{}, &mocks.FollowRepositoryMock{}, WithMessageStream(stream))
+		res, err := service.Send(context.Background(), authorId, conversationId, &dto.SendMessageInput{Body: " hi "})
+		require.NoError(t, err)
+		require.Equal(t, "hi", res.Body)
+		require.Equal(t, "bob", res.Sender.Username)
+		require.Equal(t, [][]string{{authorId, followeeId}}, stream.messages)
+	})
+
+	t.Run("group members blocked by the sender are not streamed to", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		stream := &messageStream{}
+		carolId := "6ba7b817-9dad-11d1-80b4-00c04fd430c8"
+		group := directConversation()
+		group.DirectKey = nil
+		group.Participants = append(group.Participants, &domain.Participant{User: &domain.User{Id: carolId, Username: "carol"}})
+
+		messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(group, nil)
+		messageRepository.On("CreateMessage", mock.Anything, mock.Anything).Return(&domain.Message{Id: messageId}, nil)
+
+		service := NewMessageService(messageRepository, &mocks.UserRepositoryMock{}, &mocks.FollowRepositoryMock{},
+			WithMessageBlocks(blockList{{carolId, authorId}: true}), WithMessageStream(stream))
+		_, err := service.Send(context.Background(), authorId, conversationId, &dto.SendMessageInput{Body: "hi"})
+		require.NoError(t, err)
+		require.Equal(t, [][]string{{authorId, followeeId}}, stream.messages)
+	})
+
+	t.Run("a block ends a one-to-one conversation", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(directConversation(), nil)
+
+		service := NewMessageService(messageRepository, &mocks.UserRepositoryMock{}, &mocks.FollowRepositoryMock{}, WithMessageBlocks(blockList{{followeeId, authorId}: true}))
+		_, err := service.Send(context.Background(), authorId, conversationId, &dto.SendMessageInput{Body: "hi"})
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		messageRepository.AssertNotCalled(t, "CreateMessage")
+	})
+
+	t.Run("outsiders are told the conversation does not exist", func(t *testing.T) {
+		t.Parallel()
+		messageRepository := &mocks.MessageRepositoryMock{}
+		messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(nil, customErr.ErrNotFound)
+
+		service := NewMessageService(messageRepository, &mocks.UserRepositoryMock{}, &mocks.FollowRepositoryMock{})
+		_, err := service.Send(context.Background(), authorId, conversationId, &dto.SendMessageInput{Body: "hi"})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
+
+func TestMessageService_MarkRead(t *testing.T) {
+	testCases := []struct {
+		name    string
+		receipt *domain.ReadReceipt
+		streams int
+	}{
+		{name: "moving the cursor sends a receipt", receipt: &domain.ReadReceipt{ConversationId: conversationId, UserId: authorId, MessageId: messageId}, streams: 1},
+		{name: "reading an older message does not", receipt: nil},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			messageRepository := &mocks.MessageRepositoryMock{}
+			stream := &messageStream{}
+
+			messageRepository.On("GetConversation", mock.Anything, authorId, conversationId).Return(directConversation(), nil)
+			messageRepository.On("MarkRead", mock.Anything, authorId, conversationId, messageId).Return(tc.receipt, nil)
+
+			service := NewMessageService(messageRepository, &mocks.UserRepositoryMock{}, &mocks.FollowRepositoryMock{}, WithMessageStream(stream))
+			require.NoError(t, service.MarkRead(context.Background(), authorId, conversationId, messageId))
+			require.Len(t, stream.receipts, tc.streams)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+)
+
+// MutedWordService manages the words users muted. Like blocks and mutes, they
+// are applied by the repositories reading the home timeline, replies,
+// notifications and the event stream, not here.
+type MutedWordService interface {
+	Mute(ctx context.Context, userId string, input *dto.MuteWordInput) (*dto.MutedWordResponse, error)
+	List(ctx context.Context, userId string) (*dto.MutedWordListResponse, error)
+	Unmute(ctx context.Context, userId, id string) error
+}
+
+type mutedWordService struct {
+	mutedWordRepository repository.MutedWordRepository
+}
+
+// Mute mutes the phrase; muting a phrase again replaces its scopes and expiry.
+func (m *mutedWordService) Mute(ctx context.Context, userId string, input *dto.MuteWordInput) (*dto.MutedWordResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	word, err := m.mutedWordRepository.Upsert(ctx, input.MutedWord(userId))
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewMutedWordResponse(word), nil
+}
+
+func (m *mutedWordService) List(ctx context.Context, userId string) (*dto.MutedWordListResponse, error) {
+	words, err := m.mutedWordRepository.List(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewMutedWordListResponse(words), nil
+}
+
+func (m *mutedWordService) Unmute(ctx context.Context, userId, id string) error {
+	if !dto.IsValidId(id) {
+		return customErr.ErrNotFound
+	}
+	return m.mutedWordRepository.Delete(ctx, userId, id)
+}
+
+func NewMutedWordService(mutedWordRepository repository.MutedWordRepository) MutedWordService {
+	return &mutedWordService{
+		mutedWordRepository: mutedWordRepository,
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMutedWordService_Mute(t *testing.T) {
+	t.Run("stores the sanitized phrase", func(t *testing.T) {
+		t.Parallel()
+		mutedWordRepository := &mocks.MutedWordRepositoryMock{}
+		want := &domain.MutedWord{UserId: authorId, Phrase: "#spoilers", Scopes: []domain.MutedWordScope{domain.MutedWordHome, domain.MutedWordReplies}}
+		mutedWordRepository.On("Upsert", mock.Anything, want).Return(want, nil)
+
+		service := NewMutedWordService(mutedWordRepository)
+		res, err := service.Mute(context.Background(), authorId, &dto.MuteWordInput{Phrase: " #Spoilers ", Scopes: []string{"replies", "home"}})
+		require.NoError(t, err)
+		require.Equal(t, "#spoilers", res.Phrase)
+		require.Equal(t, []string{"home", "replies"}, res.Scopes)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		mutedWordRepository := &mocks.MutedWordRepositoryMock{}
+
+		service := NewMutedWordService(mutedWordRepository)
+		_, err := service.Mute(context.Background(), authorId, &dto.MuteWordInput{Phrase: "go", Scopes: []string{"search"}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		mutedWordRepository.AssertNotCalled(t, "Upsert")
+	})
+}
+
+func TestMutedWordService_Unmute(t *testing.T) {
+	t.Parallel()
+	mutedWordRepository := &mocks.MutedWordRepositoryMock{}
+
+	service := NewMutedWordService(mutedWordRepository)
+	require.ErrorIs(t, service.Unmute(context.Background(), authorId, "not-an-id"), customErr.ErrNotFound)
+	mutedWordRepository.AssertNotCalled(t, "Delete")
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Notifier is told about events other users may be notified of. Notify must not
+// block the request that caused the event.
+type Notifier interface {
+	Notify(ctx context.Context, event *domain.NotificationEvent)
+}
+
+type NotificationService interface {
+	Notifier
+	Run(ctx context.Context)
+	List(ctx context.Context, userId string, input *dto.PageInput) (*dto.NotificationListResponse, error)
+	UnreadCount(ctx context.Context, userId string) (*dto.UnreadCountResponse, error)
+	MarkRead(ctx context.Context, userId, id string) error
+	MarkAllRead(ctx context.Context, userId string) error
+	GetPreferences(ctx context.Context, userId string) (*dto.NotificationPreferences, error)
+	UpdatePreferences(ctx context.Context, userId string, input *dto.NotificationPreferences) (*dto.NotificationPreferences, error)
+}
+
+type notificationService struct {
+	notificationRepository repository.NotificationRepository
+	queue                  chan *domain.NotificationEvent
+	workers                int
+	stream                 StreamPublisher
+	logger                 *slog.Logger
+}
+
+type NotificationOptions func(*notificationService)
+
+// WithNotificationQueueSize sets how many events may wait for a worker; events
+// arriving while the queue is full are dropped.
+func WithNotificationQueueSize(size int) NotificationOptions {
+	return func(n *notificationService) {
+		n.queue = make(chan *domain.NotificationEvent, size)
+	}
+}
+
+func WithNotificationWorkers(workers int) NotificationOptions {
+	return func(n *notificationService) {
+		n.workers = workers
+	}
+}
+
+// WithNotificationStream pushes stored notifications to connected clients.
+func WithNotificationStream(stream StreamPublisher) NotificationOptions {
+	return func(n *notificationService) {
+		n.stream = stream
+	}
+}
+
+func WithNotificationLogger(logger *slog.Logger) NotificationOptions {
+	return func(n *notificationService) {
+		n.logger = logger
+	}
+}
+
+// Notify queues the event for the workers started by Run. Nobody is notified of
+// their own actions. A notification is not worth slowing down or failing the
+// request for, so when the queue is full the event is dropped.
+func (n *notificationService) Notify(ctx context.Context, event *domain.NotificationEvent) {
+	if event.RecipientId == "" || event.RecipientId == event.ActorId {
+		return
+	}
+
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	select {
+	case n.queue <- event:
+	default:
+		n.logger.WarnContext(ctx, "notification queue full, event dropped", "type", event.Type, "recipient_id", event.RecipientId)
+	}
+}
+
+// Run stores queued events until ctx is cancelled, then stores what is still
+// queued and returns.
+func (n *notificationService) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for i := 0; i < max(n.workers, 1); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			n.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+func (n *notificationService) work(ctx context.Context) {
+	for {
+		select {
+		case event := <-n.queue:
+			n.store(ctx, event)
+		case <-ctx.Done():
+			n.drain(context.WithoutCancel(ctx))
+			return
+		}
+	}
+}
+
+func (n *notificationService) drain(ctx context.Context) {
+	for {
+		select {
+		case event := <-n.queue:
+			n.store(ctx, event)
+		default:
+			return
+		}
+	}
+}
+
+func (n *notificationService) store(ctx context.Context, event *domain.NotificationEvent) {
+	stored, err := n.notificationRepository.Create(ctx, event, groupKey(event))
+	if err != nil {
+		n.logger.ErrorContext(ctx, "notification failed", "type", event.Type, "recipient_id", event.RecipientId, "err", err.Error())
+		return
+	}
+
+	if stored {
+		if err := n.stream.NotificationStored(ctx, event); err != nil {
+			n.logger.ErrorContext(ctx, "notification stream failed", "type", event.Type, "recipient_id", event.RecipientId, "err", err.Error())
+		}
+	}
+}
+
+// groupKey decides which events share a notification while it is unread: all
+// new followers, and the likes or reposts of the same post. Replies and
+// mentions are posts in their own right and are never grouped.
+func groupKey(event *domain.NotificationEvent) string {
+	if event.PostId == nil {
+		return string(event.Type)
+	}
+	return string(event.Type) + ":" + *event.PostId
+}
+
+func (n *notificationService) List(ctx context.Context, userId string, input *dto.PageInput) (*dto.NotificationListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	notifications, err := n.notificationRepository.List(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewNotificationListResponse(notifications, input.Limit), nil
+}
+
+func (n *notificationService) UnreadCount(ctx context.Context, userId string) (*dto.UnreadCountResponse, error) {
+	count, err := n.notificationRepository.CountUnread(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.UnreadCountResponse{Count: count}, nil
+}
+
+func (n *notificationService) MarkRead(ctx context.Context, userId, id string) error {
+	if !dto.IsValidId(id) {
+		return customErr.ErrNotFound
+	}
+	return n.notificationRepository.MarkRead(ctx, userId, id)
+}
+
+func (n *notificationService) MarkAllRead(ctx context.Context, userId string) error {
+	return n.notificationRepository.MarkAllRead(ctx, userId)
+}
+
+func (n *notificationService) GetPreferences(ctx context.Context, userId string) (*dto.NotificationPreferences, error) {
+	muted, err := n.notificationRepository.ListMutedTypes(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewNotificationPreferences(muted), nil
+}
+
+// UpdatePreferences replaces the muted notification types. Muting a type hides
+// the notifications of that type already received and stops new ones.
+func (n *notificationService) UpdatePreferences(ctx context.Context, userId string, input *dto.NotificationPreferences) (*dto.NotificationPreferences, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	muted := make([]domain.NotificationType, 0, len(input.Muted))
+	for _, t := range input.Muted {
+		muted = append(muted, domain.NotificationType(t))
+	}
+
+	if err := n.notificationRepository.SetMutedTypes(ctx, userId, muted); err != nil {
+		return nil, err
+	}
+	return dto.NewNotificationPreferences(muted), nil
+}
+
+func NewNotificationService(notificationRepository repository.NotificationRepository, opts ...NotificationOptions) NotificationService {
+	n := &notificationService{
+		notificationRepository: notificationRepository,
+		queue:                  make(chan *domain.NotificationEvent, 1024),
+		workers:                2,
+		stream:                 nopStream{},
+		logger:                 slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// nopNotifier is used when no notifier is wired in.
+type nopNotifier struct{}
+
+func (nopNotifier) Notify(context.Context, *domain.NotificationEvent) {}
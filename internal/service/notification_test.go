@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sync"
+	"testing"
+)
+
+// notificationRecorder collects the events services hand to their notifier.
+type notificationRecorder struct {
+	mu     sync.Mutex
+	events []*domain.NotificationEvent
+}
+
+func (n *notificationRecorder) Notify(_ context.Context, event *domain.NotificationEvent) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.events = append(n.events, event)
+}
+
+func (n *notificationRecorder) recipients(t domain.NotificationType) []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var recipients []string
+	for _, event := range n.events {
+		if event.Type == t {
+			recipients = append(recipients, event.RecipientId)
+		}
+	}
+	return recipients
+}
+
+func TestNotificationService_Notify(t *testing.T) {
+	t.Run("workers store queued events with their group key", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+		stored := make(chan string, 2)
+		notificationRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+			stored <- args.String(2)
+		})
+
+		service := NewNotificationService(notificationRepository)
+		service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationLike, RecipientId: authorId, ActorId: followeeId, PostId: &[]string{postId}[0]})
+		service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: authorId, ActorId: followeeId})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		// Run drains the queue before returning even when ctx is already done.
+		service.Run(ctx)
+		close(stored)
+
+		var keys []string
+		for key := range stored {
+			keys = append(keys, key)
+		}
+		require.ElementsMatch(t, []string{"like:" + postId, "follow"}, keys)
+	})
+
+	t.Run("nobody is notified of their own actions", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+
+		service := NewNotificationService(notificationRepository)
+		service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationLike, RecipientId: authorId, ActorId: authorId, PostId: &[]string{postId}[0]})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.Run(ctx)
+		notificationRepository.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("a full queue drops events instead of blocking", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+		notificationRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+
+		service := NewNotificationService(notificationRepository, WithNotificationQueueSize(1))
+		for range 3 {
+			service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: authorId, ActorId: followeeId})
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.Run(ctx)
+		notificationRepository.AssertNumberOfCalls(t, "Create", 1)
+	})
+
+	t.Run("storage failures are only logged", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+		notificationRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(false, errors.New("something"))
+
+		service := NewNotificationService(notificationRepository)
+		service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: authorId, ActorId: followeeId})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		service.Run(ctx)
+		notificationRepository.AssertExpectations(t)
+	})
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	t.Run("invalid id", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+
+		service := NewNotificationService(notificationRepository)
+		require.ErrorIs(t, service.MarkRead(context.Background(), authorId, "nope"), customErr.ErrNotFound)
+		notificationRepository.AssertNotCalled(t, "MarkRead")
+	})
+
+	t.Run("someone else's notification", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+		notificationRepository.On("MarkRead", mock.Anything, authorId, postId).Return(customErr.ErrNotFound)
+
+		service := NewNotificationService(notificationRepository)
+		require.ErrorIs(t, service.MarkRead(context.Background(), authorId, postId), customErr.ErrNotFound)
+	})
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	t.Run("replaces the muted types", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+		notificationRepository.On("SetMutedTypes", mock.Anything, authorId, []domain.NotificationType{domain.NotificationLike, domain.NotificationRepost}).Return(nil)
+
+		service := NewNotificationService(notificationRepository)
+		res, err := service.UpdatePreferences(context.Background(), authorId, &dto.NotificationPreferences{Muted: []string{" Repost", "like", "like"}})
+		require.NoError(t, err)
+		require.Equal(t, []string{"like", "repost"}, res.Muted)
+		notificationRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		t.Parallel()
+		notificationRepository := &mocks.NotificationRepositoryMock{}
+
+		service := NewNotificationService(notificationRepository)
+		_, err := service.UpdatePreferences(context.Background(), authorId, &dto.NotificationPreferences{Muted: []string{"poke"}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		notificationRepository.AssertNotCalled(t, "SetMutedTypes")
+	})
+}
+
+func TestPostService_NotificationHooks(t *testing.T) {
+	parentAuthorId := "6ba7b813-9dad-11d1-80b4-00c04fd430c8"
+
+	t.Run("replies notify the parent author once", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		notifier := &notificationRecorder{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId}, nil)
+		userRepository.On("GetByUsername", mock.Anything, "carol").Return(&domain.User{Id: parentAuthorId}, nil)
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, AuthorId: parentAuthorId}, nil)
+		postRepository.On("Create", mock.Anything, mock.Anything).Return(func(_ context.Context, post *domain.Post) (*domain.Post, error) {
+			post.Id = "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+			return post, nil
+		})
+
+		service := NewPostService(postRepository, userRepository, WithPostNotifier(notifier))
+		_, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "@carol @alice look", ReplyToId: &[]string{postId}[0]})
+		require.NoError(t, err)
+		require.Equal(t, []string{parentAuthorId}, notifier.recipients(domain.NotificationReply))
+		require.Equal(t, []string{followeeId}, notifier.recipients(domain.NotificationMention))
+	})
+}
+
+func TestLikeService_NotificationHooks(t *testing.T) {
+	testCases := []struct {
+		name    string
+		created bool
+		notify  []string
+	}{
+		{name: "new likes notify the author", created: true, notify: []string{followeeId}},
+		{name: "repeated likes do not", created: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			likeRepository := &mocks.LikeRepositoryMock{}
+			postRepository := &mocks.PostRepositoryMock{}
+			notifier := &notificationRecorder{}
+
+			postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, AuthorId: followeeId}, nil)
+			likeRepository.On("Like", mock.Anything, authorId, postId).Return(tc.created, nil)
+			likeRepository.On("CountLikes", mock.Anything, postId).Return(int64(1), nil)
+
+			service := NewLikeService(likeRepository, postRepository, &mocks.UserRepositoryMock{}, WithLikeNotifier(notifier))
+			_, err := service.Like(context.Background(), authorId, postId)
+			require.NoError(t, err)
+			require.Equal(t, tc.notify, notifier.recipients(domain.NotificationLike))
+		})
+	}
+}
+
+func TestFollowService_NotificationHooks(t *testing.T) {
+	followRepository := &mocks.FollowRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+	notifier := &notificationRecorder{}
+
+	userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+	followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowCreated, nil)
+	followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: true}, nil)
+
+	service := NewFollowService(followRepository, userRepository, WithFollowNotifier(notifier))
+	_, err := service.Follow(context.Background(), authorId, "alice")
+	require.NoError(t, err)
+	require.Equal(t, []string{followeeId}, notifier.recipients(domain.NotificationFollow))
+}
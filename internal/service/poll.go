@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"time"
+)
+
+// PollService takes votes on the polls the post service creates with their
+// posts.
+type PollService interface {
+	Vote(ctx context.Context, userId, postId string, input *dto.VoteInput) (*dto.PollResponse, error)
+	// Run finalizes the polls that closed and notifies their authors and
+	// voters, until ctx is done.
+	Run(ctx context.Context)
+}
+
+// pollFinalizeBatch is the number of polls finalized per transaction, and
+// pollAnnounceBatch the number of voters notified per transaction; both keep a
+// transaction well within its timeout however many votes a poll got.
+const (
+	pollFinalizeBatch = 10
+	pollAnnounceBatch = 500
+)
+
+type pollService struct {
+	pollRepository   repository.PollRepository
+	postRepository   repository.PostRepository
+	stream           StreamPublisher
+	finalizeInterval time.Duration
+	logger           *slog.Logger
+}
+
+type PollOptions func(*pollService)
+
+// WithPollStream pushes the "poll closed" notifications to connected clients.
+func WithPollStream(stream StreamPublisher) PollOptions {
+	return func(p *pollService) {
+		p.stream = stream
+	}
+}
+
+// WithPollFinalizeInterval sets how often closed polls are looked for, which
+// bounds how late their results are announced.
+func WithPollFinalizeInterval(interval time.Duration) PollOptions {
+	return func(p *pollService) {
+		p.finalizeInterval = interval
+	}
+}
+
+func WithPollLogger(logger *slog.Logger) PollOptions {
+	return func(p *pollService) {
+		p.logger = logger
+	}
+}
+
+// Vote returns the poll with its tallies, which the vote reveals. Polls of posts
+// the user may not see cannot be found, and authors do not vote in their own
+// polls since they see the tallies from the start.
+func (p *pollService) Vote(ctx context.Context, userId, postId string, input *dto.VoteInput) (*dto.PollResponse, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	post, err := p.get(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.AuthorId == userId {
+		return nil, fmt.Errorf("%w: authors cannot vote in their own polls", customErr.ErrForbidden)
+	}
+
+	if err := p.pollRepository.Vote(ctx, post.Id, userId, *input.Position); err != nil {
+		return nil, err
+	}
+
+	post, err = p.get(ctx, userId, post.Id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPollResponse(post.Poll), nil
+}
+
+// get resolves a post with a poll the user may see.
+func (p *pollService) get(ctx context.Context, userId, postId string) (*domain.Post, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	post, err := p.postRepository.GetById(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Poll == nil {
+		return nil, customErr.ErrNotFound
+	}
+	return post, nil
+}
+
+func (p *pollService) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.finalizeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			p.finalize(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// finalize works through the closed polls in batches until none is left, then
+// announces the results of the finalized polls. Polls that could not be
+// finalized are retried on the next tick without holding up the announcements.
+func (p *pollService) finalize(ctx context.Context) {
+	for ctx.Err() == nil {
+		finalized, err := p.pollRepository.Finalize(ctx, pollFinalizeBatch)
+		if err != nil {
+			p.logger.ErrorContext(ctx, "poll finalization failed", "err", err.Error())
+			break
+		}
+
+		if finalized < pollFinalizeBatch {
+			break
+		}
+	}
+	p.announce(ctx)
+}
+
+// announce stores the notifications of the finalized polls a page of voters at
+// a time. They are stored by the repository along with the progress of the
+// announcement rather than queued, so a poll with more voters than the
+// notification queue holds still reaches every one of them; pushing them to
+// connected clients is best effort.
+func (p *pollService) announce(ctx context.Context) {
+	for ctx.Err() == nil {
+		announcement, err := p.pollRepository.Announce(ctx, pollAnnounceBatch, time.Now())
+		if err != nil {
+			p.logger.ErrorContext(ctx, "poll announcement failed", "err", err.Error())
+			return
+		}
+
+		if announcement == nil {
+			return
+		}
+
+		for _, recipientId := range announcement.RecipientIds {
+			event := &domain.NotificationEvent{
+				Type:        domain.NotificationPollClosed,
+				RecipientId: recipientId,
+				ActorId:     announcement.AuthorId,
+				PostId:      &announcement.PostId,
+				CreatedAt:   announcement.CreatedAt,
+			}
+			if err := p.stream.NotificationStored(ctx, event); err != nil {
+				p.logger.ErrorContext(ctx, "notification stream failed", "type", event.Type, "recipient_id", recipientId, "err", err.Error())
+			}
+		}
+	}
+}
+
+func NewPollService(pollRepository repository.PollRepository, postRepository repository.PostRepository, opts ...PollOptions) PollService {
+	p := &pollService{
+		pollRepository:   pollRepository,
+		postRepository:   postRepository,
+		stream:           nopStream{},
+		finalizeInterval: time.Minute,
+		logger:           slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestPollService_Vote(t *testing.T) {
+	position, count := 1, 1
+	open := &domain.Poll{PostId: postId, ClosesAt: time.Now().Add(time.Hour), Options: []*domain.PollOption{{Position: 0, Text: "Yes"}, {Position: 1, Text: "No"}}}
+	voted := &domain.Poll{PostId: postId, ClosesAt: open.ClosesAt, Vote: &position, VoteCount: &count, Options: []*domain.PollOption{{Position: 0, Text: "Yes"}, {Position: 1, Text: "No", VoteCount: &count}}}
+
+	t.Run("reveals the tallies", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId, Poll: open}, nil).Once()
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId, Poll: voted}, nil).Once()
+		pollRepository.On("Vote", mock.Anything, postId, followeeId, 1).Return(nil)
+
+		service := NewPollService(pollRepository, postRepository)
+		res, err := service.Vote(context.Background(), followeeId, postId, &dto.VoteInput{Position: &position})
+		require.NoError(t, err)
+		require.Equal(t, 1, *res.Vote)
+		require.Equal(t, 1, *res.Options[1].VoteCount)
+		pollRepository.AssertExpectations(t)
+	})
+
+	t.Run("voting twice", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId, Poll: voted}, nil)
+		pollRepository.On("Vote", mock.Anything, postId, followeeId, 1).Return(customErr.ErrAlreadyVoted)
+
+		service := NewPollService(pollRepository, postRepository)
+		_, err := service.Vote(context.Background(), followeeId, postId, &dto.VoteInput{Position: &position})
+		require.ErrorIs(t, err, customErr.ErrAlreadyVoted)
+	})
+
+	t.Run("own poll", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId, Poll: open}, nil)
+
+		service := NewPollService(pollRepository, postRepository)
+		_, err := service.Vote(context.Background(), authorId, postId, &dto.VoteInput{Position: &position})
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		pollRepository.AssertNotCalled(t, "Vote")
+	})
+
+	t.Run("post without a poll", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+
+		service := NewPollService(pollRepository, postRepository)
+		_, err := service.Vote(context.Background(), followeeId, postId, &dto.VoteInput{Position: &position})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		pollRepository.AssertNotCalled(t, "Vote")
+	})
+
+	t.Run("missing position", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+
+		service := NewPollService(&mocks.PollRepositoryMock{}, postRepository)
+		_, err := service.Vote(context.Background(), followeeId, postId, &dto.VoteInput{})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		postRepository.AssertNotCalled(t, "GetById")
+	})
+}
+
+// notificationStream records the notifications streamed to clients.
+type notificationStream struct {
+	nopStream
+	events []*domain.NotificationEvent
+}
+
+func (n *notificationStream) NotificationStored(_ context.Context, event *domain.NotificationEvent) error {
+	n.events = append(n.events, event)
+	return nil
+}
+
+func TestPollService_Finalize(t *testing.T) {
+	t.Run("streams the stored notifications page by page", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		stream := &notificationStream{}
+		pollRepository.On("Finalize", mock.Anything, pollFinalizeBatch).Return(1, nil).Once()
+		pollRepository.On("Announce", mock.Anything, pollAnnounceBatch, mock.Anything).Return(&domain.PollAnnouncement{PostId: postId, AuthorId: authorId, RecipientIds: []string{authorId, followeeId}}, nil).Once()
+		pollRepository.On("Announce", mock.Anything, pollAnnounceBatch, mock.Anything).Return(&domain.PollAnnouncement{PostId: postId, AuthorId: authorId, RecipientIds: []string{folderId}}, nil).Once()
+		pollRepository.On("Announce", mock.Anything, pollAnnounceBatch, mock.Anything).Return(nil, nil).Once()
+
+		service := NewPollService(pollRepository, &mocks.PostRepositoryMock{}, WithPollStream(stream)).(*pollService)
+		service.finalize(context.Background())
+		pollRepository.AssertExpectations(t)
+
+		var recipients []string
+		for _, event := range stream.events {
+			require.Equal(t, domain.NotificationPollClosed, event.Type)
+			require.Equal(t, authorId, event.ActorId)
+			require.Equal(t, postId, *event.PostId)
+			recipients = append(recipients, event.RecipientId)
+		}
+		require.Equal(t, []string{authorId, followeeId, folderId}, recipients)
+	})
+
+	t.Run("works through full batches", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		pollRepository.On("Finalize", mock.Anything, pollFinalizeBatch).Return(pollFinalizeBatch, nil).Once()
+		pollRepository.On("Finalize", mock.Anything, pollFinalizeBatch).Return(0, nil).Once()
+		pollRepository.On("Announce", mock.Anything, pollAnnounceBatch, mock.Anything).Return(nil, nil)
+
+		service := NewPollService(pollRepository, &mocks.PostRepositoryMock{}).(*pollService)
+		service.finalize(context.Background())
+		pollRepository.AssertNumberOfCalls(t, "Finalize", 2)
+	})
+
+	t.Run("a failing batch does not hold up the announcements", func(t *testing.T) {
+		t.Parallel()
+		pollRepository := &mocks.PollRepositoryMock{}
+		pollRepository.On("Finalize", mock.Anything, pollFinalizeBatch).Return(0, context.DeadlineExceeded)
+		pollRepository.On("Announce", mock.Anything, pollAnnounceBatch, mock.Anything).Return(nil, nil)
+
+		service := NewPollService(pollRepository, &mocks.PostRepositoryMock{}).(*pollService)
+		service.finalize(context.Background())
+		pollRepository.AssertNumberOfCalls(t, "Finalize", 1)
+		pollRepository.AssertNumberOfCalls(t, "Announce", 1)
+	})
+}
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/entities"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"time"
+)
+
+type PostService interface {
+	Create(ctx context.Context, authorId string, input *dto.CreatePostInput) (*dto.PostResponse, error)
+	GetById(ctx context.Context, userId, id string) (*dto.PostResponse, error)
+	ListByAuthor(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.PostListResponse, error)
+	GetThread(ctx context.Context, userId, id string, input *dto.ThreadInput) (*dto.ThreadResponse, error)
+	ListReplies(ctx context.Context, userId, id string, input *dto.ThreadInput) (*dto.RepliesResponse, error)
+	Delete(ctx context.Context, userId, id string) error
+}
+
+// maxResolvedMentions bounds the username lookups a single post can cause;
+// further mentions stay plain text.
+const maxResolvedMentions = 10
+
+type postService struct {
+	postRepository repository.PostRepository
+	userRepository repository.UserRepository
+	timeline       TimelineFanOut
+	notifier       Notifier
+	logger         *slog.Logger
+}
+
+type PostOptions func(*postService)
+
+func WithPostTimeline(timeline TimelineFanOut) PostOptions {
+	return func(p *postService) {
+		p.timeline = timeline
+	}
+}
+
+func WithPostNotifier(notifier Notifier) PostOptions {
+	return func(p *postService) {
+		p.notifier = notifier
+	}
+}
+
+func WithPostLogger(logger *slog.Logger) PostOptions {
+	return func(p *postService) {
+		p.logger = logger
+	}
+}
+
+func (p *postService) Create(ctx context.Context, authorId string, input *dto.CreatePostInput) (*dto.PostResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	author, err := p.userRepository.GetById(ctx, authorId)
+	if err != nil {
+		return nil, err
+	}
+
+	if author.IsDeleted() {
+		return nil, customErr.ErrUserDeleted
+	}
+
+	post := &domain.Post{
+		AuthorId: author.Id,
+		Body:     input.Body,
+		Media:    input.PostMedia(),
+	}
+	if input.Poll != nil {
+		post.Poll = input.Poll.Poll(time.Now())
+	}
+
+	var parent *domain.Post
+	if input.ReplyToId != nil {
+		parent, err = p.postRepository.GetById(ctx, authorId, *input.ReplyToId)
+		if err != nil {
+			return nil, err
+		}
+		post.ParentId = &parent.Id
+		post.ConversationId = parent.ConversationId
+		post.Depth = parent.Depth + 1
+	}
+
+	if input.QuoteId != nil {
+		quote, err := p.postRepository.GetById(ctx, authorId, *input.QuoteId)
+		if err != nil {
+			return nil, err
+		}
+		post.QuoteId = &quote.Id
+		post.Quote = quote
+	}
+
+	post.Entities, err = p.resolveEntities(ctx, entities.Extract(post.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	post, err = p.postRepository.Create(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("error creating post: %w", err)
+	}
+	post.Author = author
+
+	p.notify(ctx, post, parent)
+
+	// The post is stored at this point; a failed fan-out only delays it in
+	// timelines and must not make the client retry and duplicate it.
+	if err := p.timeline.PostCreated(ctx, post); err != nil {
+		p.logger.ErrorContext(ctx, "timeline fan-out failed", "post_id", post.Id, "err", err.Error())
+	}
+
+	return dto.NewPostResponse(post), nil
+}
+
+func (p *postService) GetById(ctx context.Context, userId, id string) (*dto.PostResponse, error) {
+	if !dto.IsValidId(id) {
+		return nil, customErr.ErrNotFound
+	}
+
+	post, err := p.postRepository.GetById(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPostResponse(post), nil
+}
+
+func (p *postService) ListByAuthor(ctx context.Context, userId, username string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	author, err := p.userRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if author.IsDeleted() {
+		return nil, customErr.ErrNotFound
+	}
+
+	posts, err := p.postRepository.ListByAuthor(ctx, userId, author.Id, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewPostListResponse(posts, input.Limit), nil
+}
+
+// resolveEntities looks up the users mentioned in a post. Mentions of unknown or
+// deactivated users are dropped, as are mentions past maxResolvedMentions.
+func (p *postService) resolveEntities(ctx context.Context, extracted []*domain.Entity) ([]*domain.Entity, error) {
+	resolved := make(map[string]*string)
+	res := make([]*domain.Entity, 0, len(extracted))
+	for _, entity := range extracted {
+		if entity.Type != domain.EntityMention {
+			res = append(res, entity)
+			continue
+		}
+
+		userId, seen := resolved[entity.Text]
+		if !seen {
+			if len(resolved) == maxResolvedMentions {
+				continue
+			}
+
+			user, err := p.userRepository.GetByUsername(ctx, entity.Text)
+			switch {
+			case errors.Is(err, customErr.ErrNotFound):
+			case err != nil:
+				return nil, err
+			case !user.IsDeleted():
+				userId = &user.Id
+			}
+			resolved[entity.Text] = userId
+		}
+
+		if userId != nil {
+			entity.UserId = userId
+			res = append(res, entity)
+		}
+	}
+	return res, nil
+}
+
+// notify tells the author of the parent post about a reply and the mentioned
+// users about the mention. An author replied to and mentioned at once is only
+// told about the reply.
+func (p *postService) notify(ctx context.Context, post, parent *domain.Post) {
+	if parent != nil {
+		p.notifier.Notify(ctx, &domain.NotificationEvent{
+			Type:        domain.NotificationReply,
+			RecipientId: parent.AuthorId,
+			ActorId:     post.AuthorId,
+			PostId:      &post.Id,
+			CreatedAt:   post.CreatedAt,
+		})
+	}
+
+	for _, userId := range mentionedUsers(post) {
+		if parent != nil && userId == parent.AuthorId {
+			continue
+		}
+		p.notifier.Notify(ctx, &domain.NotificationEvent{
+			Type:        domain.NotificationMention,
+			RecipientId: userId,
+			ActorId:     post.AuthorId,
+			PostId:      &post.Id,
+			CreatedAt:   post.CreatedAt,
+		})
+	}
+}
+
+// mentionedUsers returns the distinct users a post mentions, except its author.
+func mentionedUsers(post *domain.Post) []string {
+	seen := make(map[string]bool)
+	var userIds []string
+	for _, entity := range post.Entities {
+		if entity.UserId == nil || *entity.UserId == post.AuthorId || seen[*entity.UserId] {
+			continue
+		}
+		seen[*entity.UserId] = true
+		userIds = append(userIds, *entity.UserId)
+	}
+	return userIds
+}
+
+// GetThread returns the post with the chain of posts it replies to and the first
+// levels of its replies. Deleted posts in the thread are returned as tombstones,
+// but the requested post itself must not be deleted nor hidden from the user by
+// a block.
+func (p *postService) GetThread(ctx context.Context, userId, id string, input *dto.ThreadInput) (*dto.ThreadResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !dto.IsValidId(id) {
+		return nil, customErr.ErrNotFound
+	}
+
+	chain, err := p.postRepository.ListAncestors(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(chain) == 0 || chain[len(chain)-1].IsDeleted() || chain[len(chain)-1].Unavailable {
+		return nil, customErr.ErrNotFound
+	}
+
+	replies, err := p.postRepository.ListReplies(ctx, userId, id, input.DecodedCursor(), input.Limit+1, dto.ThreadChildLimit+1, input.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &dto.ThreadResponse{
+		Ancestors: make([]*dto.PostResponse, 0, len(chain)-1),
+		Post:      dto.NewPostResponse(chain[len(chain)-1]),
+	}
+	for _, ancestor := range chain[:len(chain)-1] {
+		res.Ancestors = append(res.Ancestors, dto.NewPostResponse(ancestor))
+	}
+
+	page := dto.NewRepliesResponse(id, replies, input.Limit, dto.ThreadChildLimit)
+	res.Replies, res.NextCursor = page.Replies, page.NextCursor
+	return res, nil
+}
+
+// ListReplies pages through the direct replies of a post, each with the first
+// levels of its own replies. Replies of a deleted post stay reachable.
+func (p *postService) ListReplies(ctx context.Context, userId, id string, input *dto.ThreadInput) (*dto.RepliesResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if !dto.IsValidId(id) {
+		return nil, customErr.ErrNotFound
+	}
+
+	replies, err := p.postRepository.ListReplies(ctx, userId, id, input.DecodedCursor(), input.Limit+1, dto.ThreadChildLimit+1, input.Depth)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewRepliesResponse(id, replies, input.Limit, dto.ThreadChildLimit), nil
+}
+
+func (p *postService) Delete(ctx context.Context, userId, id string) error {
+	if !dto.IsValidId(id) {
+		return customErr.ErrNotFound
+	}
+
+	post, err := p.postRepository.GetById(ctx, userId, id)
+	if err != nil {
+		return err
+	}
+
+	if post.AuthorId != userId {
+		return customErr.ErrForbidden
+	}
+
+	if err := p.postRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	if err := p.timeline.PostDeleted(ctx, post); err != nil {
+		p.logger.ErrorContext(ctx, "timeline removal failed", "post_id", post.Id, "err", err.Error())
+	}
+	return nil
+}
+
+func NewPostService(postRepository repository.PostRepository, userRepository repository.UserRepository, opts ...PostOptions) PostService {
+	p := &postService{
+		postRepository: postRepository,
+		userRepository: userRepository,
+		timeline:       nopTimeline{},
+		notifier:       nopNotifier{},
+		logger:         slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	authorId = "6ba7b810-9dad-11d1-80b4-00c04fd430c8"
+	postId   = "6ba7b811-9dad-11d1-80b4-00c04fd430c8"
+)
+
+func TestPostService_Create(t *testing.T) {
+	author := &domain.User{Id: authorId, Username: "bob"}
+
+	t.Run("can create", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("Create", mock.Anything, mock.MatchedBy(func(post *domain.Post) bool {
+			return post.AuthorId == authorId && post.Body == "hello"
+		})).Return(&domain.Post{Id: postId, AuthorId: authorId, Body: "hello", CreatedAt: time.Now()}, nil)
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.Create(ctx, authorId, &dto.CreatePostInput{Body: "  hello "})
+		require.NoError(t, err)
+		require.Equal(t, postId, res.Id)
+		require.Equal(t, "bob", res.Author.Username)
+
+		postRepository.AssertExpectations(t)
+		userRepository.AssertExpectations(t)
+	})
+
+	t.Run("with media only", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("Create", mock.Anything, mock.MatchedBy(func(post *domain.Post) bool {
+			return post.Body == "" && len(post.Media) == 2 &&
+				post.Media[0].Id == followeeId && post.Media[0].AltText == "a cat" &&
+				post.Media[1].Id == postId && post.Media[1].Position == 1
+		})).Return(func(_ context.Context, post *domain.Post) (*domain.Post, error) {
+			post.Id = postId
+			return post, nil
+		})
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.Create(ctx, authorId, &dto.CreatePostInput{Media: []*dto.AttachMediaInput{{Id: followeeId, AltText: " a cat "}, {Id: postId}}})
+		require.NoError(t, err)
+		require.Len(t, res.Media, 2)
+		require.Equal(t, "a cat", res.Media[0].AltText)
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("with a poll", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		before := time.Now()
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("Create", mock.Anything, mock.MatchedBy(func(post *domain.Post) bool {
+			return post.Poll != nil && len(post.Poll.Options) == 2 && post.Poll.Options[1].Text == "No" &&
+				!post.Poll.ClosesAt.Before(before.Add(time.Hour))
+		})).Return(func(_ context.Context, post *domain.Post) (*domain.Post, error) {
+			post.Id = postId
+			return post, nil
+		})
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.Create(ctx, authorId, &dto.CreatePostInput{Body: "Tabs?", Poll: &dto.PollInput{Options: []string{"Yes", " No "}, DurationMinutes: 60}})
+		require.NoError(t, err)
+		require.Len(t, res.Poll.Options, 2)
+		require.False(t, res.Poll.Closed)
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("media of others cannot be attached", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("Create", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("%w: media not found or already attached", customErr.ErrValidation))
+
+		service := NewPostService(postRepository, userRepository)
+		_, err := service.Create(ctx, authorId, &dto.CreatePostInput{Media: []*dto.AttachMediaInput{{Id: postId}}})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		service := NewPostService(postRepository, userRepository)
+		_, err := service.Create(ctx, authorId, &dto.CreatePostInput{Body: "   "})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		postRepository.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("something"))
+
+		service := NewPostService(postRepository, userRepository)
+		_, err := service.Create(ctx, authorId, &dto.CreatePostInput{Body: "hello"})
+		require.Error(t, err)
+		postRepository.AssertExpectations(t)
+	})
+}
+
+func TestPostService_GetById(t *testing.T) {
+	t.Run("malformed id is not found", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+
+		_, err := service.GetById(context.Background(), authorId, "nope")
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		postRepository.AssertNotCalled(t, "GetById")
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(nil, customErr.ErrNotFound)
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+
+		_, err := service.GetById(context.Background(), authorId, postId)
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		postRepository.AssertExpectations(t)
+	})
+}
+
+func TestPostService_ListByAuthor(t *testing.T) {
+	t.Run("asks for one more than the page", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId, Username: "bob"}, nil)
+		postRepository.On("ListByAuthor", mock.Anything, followeeId, authorId, (*domain.Cursor)(nil), 3).Return([]*domain.Post{
+			{Id: postId, CreatedAt: time.Now()},
+		}, nil)
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.ListByAuthor(context.Background(), followeeId, "bob", &dto.PageInput{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, res.Posts, 1)
+		require.Empty(t, res.NextCursor)
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("unknown author", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		userRepository.On("GetByUsername", mock.Anything, "ghost").Return(nil, customErr.ErrNotFound)
+
+		service := NewPostService(&mocks.PostRepositoryMock{}, userRepository)
+		_, err := service.ListByAuthor(context.Background(), followeeId, "ghost", &dto.PageInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+	})
+}
+
+func TestPostService_Delete(t *testing.T) {
+	t.Run("author can delete", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+		postRepository.On("Delete", mock.Anything, postId).Return(nil)
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+		require.NoError(t, service.Delete(context.Background(), authorId, postId))
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("others cannot delete", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		postRepository.On("GetById", mock.Anything, "someone-else", postId).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+		err := service.Delete(context.Background(), "someone-else", postId)
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		postRepository.AssertNotCalled(t, "Delete")
+	})
+}
+
+func TestPostService_Reply(t *testing.T) {
+	author := &domain.User{Id: authorId, Username: "bob"}
+	replyId := "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+
+	t.Run("joins the parent conversation", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, ConversationId: "conversation", Depth: 1}, nil)
+		postRepository.On("Create", mock.Anything, mock.MatchedBy(func(post *domain.Post) bool {
+			return *post.ParentId == postId && post.ConversationId == "conversation" && post.Depth == 2
+		})).Return(&domain.Post{Id: replyId, ParentId: &[]string{postId}[0], ConversationId: "conversation", Depth: 2}, nil)
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "hi", ReplyToId: &[]string{postId}[0]})
+		require.NoError(t, err)
+		require.Equal(t, postId, *res.ReplyToId)
+		require.Equal(t, "conversation", res.ConversationId)
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("deleted parent", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(author, nil)
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(nil, customErr.ErrNotFound)
+
+		service := NewPostService(postRepository, userRepository)
+		_, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "hi", ReplyToId: &[]string{postId}[0]})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		postRepository.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestPostService_GetThread(t *testing.T) {
+	t.Run("ancestors and replies", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		deletedAt := time.Now()
+		rootId := "6ba7b813-9dad-11d1-80b4-00c04fd430c8"
+
+		postRepository.On("ListAncestors", mock.Anything, followeeId, postId).Return([]*domain.Post{
+			{Id: rootId, Body: "gone", DeletedAt: &deletedAt},
+			{Id: postId, ParentId: &rootId, Body: "hello"},
+		}, nil)
+		postRepository.On("ListReplies", mock.Anything, followeeId, postId, (*domain.Cursor)(nil), 3, dto.ThreadChildLimit+1, 2).Return([]*domain.Post{
+			{Id: "reply", ParentId: &[]string{postId}[0], Body: "hi"},
+		}, nil)
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+		res, err := service.GetThread(context.Background(), followeeId, postId, &dto.ThreadInput{PageInput: dto.PageInput{Limit: 2}, Depth: 2})
+		require.NoError(t, err)
+		require.Len(t, res.Ancestors, 1)
+		require.True(t, res.Ancestors[0].Deleted)
+		require.Empty(t, res.Ancestors[0].Body)
+		require.Equal(t, postId, res.Post.Id)
+		require.Len(t, res.Replies, 1)
+		require.Empty(t, res.NextCursor)
+		postRepository.AssertExpectations(t)
+	})
+
+	t.Run("deleted post is not found", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		deletedAt := time.Now()
+		postRepository.On("ListAncestors", mock.Anything, followeeId, postId).Return([]*domain.Post{{Id: postId, DeletedAt: &deletedAt}}, nil)
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+		_, err := service.GetThread(context.Background(), followeeId, postId, &dto.ThreadInput{})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		postRepository.AssertNotCalled(t, "ListReplies")
+	})
+
+	t.Run("depth is bounded", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{})
+		_, err := service.GetThread(context.Background(), followeeId, postId, &dto.ThreadInput{Depth: dto.MaxThreadDepth + 1})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		postRepository.AssertNotCalled(t, "ListAncestors")
+	})
+}
+
+func TestPostService_Quote(t *testing.T) {
+	t.Run("embeds the quoted post", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		quoteId := "6ba7b815-9dad-11d1-80b4-00c04fd430c8"
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId}, nil)
+		postRepository.On("GetById", mock.Anything, authorId, quoteId).Return(&domain.Post{Id: quoteId, Body: "original", Author: &domain.User{Username: "alice"}}, nil)
+		postRepository.On("Create", mock.Anything, mock.MatchedBy(func(post *domain.Post) bool {
+			return *post.QuoteId == quoteId
+		})).Return(func(_ context.Context, post *domain.Post) (*domain.Post, error) {
+			post.Id = postId
+			return post, nil
+		})
+
+		service := NewPostService(postRepository, userRepository)
+		res, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "so true", QuoteId: &quoteId})
+		require.NoError(t, err)
+		require.Equal(t, "original", res.Quote.Body)
+		require.Equal(t, "alice", res.Quote.Author.Username)
+	})
+
+	t.Run("deleted post cannot be quoted", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId}, nil)
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(nil, customErr.ErrNotFound)
+
+		service := NewPostService(postRepository, userRepository)
+		_, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "so true", QuoteId: &[]string{postId}[0]})
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		postRepository.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestPostService_Entities(t *testing.T) {
+	postRepository := &mocks.PostRepositoryMock{}
+	userRepository := &mocks.UserRepositoryMock{}
+	notifier := &notificationRecorder{}
+	deletedAt := time.Now()
+
+	userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId, Username: "bob"}, nil)
+	userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId, Username: "alice"}, nil).Once()
+	userRepository.On("GetByUsername", mock.Anything, "bob").Return(&domain.User{Id: authorId, Username: "bob"}, nil).Once()
+	userRepository.On("GetByUsername", mock.Anything, "ghost").Return(nil, customErr.ErrNotFound).Once()
+	userRepository.On("GetByUsername", mock.Anything, "gone").Return(&domain.User{Id: postId, DeletedAt: &deletedAt}, nil).Once()
+	postRepository.On("Create", mock.Anything, mock.Anything).Return(func(_ context.Context, post *domain.Post) (*domain.Post, error) {
+		post.Id = postId
+		return post, nil
+	})
+
+	service := NewPostService(postRepository, userRepository, WithPostNotifier(notifier))
+	res, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "@alice @bob @ghost @gone @alice #go https://go.dev"})
+	require.NoError(t, err)
+
+	var types []string
+	for _, entity := range res.Entities {
+		types = append(types, entity.Type)
+		if entity.Type == string(domain.EntityMention) {
+			require.NotNil(t, entity.UserId)
+		}
+	}
+	require.Equal(t, []string{"mention", "mention", "mention", "hashtag", "url"}, types)
+	require.Equal(t, []string{followeeId}, notifier.recipients(domain.NotificationMention))
+	userRepository.AssertExpectations(t)
+}
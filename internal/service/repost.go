@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+)
+
+type RepostService interface {
+	Repost(ctx context.Context, userId, postId string) (*dto.RepostResponse, error)
+	Unrepost(ctx context.Context, userId, postId string) (*dto.RepostResponse, error)
+}
+
+type repostService struct {
+	repostRepository repository.RepostRepository
+	postRepository   repository.PostRepository
+	userRepository   repository.UserRepository
+	timeline         TimelineFanOut
+	notifier         Notifier
+	logger           *slog.Logger
+}
+
+type RepostOptions func(*repostService)
+
+func WithRepostTimeline(timeline TimelineFanOut) RepostOptions {
+	return func(r *repostService) {
+		r.timeline = timeline
+	}
+}
+
+func WithRepostNotifier(notifier Notifier) RepostOptions {
+	return func(r *repostService) {
+		r.notifier = notifier
+	}
+}
+
+func WithRepostLogger(logger *slog.Logger) RepostOptions {
+	return func(r *repostService) {
+		r.logger = logger
+	}
+}
+
+// Repost is idempotent: reposting a post twice neither moves it up again nor
+// duplicates it in timelines. Posts of protected accounts cannot be reposted,
+// not even by their followers or their author.
+func (r *repostService) Repost(ctx context.Context, userId, postId string) (*dto.RepostResponse, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	reposter, err := r.userRepository.GetById(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	if reposter.IsDeleted() {
+		return nil, customErr.ErrUserDeleted
+	}
+
+	post, err := r.postRepository.GetById(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if post.Author != nil && post.Author.Protected {
+		return nil, fmt.Errorf("%w: posts of protected accounts cannot be reposted", customErr.ErrForbidden)
+	}
+
+	repost := &domain.Repost{UserId: userId, PostId: post.Id, Post: post}
+	created, err := r.repostRepository.Create(ctx, repost)
+	if err != nil {
+		return nil, err
+	}
+
+	if created {
+		if err := r.timeline.Reposted(ctx, reposter, repost); err != nil {
+			r.logger.ErrorContext(ctx, "timeline fan-out failed", "post_id", post.Id, "err", err.Error())
+		}
+		r.notifier.Notify(ctx, &domain.NotificationEvent{
+			Type:        domain.NotificationRepost,
+			RecipientId: post.AuthorId,
+			ActorId:     userId,
+			PostId:      &post.Id,
+			CreatedAt:   repost.CreatedAt,
+		})
+	}
+	return &dto.RepostResponse{Reposted: true}, nil
+}
+
+// Unrepost is idempotent and also works once the post has been deleted.
+func (r *repostService) Unrepost(ctx context.Context, userId, postId string) (*dto.RepostResponse, error) {
+	if !dto.IsValidId(postId) {
+		return nil, customErr.ErrNotFound
+	}
+
+	deleted, err := r.repostRepository.Delete(ctx, userId, postId)
+	if err != nil {
+		return nil, err
+	}
+
+	if deleted {
+		if err := r.timeline.Unreposted(ctx, userId, postId); err != nil {
+			r.logger.ErrorContext(ctx, "timeline removal failed", "post_id", postId, "err", err.Error())
+		}
+	}
+	return &dto.RepostResponse{Reposted: false}, nil
+}
+
+func NewRepostService(repostRepository repository.RepostRepository, postRepository repository.PostRepository, userRepository repository.UserRepository, opts ...RepostOptions) RepostService {
+	r := &repostService{
+		repostRepository: repostRepository,
+		postRepository:   postRepository,
+		userRepository:   userRepository,
+		timeline:         nopTimeline{},
+		notifier:         nopNotifier{},
+		logger:           slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
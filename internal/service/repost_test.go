@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestRepostService_Repost(t *testing.T) {
+	reposter := &domain.User{Id: followeeId, Username: "alice"}
+
+	t.Run("fans out new reposts", func(t *testing.T) {
+		t.Parallel()
+		repostRepository := &mocks.RepostRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, followeeId).Return(reposter, nil)
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+		repostRepository.On("Create", mock.Anything, mock.Anything).Return(true, nil)
+		timelineRepository.On("AddRepost", mock.Anything, mock.MatchedBy(func(repost *domain.Repost) bool {
+			return repost.UserId == followeeId && repost.Post.AuthorId == authorId
+		})).Return(nil)
+
+		service := NewRepostService(repostRepository, postRepository, userRepository, WithRepostTimeline(NewTimelineService(timelineRepository)))
+		res, err := service.Repost(context.Background(), followeeId, postId)
+		require.NoError(t, err)
+		require.True(t, res.Reposted)
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("reposting twice does not fan out again", func(t *testing.T) {
+		t.Parallel()
+		repostRepository := &mocks.RepostRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, followeeId).Return(reposter, nil)
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(&domain.Post{Id: postId}, nil)
+		repostRepository.On("Create", mock.Anything, mock.Anything).Return(false, nil)
+
+		service := NewRepostService(repostRepository, postRepository, userRepository, WithRepostTimeline(NewTimelineService(timelineRepository)))
+		_, err := service.Repost(context.Background(), followeeId, postId)
+		require.NoError(t, err)
+		timelineRepository.AssertNotCalled(t, "AddRepost")
+	})
+
+	t.Run("deleted post", func(t *testing.T) {
+		t.Parallel()
+		repostRepository := &mocks.RepostRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, followeeId).Return(reposter, nil)
+		postRepository.On("GetById", mock.Anything, followeeId, postId).Return(nil, customErr.ErrNotFound)
+
+		service := NewRepostService(repostRepository, postRepository, userRepository)
+		_, err := service.Repost(context.Background(), followeeId, postId)
+		require.ErrorIs(t, err, customErr.ErrNotFound)
+		repostRepository.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("protected post", func(t *testing.T) {
+		t.Parallel()
+		repostRepository := &mocks.RepostRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, followeeId).Return(reposter, nil)
+		postRepository.On("GetById", mock.Anything, followeeId, postId).
+			Return(&domain.Post{Id: postId, AuthorId: authorId, Author: &domain.User{Id: authorId, Protected: true}}, nil)
+
+		service := NewRepostService(repostRepository, postRepository, userRepository)
+		_, err := service.Repost(context.Background(), followeeId, postId)
+		require.ErrorIs(t, err, customErr.ErrForbidden)
+		repostRepository.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("deactivated reposter", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		deletedAt := time.Now()
+		userRepository.On("GetById", mock.Anything, followeeId).Return(&domain.User{Id: followeeId, DeletedAt: &deletedAt}, nil)
+
+		service := NewRepostService(&mocks.RepostRepositoryMock{}, &mocks.PostRepositoryMock{}, userRepository)
+		_, err := service.Repost(context.Background(), followeeId, postId)
+		require.ErrorIs(t, err, customErr.ErrUserDeleted)
+	})
+}
+
+func TestRepostService_Unrepost(t *testing.T) {
+	repostRepository := &mocks.RepostRepositoryMock{}
+	timelineRepository := &mocks.TimelineRepositoryMock{}
+
+	repostRepository.On("Delete", mock.Anything, followeeId, postId).Return(true, nil)
+	timelineRepository.On("RemoveRepost", mock.Anything, followeeId, postId).Return(nil)
+
+	service := NewRepostService(repostRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{}, WithRepostTimeline(NewTimelineService(timelineRepository)))
+	res, err := service.Unrepost(context.Background(), followeeId, postId)
+	require.NoError(t, err)
+	require.False(t, res.Reposted)
+	timelineRepository.AssertExpectations(t)
+}
@@ -4,8 +4,11 @@ import (
 	"golang.org/x/crypto/bcrypt"
 	"os"
 	"testing"
+	"time"
 )
 
+var testTokenService = NewTokenService("secret", "x", time.Hour)
+
 func TestMain(t *testing.M) {
 	passwordCost = bcrypt.MinCost
 	os.Exit(t.Run())
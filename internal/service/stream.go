@@ -0,0 +1,469 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// streamBatchSize is how many stored events a subscription reads at a time.
+const streamBatchSize = 100
+
+// StreamPublisher puts events on the real-time stream.
+type StreamPublisher interface {
+	NotificationStored(ctx context.Context, event *domain.NotificationEvent) error
+	PostPublished(ctx context.Context, post *domain.Post) error
+	Reposted(ctx context.Context, repost *domain.Repost) error
+	MessageSent(ctx context.Context, message *domain.Message, recipientIds []string) error
+	MessageRead(ctx context.Context, receipt *domain.ReadReceipt, recipientIds []string) error
+}
+
+// StreamWriter sends events to one connected client, over SSE or WebSocket.
+// Writes must give up once the client stopped reading for a while.
+type StreamWriter interface {
+	WriteEvent(ctx context.Context, event *dto.StreamEvent) error
+	Heartbeat(ctx context.Context) error
+}
+
+type StreamService interface {
+	StreamPublisher
+	Run(ctx context.Context) error
+	Subscribe(ctx context.Context, userId, lastEventId string) (*Subscription, error)
+}
+
+type streamService struct {
+	streamRepository repository.StreamRepository
+	postRepository   repository.PostRepository
+	userRepository   repository.UserRepository
+	listener         repository.StreamListener
+	heartbeat        time.Duration
+	maxBacklog       int
+	retention        time.Duration
+	logger           *slog.Logger
+
+	mu            sync.Mutex
+	subscriptions map[string]map[*Subscription]struct{}
+	closed        chan struct{}
+}
+
+type StreamOptions func(*streamService)
+
+func WithStreamHeartbeat(interval time.Duration) StreamOptions {
+	return func(s *streamService) {
+		s.heartbeat = interval
+	}
+}
+
+// WithStreamMaxBacklog sets how many missed events a client may catch up on
+// when resuming; a client further behind is told to reload instead.
+func WithStreamMaxBacklog(backlog int) StreamOptions {
+	return func(s *streamService) {
+		s.maxBacklog = backlog
+	}
+}
+
+// WithStreamRetention sets how long events are kept for resuming clients.
+func WithStreamRetention(retention time.Duration) StreamOptions {
+	return func(s *streamService) {
+		s.retention = retention
+	}
+}
+
+func WithStreamLogger(logger *slog.Logger) StreamOptions {
+	return func(s *streamService) {
+		s.logger = logger
+	}
+}
+
+type streamNotification struct {
+	Type      domain.NotificationType `json:"type"`
+	ActorId   string                  `json:"actor_id"`
+	PostId    *string                 `json:"post_id,omitempty"`
+	CreatedAt time.Time               `json:"created_at"`
+}
+
+type streamTimelineItem struct {
+	PostId       string  `json:"post_id"`
+	RepostedById *string `json:"reposted_by_id,omitempty"`
+}
+
+type streamMessage struct {
+	Id             string    `json:"id"`
+	ConversationId string    `json:"conversation_id"`
+	SenderId       string    `json:"sender_id"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type streamReceipt struct {
+	ConversationId string    `json:"conversation_id"`
+	UserId         string    `json:"user_id"`
+	MessageId      string    `json:"message_id"`
+	ReadAt         time.Time `json:"read_at"`
+}
+
+func (s *streamService) NotificationStored(ctx context.Context, event *domain.NotificationEvent) error {
+	payload, err := json.Marshal(streamNotification{Type: event.Type, ActorId: event.ActorId, PostId: event.PostId, CreatedAt: event.CreatedAt})
+	if err != nil {
+		return err
+	}
+	return s.streamRepository.Publish(ctx, &domain.StreamEvent{UserId: &event.RecipientId, Type: domain.StreamNotification, Payload: payload})
+}
+
+// PostPublished puts the post on the home stream of its author and followers.
+// It is a single broadcast whatever the follower count.
+func (s *streamService) PostPublished(ctx context.Context, post *domain.Post) error {
+	payload, err := json.Marshal(streamTimelineItem{PostId: post.Id})
+	if err != nil {
+		return err
+	}
+	return s.streamRepository.Publish(ctx, &domain.StreamEvent{AuthorId: &post.AuthorId, Type: domain.StreamTimeline, Payload: payload})
+}
+
+func (s *streamService) Reposted(ctx context.Context, repost *domain.Repost) error {
+	payload, err := json.Marshal(streamTimelineItem{PostId: repost.PostId, RepostedById: &repost.UserId})
+	if err != nil {
+		return err
+	}
+	return s.streamRepository.Publish(ctx, &domain.StreamEvent{AuthorId: &repost.UserId, Type: domain.StreamTimeline, Payload: payload})
+}
+
+// MessageSent puts the message on the stream of every participant, the sender
+// included so their other devices see it too. Conversations are small enough
+// for one event per participant.
+func (s *streamService) MessageSent(ctx context.Context, message *domain.Message, recipientIds []string) error {
+	payload, err := json.Marshal(streamMessage{Id: message.Id, ConversationId: message.ConversationId, SenderId: message.SenderId, Body: message.Body, CreatedAt: message.CreatedAt})
+	if err != nil {
+		return err
+	}
+	return s.publishTo(ctx, recipientIds, domain.StreamMessage, payload)
+}
+
+func (s *streamService) MessageRead(ctx context.Context, receipt *domain.ReadReceipt, recipientIds []string) error {
+	payload, err := json.Marshal(streamReceipt{ConversationId: receipt.ConversationId, UserId: receipt.UserId, MessageId: receipt.MessageId, ReadAt: receipt.ReadAt})
+	if err != nil {
+		return err
+	}
+	return s.publishTo(ctx, recipientIds, domain.StreamReceipt, payload)
+}
+
+func (s *streamService) publishTo(ctx context.Context, userIds []string, t domain.StreamEventType, payload []byte) error {
+	var errs []error
+	for _, userId := range userIds {
+		if err := s.streamRepository.Publish(ctx, &domain.StreamEvent{UserId: &userId, Type: t, Payload: payload}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run relays wake-ups from every instance to the local subscriptions and prunes
+// expired events until ctx is done. Subscriptions end when it returns.
+func (s *streamService) Run(ctx context.Context) error {
+	defer close(s.closed)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.prune(ctx)
+	}()
+	defer wg.Wait()
+
+	return s.listener.Listen(ctx, func(payload string) {
+		s.dispatch(ctx, payload)
+	})
+}
+
+func (s *streamService) prune(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.streamRepository.Prune(ctx, time.Now().Add(-s.retention)); err != nil {
+				s.logger.ErrorContext(ctx, "stream pruning failed", "err", err.Error())
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// dispatch wakes the subscriptions a published event may concern. Broadcasts
+// wake the author and whichever local subscribers follow them.
+func (s *streamService) dispatch(ctx context.Context, payload string) {
+	kind, id, _ := strings.Cut(payload, ":")
+	switch kind {
+	case "user":
+		s.wake(id)
+	case "author":
+		s.wake(id)
+		users := s.subscribedUsers()
+		if len(users) == 0 {
+			return
+		}
+		followers, err := s.streamRepository.ListFollowers(ctx, id, users)
+		if err != nil {
+			s.logger.ErrorContext(ctx, "stream dispatch failed", "author_id", id, "err", err.Error())
+			return
+		}
+		s.wake(followers...)
+	default:
+		s.wake(s.subscribedUsers()...)
+	}
+}
+
+// wake signals the users' subscriptions without ever blocking: a subscription
+// already signalled will read everything new anyway.
+func (s *streamService) wake(userIds ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, userId := range userIds {
+		for subscription := range s.subscriptions[userId] {
+			select {
+			case subscription.wake <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+func (s *streamService) subscribedUsers() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]string, 0, len(s.subscriptions))
+	for userId := range s.subscriptions {
+		users = append(users, userId)
+	}
+	return users
+}
+
+// Subscribe starts a subscription at lastEventId, or at the latest event when it
+// is empty. A client whose position was pruned, or which missed more than the
+// allowed backlog, starts at the latest event with a reset event.
+func (s *streamService) Subscribe(ctx context.Context, userId, lastEventId string) (*Subscription, error) {
+	first, last, err := s.streamRepository.Bounds(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &Subscription{service: s, userId: userId, position: last, wake: make(chan struct{}, 1)}
+	if lastEventId != "" {
+		resumeId, err := strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil || resumeId < 0 {
+			return nil, fmt.Errorf("%w: invalid last event id", customErr.ErrValidation)
+		}
+
+		subscription.reset = true
+		if resumeId >= first-1 && resumeId <= last.Id {
+			position, err := s.streamRepository.Position(ctx, resumeId)
+			if err != nil {
+				return nil, err
+			}
+
+			missed, err := s.streamRepository.CountAfter(ctx, userId, position, s.maxBacklog+1)
+			if err != nil {
+				return nil, err
+			}
+			if missed <= s.maxBacklog {
+				subscription.position, subscription.reset = position, false
+			}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.subscriptions[userId] == nil {
+		s.subscriptions[userId] = make(map[*Subscription]struct{})
+	}
+	s.subscriptions[userId][subscription] = struct{}{}
+	return subscription, nil
+}
+
+func (s *streamService) unsubscribe(subscription *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscriptions[subscription.userId], subscription)
+	if len(s.subscriptions[subscription.userId]) == 0 {
+		delete(s.subscriptions, subscription.userId)
+	}
+}
+
+// render turns a stored event into what the user's client receives. Events
+// about posts or users that are gone by now, or posts hidden from the user by a
+// block, are skipped.
+func (s *streamService) render(ctx context.Context, userId string, event *domain.StreamEvent) (*dto.StreamEvent, error) {
+	res := &dto.StreamEvent{Id: strconv.FormatInt(event.Id, 10), Type: string(event.Type)}
+	switch event.Type {
+	case domain.StreamNotification:
+		var payload streamNotification
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		actor, err := s.userRepository.GetById(ctx, payload.ActorId)
+		if err != nil || actor.IsDeleted() {
+			return nil, skipMissing(err)
+		}
+		res.Data = &dto.StreamNotification{Type: string(payload.Type), Actor: dto.NewPublicUser(actor), PostId: payload.PostId, CreatedAt: payload.CreatedAt}
+	case domain.StreamTimeline:
+		var payload streamTimelineItem
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		post, err := s.postRepository.GetById(ctx, userId, payload.PostId)
+		if err != nil || post.IsDeleted() {
+			return nil, skipMissing(err)
+		}
+		item := dto.NewPostResponse(post)
+		if payload.RepostedById != nil {
+			reposter, err := s.userRepository.GetById(ctx, *payload.RepostedById)
+			if err != nil || reposter.IsDeleted() {
+				return nil, skipMissing(err)
+			}
+			item.RepostedBy = dto.NewPublicUser(reposter)
+		}
+		res.Data = item
+	case domain.StreamMessage:
+		var payload streamMessage
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		sender, err := s.userRepository.GetById(ctx, payload.SenderId)
+		if err != nil || sender.IsDeleted() {
+			return nil, skipMissing(err)
+		}
+		res.Data = dto.NewMessageResponse(&domain.Message{Id: payload.Id, ConversationId: payload.ConversationId, SenderId: payload.SenderId, Sender: sender, Body: payload.Body, CreatedAt: payload.CreatedAt})
+	case domain.StreamReceipt:
+		var payload streamReceipt
+		if err := json.Unmarshal(event.Payload, &payload); err != nil {
+			return nil, err
+		}
+		reader, err := s.userRepository.GetById(ctx, payload.UserId)
+		if err != nil || reader.IsDeleted() {
+			return nil, skipMissing(err)
+		}
+		res.Data = dto.NewReadReceiptResponse(&domain.ReadReceipt{ConversationId: payload.ConversationId, UserId: payload.UserId, MessageId: payload.MessageId, ReadAt: payload.ReadAt}, reader)
+	default:
+		return nil, nil
+	}
+	return res, nil
+}
+
+func skipMissing(err error) error {
+	if errors.Is(err, customErr.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func NewStreamService(streamRepository repository.StreamRepository, postRepository repository.PostRepository, userRepository repository.UserRepository, listener repository.StreamListener, opts ...StreamOptions) StreamService {
+	s := &streamService{
+		streamRepository: streamRepository,
+		postRepository:   postRepository,
+		userRepository:   userRepository,
+		listener:         listener,
+		heartbeat:        25 * time.Second,
+		maxBacklog:       500,
+		retention:        24 * time.Hour,
+		logger:           slog.New(slog.DiscardHandler),
+		subscriptions:    make(map[string]map[*Subscription]struct{}),
+		closed:           make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Subscription is one client connection following a user's notifications, home
+// timeline and direct messages. It keeps the stream position of the last event
+// it handled, which stays valid after the event itself is pruned.
+type Subscription struct {
+	service  *streamService
+	userId   string
+	position domain.StreamPosition
+	reset    bool
+	wake     chan struct{}
+}
+
+// Serve writes the subscription's events to w until ctx is done, the service
+// stops or a write fails. Events are read from storage after each wake-up, so a
+// slow client falls behind without holding anything in memory; one that stops
+// reading fails its write deadline and is disconnected, free to resume later.
+func (s *Subscription) Serve(ctx context.Context, w StreamWriter) error {
+	if s.reset {
+		if err := w.WriteEvent(ctx, &dto.StreamEvent{Id: strconv.FormatInt(s.position.Id, 10), Type: string(domain.StreamReset)}); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(s.service.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		if err := s.flush(ctx, w); err != nil {
+			return err
+		}
+
+		select {
+		case <-s.wake:
+		case <-heartbeat.C:
+			if err := w.Heartbeat(ctx); err != nil {
+				return err
+			}
+		case <-s.service.closed:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (s *Subscription) flush(ctx context.Context, w StreamWriter) error {
+	for {
+		events, err := s.service.streamRepository.ListAfter(ctx, s.userId, s.position, streamBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			res, err := s.service.render(ctx, s.userId, event)
+			if err != nil {
+				return err
+			}
+			if res != nil {
+				if err := w.WriteEvent(ctx, res); err != nil {
+					return err
+				}
+			}
+			s.position = event.Position()
+		}
+
+		if len(events) < streamBatchSize {
+			return nil
+		}
+	}
+}
+
+func (s *Subscription) Close() {
+	s.service.unsubscribe(s)
+}
+
+// nopStream is used when no stream is wired in.
+type nopStream struct{}
+
+func (nopStream) NotificationStored(context.Context, *domain.NotificationEvent) error { return nil }
+func (nopStream) PostPublished(context.Context, *domain.Post) error                   { return nil }
+func (nopStream) Reposted(context.Context, *domain.Repost) error                      { return nil }
+func (nopStream) MessageSent(context.Context, *domain.Message, []string) error        { return nil }
+func (nopStream) MessageRead(context.Context, *domain.ReadReceipt, []string) error    { return nil }
@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+// streamRecorder is a client connection that records what it is sent.
+type streamRecorder struct {
+	events chan *dto.StreamEvent
+}
+
+func newStreamRecorder() *streamRecorder {
+	return &streamRecorder{events: make(chan *dto.StreamEvent, 10)}
+}
+
+func (s *streamRecorder) WriteEvent(_ context.Context, event *dto.StreamEvent) error {
+	s.events <- event
+	return nil
+}
+
+func (s *streamRecorder) Heartbeat(context.Context) error { return nil }
+
+func (s *streamRecorder) next(t *testing.T) *dto.StreamEvent {
+	t.Helper()
+	select {
+	case event := <-s.events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no stream event")
+		return nil
+	}
+}
+
+// fakeListener hands the wake-ups sent to it to Run.
+type fakeListener chan string
+
+func (f fakeListener) Listen(ctx context.Context, wake func(payload string)) error {
+	for {
+		select {
+		case payload := <-f:
+			wake(payload)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func timelineEvent(t *testing.T, position domain.StreamPosition, postId string) *domain.StreamEvent {
+	payload, err := json.Marshal(streamTimelineItem{PostId: postId})
+	require.NoError(t, err)
+	return &domain.StreamEvent{Id: position.Id, XactId: position.XactId, AuthorId: &[]string{followeeId}[0], Type: domain.StreamTimeline, Payload: payload}
+}
+
+func TestStreamService_Subscribe(t *testing.T) {
+	testCases := []struct {
+		name        string
+		lastEventId string
+		maxBacklog  int
+		missed      int
+		wantReset   bool
+		wantErr     error
+	}{
+		{name: "new subscriptions start at the latest event", lastEventId: "", maxBacklog: 2},
+		{name: "resumes within the backlog", lastEventId: "40", maxBacklog: 10, missed: 10},
+		{name: "too far behind", lastEventId: "40", maxBacklog: 2, missed: 3, wantReset: true},
+		{name: "position already pruned", lastEventId: "5", maxBacklog: 2, wantReset: true},
+		{name: "unknown position", lastEventId: "51", maxBacklog: 2, wantReset: true},
+		{name: "invalid id", lastEventId: "abc", maxBacklog: 2, wantErr: customErr.ErrValidation},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			streamRepository := &mocks.StreamRepositoryMock{}
+			last, resumed := domain.StreamPosition{XactId: 900, Id: 50}, domain.StreamPosition{XactId: 700, Id: 40}
+			streamRepository.On("Bounds", mock.Anything).Return(int64(10), last, nil)
+			streamRepository.On("Position", mock.Anything, int64(40)).Return(resumed, nil)
+			streamRepository.On("CountAfter", mock.Anything, authorId, resumed, tc.maxBacklog+1).Return(tc.missed, nil)
+
+			service := NewStreamService(streamRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{}, fakeListener(nil), WithStreamMaxBacklog(tc.maxBacklog))
+
+			subscription, err := service.Subscribe(context.Background(), authorId, tc.lastEventId)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer subscription.Close()
+			require.Equal(t, tc.wantReset, subscription.reset)
+			if tc.wantReset || tc.lastEventId == "" {
+				require.Equal(t, last, subscription.position)
+			} else {
+				require.Equal(t, resumed, subscription.position)
+			}
+		})
+	}
+}
+
+func TestStreamService_Serve(t *testing.T) {
+	t.Run("delivers events after a wake-up and skips deleted posts", func(t *testing.T) {
+		t.Parallel()
+		streamRepository := &mocks.StreamRepositoryMock{}
+		postRepository := &mocks.PostRepositoryMock{}
+		listener := make(fakeListener)
+		deletedId := "6ba7b812-9dad-11d1-80b4-00c04fd430c8"
+		deletedAt := time.Now()
+		caughtUp := make(chan struct{})
+
+		last := domain.StreamPosition{XactId: 70, Id: 7}
+		streamRepository.On("Bounds", mock.Anything).Return(int64(1), last, nil)
+		streamRepository.On("ListAfter", mock.Anything, authorId, last, streamBatchSize).Return(nil, nil).Once().Run(func(mock.Arguments) {
+			close(caughtUp)
+		})
+		streamRepository.On("ListAfter", mock.Anything, authorId, last, streamBatchSize).Return([]*domain.StreamEvent{
+			timelineEvent(t, domain.StreamPosition{XactId: 80, Id: 9}, deletedId),
+			timelineEvent(t, domain.StreamPosition{XactId: 81, Id: 8}, postId),
+		}, nil).Once()
+		streamRepository.On("ListAfter", mock.Anything, authorId, domain.StreamPosition{XactId: 81, Id: 8}, streamBatchSize).Return(nil, nil)
+		streamRepository.On("ListFollowers", mock.Anything, followeeId, []string{authorId}).Return([]string{authorId}, nil)
+		postRepository.On("GetById", mock.Anything, authorId, deletedId).Return(&domain.Post{Id: deletedId, DeletedAt: &deletedAt}, nil)
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, Body: "hello", Author: &domain.User{Id: followeeId}}, nil)
+
+		service := NewStreamService(streamRepository, postRepository, &mocks.UserRepositoryMock{}, listener)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go service.Run(ctx)
+
+		subscription, err := service.Subscribe(ctx, authorId, "")
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		recorder := newStreamRecorder()
+		served := make(chan error, 1)
+		go func() { served <- subscription.Serve(ctx, recorder) }()
+
+		// The first ListAfter call finds nothing; wait for it before publishing.
+		<-caughtUp
+		listener <- "author:" + followeeId
+
+		event := recorder.next(t)
+		require.Equal(t, "8", event.Id)
+		require.Equal(t, string(domain.StreamTimeline), event.Type)
+		require.Equal(t, "hello", event.Data.(*dto.PostResponse).Body)
+
+		cancel()
+		require.NoError(t, <-served)
+	})
+
+	t.Run("a client too far behind is told to reset", func(t *testing.T) {
+		t.Parallel()
+		streamRepository := &mocks.StreamRepositoryMock{}
+		last := domain.StreamPosition{XactId: 2000, Id: 200}
+		streamRepository.On("Bounds", mock.Anything).Return(int64(100), last, nil)
+		streamRepository.On("ListAfter", mock.Anything, authorId, last, streamBatchSize).Return(nil, nil)
+
+		service := NewStreamService(streamRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{}, fakeListener(nil))
+		subscription, err := service.Subscribe(context.Background(), authorId, "3")
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		recorder := newStreamRecorder()
+		go subscription.Serve(ctx, recorder)
+		defer cancel()
+
+		event := recorder.next(t)
+		require.Equal(t, string(domain.StreamReset), event.Type)
+		require.Equal(t, "200", event.Id)
+	})
+
+	t.Run("subscriptions end when the service stops", func(t *testing.T) {
+		t.Parallel()
+		streamRepository := &mocks.StreamRepositoryMock{}
+		streamRepository.On("Bounds", mock.Anything).Return(int64(0), domain.StreamPosition{}, nil)
+		streamRepository.On("ListAfter", mock.Anything, authorId, domain.StreamPosition{}, streamBatchSize).Return(nil, nil)
+
+		service := NewStreamService(streamRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{}, make(fakeListener))
+		subscription, err := service.Subscribe(context.Background(), authorId, "")
+		require.NoError(t, err)
+		defer subscription.Close()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan error, 1)
+		go func() { stopped <- service.Run(ctx) }()
+		cancel()
+		require.NoError(t, <-stopped)
+		require.NoError(t, subscription.Serve(context.Background(), newStreamRecorder()))
+	})
+}
+
+func TestNotificationService_Stream(t *testing.T) {
+	testCases := []struct {
+		name    string
+		stored  bool
+		publish bool
+	}{
+		{name: "stored notifications are streamed", stored: true, publish: true},
+		{name: "muted ones are not", stored: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			notificationRepository := &mocks.NotificationRepositoryMock{}
+			streamRepository := &mocks.StreamRepositoryMock{}
+			notificationRepository.On("Create", mock.Anything, mock.Anything, mock.Anything).Return(tc.stored, nil)
+			streamRepository.On("Publish", mock.Anything, mock.MatchedBy(func(event *domain.StreamEvent) bool {
+				return *event.UserId == authorId && event.Type == domain.StreamNotification
+			})).Return(nil)
+
+			stream := NewStreamService(streamRepository, &mocks.PostRepositoryMock{}, &mocks.UserRepositoryMock{}, fakeListener(nil))
+			service := NewNotificationService(notificationRepository, WithNotificationStream(stream))
+			service.Notify(context.Background(), &domain.NotificationEvent{Type: domain.NotificationFollow, RecipientId: authorId, ActorId: followeeId})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			service.Run(ctx)
+			if tc.publish {
+				streamRepository.AssertNumberOfCalls(t, "Publish", 1)
+			} else {
+				streamRepository.AssertNotCalled(t, "Publish")
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+	"log/slog"
+	"time"
+)
+
+// TimelineFanOut keeps home timelines in step with posts and follows.
+type TimelineFanOut interface {
+	PostCreated(ctx context.Context, post *domain.Post) error
+	PostDeleted(ctx context.Context, post *domain.Post) error
+	Followed(ctx context.Context, followerId string, followee *domain.User) error
+	Unfollowed(ctx context.Context, followerId, followeeId string) error
+	Reposted(ctx context.Context, reposter *domain.User, repost *domain.Repost) error
+	Unreposted(ctx context.Context, userId, postId string) error
+}
+
+type TimelineService interface {
+	TimelineFanOut
+	Home(ctx context.Context, userId string, input *dto.PageInput) (*dto.PostListResponse, error)
+	// Run fans out the new posts and reposts to the followers' timelines and
+	// moves the accounts that crossed the fan-out threshold, until ctx is done.
+	Run(ctx context.Context)
+}
+
+// timelineFanOutBatch is the number of posts, and of reposts, fanned out per
+// statement; with up to fanOutThreshold followers each, it keeps a statement
+// well within its timeout.
+const timelineFanOutBatch = 20
+
+type timelineService struct {
+	timelineRepository repository.TimelineRepository
+	fanOutThreshold    int
+	backfillSize       int
+	fanOutInterval     time.Duration
+	stream             StreamPublisher
+	logger             *slog.Logger
+}
+
+type TimelineOptions func(*timelineService)
+
+// WithFanOutThreshold sets the follower count from which an author's posts are
+// merged into timelines on read instead of being written to every follower.
+func WithFanOutThreshold(threshold int) TimelineOptions {
+	return func(t *timelineService) {
+		t.fanOutThreshold = threshold
+	}
+}
+
+// WithBackfillSize sets how many recent posts of a newly followed account are
+// copied into the follower's timeline.
+func WithBackfillSize(size int) TimelineOptions {
+	return func(t *timelineService) {
+		t.backfillSize = size
+	}
+}
+
+// WithFanOutInterval sets how often new posts and reposts are looked for, which
+// bounds how late they reach the followers' timelines.
+func WithFanOutInterval(interval time.Duration) TimelineOptions {
+	return func(t *timelineService) {
+		t.fanOutInterval = interval
+	}
+}
+
+// WithTimelineStream pushes new posts and reposts to connected clients.
+func WithTimelineStream(stream StreamPublisher) TimelineOptions {
+	return func(t *timelineService) {
+		t.stream = stream
+	}
+}
+
+func WithTimelineLogger(logger *slog.Logger) TimelineOptions {
+	return func(t *timelineService) {
+		t.logger = logger
+	}
+}
+
+// PostCreated writes the post to its author's timeline; Run fans it out to the
+// followers unless the author has too many of them, in which case it is picked
+// up on read.
+func (t *timelineService) PostCreated(ctx context.Context, post *domain.Post) error {
+	if err := t.timelineRepository.Add(ctx, post); err != nil {
+		return err
+	}
+
+	if err := t.stream.PostPublished(ctx, post); err != nil {
+		t.logger.ErrorContext(ctx, "timeline stream failed", "post_id", post.Id, "err", err.Error())
+	}
+	return nil
+}
+
+func (t *timelineService) PostDeleted(ctx context.Context, post *domain.Post) error {
+	return t.timelineRepository.RemovePost(ctx, post.Id)
+}
+
+func (t *timelineService) Followed(ctx context.Context, followerId string, followee *domain.User) error {
+	if t.backfillSize <= 0 {
+		return nil
+	}
+	return t.timelineRepository.Backfill(ctx, followerId, followee.Id, t.backfillSize)
+}
+
+func (t *timelineService) Unfollowed(ctx context.Context, followerId, followeeId string) error {
+	return t.timelineRepository.RemoveAuthor(ctx, followerId, followeeId)
+}
+
+// Reposted brings the reposted post to the top of the reposter's timeline; Run
+// does the same for the followers unless the reposter's reposts are merged in
+// on read.
+func (t *timelineService) Reposted(ctx context.Context, reposter *domain.User, repost *domain.Repost) error {
+	if err := t.timelineRepository.AddRepost(ctx, repost); err != nil {
+		return err
+	}
+
+	if err := t.stream.Reposted(ctx, repost); err != nil {
+		t.logger.ErrorContext(ctx, "timeline stream failed", "post_id", repost.PostId, "err", err.Error())
+	}
+	return nil
+}
+
+func (t *timelineService) Unreposted(ctx context.Context, userId, postId string) error {
+	return t.timelineRepository.RemoveRepost(ctx, userId, postId)
+}
+
+func (t *timelineService) Home(ctx context.Context, userId string, input *dto.PageInput) (*dto.PostListResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	entries, err := t.timelineRepository.ListHome(ctx, userId, input.DecodedCursor(), input.Limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return dto.NewTimelineResponse(entries, input.Limit), nil
+}
+
+func (t *timelineService) Run(ctx context.Context) {
+	ticker := time.NewTicker(t.fanOutInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.fanOut(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// fanOut works through the pending posts and reposts in batches until none is
+// left, then through the accounts that crossed the fan-out threshold. What
+// failed is retried on the next tick.
+func (t *timelineService) fanOut(ctx context.Context) {
+	for ctx.Err() == nil {
+		handled, err := t.timelineRepository.FanOutPending(ctx, timelineFanOutBatch)
+		if err != nil {
+			t.logger.ErrorContext(ctx, "timeline fan-out failed", "err", err.Error())
+			break
+		}
+
+		if handled < timelineFanOutBatch {
+			break
+		}
+	}
+
+	for ctx.Err() == nil {
+		moved, err := t.timelineRepository.Reconcile(ctx, t.fanOutThreshold, t.backfillSize)
+		if err != nil {
+			t.logger.ErrorContext(ctx, "timeline reconciliation failed", "err", err.Error())
+			return
+		}
+
+		if !moved {
+			return
+		}
+	}
+}
+
+func NewTimelineService(timelineRepository repository.TimelineRepository, opts ...TimelineOptions) TimelineService {
+	t := &timelineService{
+		timelineRepository: timelineRepository,
+		fanOutThreshold:    5000,
+		backfillSize:       50,
+		fanOutInterval:     time.Second,
+		stream:             nopStream{},
+		logger:             slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// nopTimeline is used when no timeline is wired in.
+type nopTimeline struct{}
+
+func (nopTimeline) PostCreated(context.Context, *domain.Post) error              { return nil }
+func (nopTimeline) PostDeleted(context.Context, *domain.Post) error              { return nil }
+func (nopTimeline) Followed(context.Context, string, *domain.User) error         { return nil }
+func (nopTimeline) Unfollowed(context.Context, string, string) error             { return nil }
+func (nopTimeline) Reposted(context.Context, *domain.User, *domain.Repost) error { return nil }
+func (nopTimeline) Unreposted(context.Context, string, string) error             { return nil }
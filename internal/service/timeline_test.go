@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTimelineService_PostCreated(t *testing.T) {
+	timelineRepository := &mocks.TimelineRepositoryMock{}
+	post := &domain.Post{Id: postId, AuthorId: authorId, Author: &domain.User{Id: authorId, FollowerCount: 99}}
+	timelineRepository.On("Add", mock.Anything, post).Return(nil)
+
+	service := NewTimelineService(timelineRepository, WithFanOutThreshold(100))
+	require.NoError(t, service.PostCreated(context.Background(), post))
+	timelineRepository.AssertExpectations(t)
+	timelineRepository.AssertNotCalled(t, "FanOutPending")
+}
+
+func TestTimelineService_FanOut(t *testing.T) {
+	t.Run("works through every batch", func(t *testing.T) {
+		t.Parallel()
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+		timelineRepository.On("FanOutPending", mock.Anything, timelineFanOutBatch).Return(timelineFanOutBatch, nil).Once()
+		timelineRepository.On("FanOutPending", mock.Anything, timelineFanOutBatch).Return(3, nil).Once()
+		timelineRepository.On("Reconcile", mock.Anything, 100, 10).Return(true, nil).Once()
+		timelineRepository.On("Reconcile", mock.Anything, 100, 10).Return(false, nil).Once()
+
+		service := NewTimelineService(timelineRepository, WithFanOutThreshold(100), WithBackfillSize(10)).(*timelineService)
+		service.fanOut(context.Background())
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("a failed batch does not hold up the threshold", func(t *testing.T) {
+		t.Parallel()
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+		timelineRepository.On("FanOutPending", mock.Anything, timelineFanOutBatch).Return(0, errors.New("something")).Once()
+		timelineRepository.On("Reconcile", mock.Anything, 100, 50).Return(false, nil).Once()
+
+		service := NewTimelineService(timelineRepository, WithFanOutThreshold(100)).(*timelineService)
+		service.fanOut(context.Background())
+		timelineRepository.AssertExpectations(t)
+	})
+}
+
+func TestTimelineService_Followed(t *testing.T) {
+	t.Run("backfills the followee", func(t *testing.T) {
+		t.Parallel()
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+		timelineRepository.On("Backfill", mock.Anything, authorId, followeeId, 10).Return(nil)
+
+		service := NewTimelineService(timelineRepository, WithFanOutThreshold(100), WithBackfillSize(10))
+		require.NoError(t, service.Followed(context.Background(), authorId, &domain.User{Id: followeeId, FollowerCount: 5}))
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("no backfill when disabled", func(t *testing.T) {
+		t.Parallel()
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		service := NewTimelineService(timelineRepository, WithFanOutThreshold(100), WithBackfillSize(0))
+		require.NoError(t, service.Followed(context.Background(), authorId, &domain.User{Id: followeeId, FollowerCount: 5}))
+		timelineRepository.AssertNotCalled(t, "Backfill")
+	})
+}
+
+func TestTimelineService_Home(t *testing.T) {
+	timelineRepository := &mocks.TimelineRepositoryMock{}
+	repostedAt := time.Now()
+	timelineRepository.On("ListHome", mock.Anything, authorId, (*domain.Cursor)(nil), 2).Return([]*domain.TimelineEntry{
+		{Post: &domain.Post{Id: postId, CreatedAt: repostedAt.Add(-time.Hour)}, RepostedBy: &domain.User{Id: followeeId, Username: "alice"}, CreatedAt: repostedAt},
+		{Post: &domain.Post{Id: followeeId}, CreatedAt: repostedAt.Add(-time.Second)},
+	}, nil)
+
+	service := NewTimelineService(timelineRepository, WithFanOutThreshold(100))
+	res, err := service.Home(context.Background(), authorId, &dto.PageInput{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, res.Posts, 1)
+	require.Equal(t, "alice", res.Posts[0].RepostedBy.Username)
+	require.Equal(t, dto.EncodeCursor(&domain.Cursor{Time: repostedAt, Id: postId}), res.NextCursor)
+}
+
+func TestTimelineService_Reposted(t *testing.T) {
+	timelineRepository := &mocks.TimelineRepositoryMock{}
+	repost := &domain.Repost{UserId: followeeId, PostId: postId, Post: &domain.Post{Id: postId, AuthorId: authorId}}
+	timelineRepository.On("AddRepost", mock.Anything, repost).Return(nil)
+
+	service := NewTimelineService(timelineRepository, WithFanOutThreshold(100))
+	require.NoError(t, service.Reposted(context.Background(), &domain.User{Id: followeeId, FollowerCount: 100}, repost))
+	timelineRepository.AssertExpectations(t)
+}
+
+func TestPostService_TimelineHooks(t *testing.T) {
+	t.Run("fan-out failure does not fail the post", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId}, nil)
+		postRepository.On("Create", mock.Anything, mock.Anything).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+		timelineRepository.On("Add", mock.Anything, mock.Anything).Return(errors.New("something"))
+
+		service := NewPostService(postRepository, userRepository, WithPostTimeline(NewTimelineService(timelineRepository)))
+		res, err := service.Create(context.Background(), authorId, &dto.CreatePostInput{Body: "hello"})
+		require.NoError(t, err)
+		require.Equal(t, postId, res.Id)
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("deleted posts leave timelines", func(t *testing.T) {
+		t.Parallel()
+		postRepository := &mocks.PostRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		postRepository.On("GetById", mock.Anything, authorId, postId).Return(&domain.Post{Id: postId, AuthorId: authorId}, nil)
+		postRepository.On("Delete", mock.Anything, postId).Return(nil)
+		timelineRepository.On("RemovePost", mock.Anything, postId).Return(nil)
+
+		service := NewPostService(postRepository, &mocks.UserRepositoryMock{}, WithPostTimeline(NewTimelineService(timelineRepository)))
+		require.NoError(t, service.Delete(context.Background(), authorId, postId))
+		timelineRepository.AssertExpectations(t)
+	})
+}
+
+func TestFollowService_TimelineHooks(t *testing.T) {
+	t.Run("unfollow removes the author", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		followRepository.On("Unfollow", mock.Anything, authorId, followeeId).Return(true, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{}, nil)
+		timelineRepository.On("RemoveAuthor", mock.Anything, authorId, followeeId).Return(nil)
+
+		service := NewFollowService(followRepository, userRepository, WithFollowTimeline(NewTimelineService(timelineRepository)))
+		_, err := service.Unfollow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		timelineRepository.AssertExpectations(t)
+	})
+
+	t.Run("repeated follow does not backfill again", func(t *testing.T) {
+		t.Parallel()
+		followRepository := &mocks.FollowRepositoryMock{}
+		userRepository := &mocks.UserRepositoryMock{}
+		timelineRepository := &mocks.TimelineRepositoryMock{}
+
+		userRepository.On("GetByUsername", mock.Anything, "alice").Return(&domain.User{Id: followeeId}, nil)
+		followRepository.On("Follow", mock.Anything, authorId, followeeId).Return(domain.FollowUnchanged, nil)
+		followRepository.On("GetRelationship", mock.Anything, authorId, followeeId).Return(&domain.Relationship{Following: true}, nil)
+
+		service := NewFollowService(followRepository, userRepository, WithFollowTimeline(NewTimelineService(timelineRepository)))
+		_, err := service.Follow(context.Background(), authorId, "alice")
+		require.NoError(t, err)
+		timelineRepository.AssertNotCalled(t, "Backfill")
+	})
+}
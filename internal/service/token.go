@@ -0,0 +1,69 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"time"
+)
+
+type TokenService interface {
+	GenerateAccessToken(user *domain.User) (string, error)
+	ParseAccessToken(token string) (string, error)
+}
+
+type tokenService struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func (t *tokenService) GenerateAccessToken(user *domain.User) (string, error) {
+	now := t.now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    t.issuer,
+		Subject:   user.Id,
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(t.ttl)),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(t.secret)
+	if err != nil {
+		return "", fmt.Errorf("error signing access token: %v", err)
+	}
+	return token, nil
+}
+
+// ParseAccessToken verifies the token and returns the id of the user it was issued to.
+func (t *tokenService) ParseAccessToken(token string) (string, error) {
+	claims := &jwt.RegisteredClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (any, error) {
+		return t.secret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(t.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(t.now),
+	)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", customErr.ErrUnauthorized, err)
+	}
+
+	if claims.Subject == "" {
+		return "", fmt.Errorf("%w: %v", customErr.ErrUnauthorized, errors.New("token has no subject"))
+	}
+	return claims.Subject, nil
+}
+
+func NewTokenService(secret, issuer string, ttl time.Duration) TokenService {
+	return &tokenService{
+		secret: []byte(secret),
+		issuer: issuer,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestTokenService(t *testing.T) {
+	user := &domain.User{Id: "123"}
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		token, err := testTokenService.GenerateAccessToken(user)
+		require.NoError(t, err)
+
+		userId, err := testTokenService.ParseAccessToken(token)
+		require.NoError(t, err)
+		require.Equal(t, user.Id, userId)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+		tokenService := NewTokenService("secret", "x", time.Minute).(*tokenService)
+		tokenService.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		token, err := tokenService.GenerateAccessToken(user)
+		require.NoError(t, err)
+
+		_, err = testTokenService.ParseAccessToken(token)
+		require.ErrorIs(t, err, customErr.ErrUnauthorized)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		t.Parallel()
+		token, err := NewTokenService("other", "x", time.Hour).GenerateAccessToken(user)
+		require.NoError(t, err)
+
+		_, err = testTokenService.ParseAccessToken(token)
+		require.ErrorIs(t, err, customErr.ErrUnauthorized)
+	})
+
+	t.Run("unsigned token", func(t *testing.T) {
+		t.Parallel()
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{
+			Issuer:    "x",
+			Subject:   user.Id,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = testTokenService.ParseAccessToken(token)
+		require.ErrorIs(t, err, customErr.ErrUnauthorized)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		t.Parallel()
+		_, err := testTokenService.ParseAccessToken("garbage")
+		require.ErrorIs(t, err, customErr.ErrUnauthorized)
+	})
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/repository"
+)
+
+type UserService interface {
+	GetProfile(ctx context.Context, viewerId, username string) (*dto.ProfileResponse, error)
+	UpdateProfile(ctx context.Context, userId string, input *dto.UpdateProfileInput) (*dto.ProfileResponse, error)
+}
+
+// FollowRequestApprover approves the pending requests to follow an account.
+type FollowRequestApprover interface {
+	ApproveAll(ctx context.Context, userId string) error
+}
+
+type userService struct {
+	userRepository repository.UserRepository
+	followRequests FollowRequestApprover
+}
+
+type UserOptions func(*userService)
+
+func WithUserFollowRequests(followRequests FollowRequestApprover) UserOptions {
+	return func(u *userService) {
+		u.followRequests = followRequests
+	}
+}
+
+// GetProfile looks the user up regardless of case; deactivated accounts and
+// accounts blocked either way by the viewer are not found.
+func (u *userService) GetProfile(ctx context.Context, viewerId, username string) (*dto.ProfileResponse, error) {
+	user, err := u.userRepository.GetProfile(ctx, viewerId, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeleted() {
+		return nil, customErr.ErrNotFound
+	}
+	return dto.NewProfileResponse(user), nil
+}
+
+// UpdateProfile approves the pending requests to follow the user when the user
+// switches to public. Should that fail, setting protected to false again
+// approves them.
+func (u *userService) UpdateProfile(ctx context.Context, userId string, input *dto.UpdateProfileInput) (*dto.ProfileResponse, error) {
+	input.Sanitize()
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepository.UpdateProfile(ctx, userId, input.ProfileUpdate())
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Protected != nil && !*input.Protected {
+		if err := u.followRequests.ApproveAll(ctx, userId); err != nil {
+			return nil, err
+		}
+		if user, err = u.userRepository.GetById(ctx, userId); err != nil {
+			return nil, err
+		}
+	}
+	return dto.NewProfileResponse(user), nil
+}
+
+func NewUserService(userRepository repository.UserRepository, opts ...UserOptions) UserService {
+	u := &userService{
+		userRepository: userRepository,
+		followRequests: nopFollowRequests{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	return u
+}
+
+// nopFollowRequests is used when no approver is wired in.
+type nopFollowRequests struct{}
+
+func (nopFollowRequests) ApproveAll(context.Context, string) error { return nil }
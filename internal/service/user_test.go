@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/domain"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserService_GetProfile(t *testing.T) {
+	deletedAt := time.Now()
+	testCases := []struct {
+		name    string
+		user    *domain.User
+		err     error
+		wantErr error
+	}{
+		{name: "found", user: &domain.User{Id: authorId, Username: "Alice", Bio: "hi", PostCount: 3, FollowerCount: 2}},
+		{name: "unknown", err: customErr.ErrNotFound, wantErr: customErr.ErrNotFound},
+		{name: "deactivated", user: &domain.User{Id: authorId, Username: "Alice", DeletedAt: &deletedAt}, wantErr: customErr.ErrNotFound},
+		{name: "blocked", err: customErr.ErrNotFound, wantErr: customErr.ErrNotFound},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			userRepository := &mocks.UserRepositoryMock{}
+			userRepository.On("GetProfile", mock.Anything, followeeId, "alice").Return(tc.user, tc.err)
+
+			service := NewUserService(userRepository)
+			res, err := service.GetProfile(context.Background(), followeeId, "alice")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, "Alice", res.Username)
+			require.Equal(t, "hi", res.Bio)
+			require.Equal(t, 3, res.PostCount)
+			require.Equal(t, 2, res.FollowerCount)
+		})
+	}
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	t.Run("only the given fields are changed", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		bio, website := "Gopher", ""
+		input, empty := " Gopher ", ""
+		userRepository.On("UpdateProfile", mock.Anything, authorId, &domain.ProfileUpdate{Bio: &bio, Website: &website}).
+			Return(&domain.User{Id: authorId, Username: "alice", Bio: bio}, nil)
+
+		service := NewUserService(userRepository)
+		res, err := service.UpdateProfile(context.Background(), authorId, &dto.UpdateProfileInput{Bio: &input, Website: &empty})
+		require.NoError(t, err)
+		require.Equal(t, "Gopher", res.Bio)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		website := "ftp://example.com"
+
+		service := NewUserService(userRepository)
+		_, err := service.UpdateProfile(context.Background(), authorId, &dto.UpdateProfileInput{Website: &website})
+		require.ErrorIs(t, err, customErr.ErrValidation)
+		userRepository.AssertNotCalled(t, "UpdateProfile")
+	})
+
+	t.Run("going public approves pending requests", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		approver := &requestApprover{}
+		protected := false
+		userRepository.On("UpdateProfile", mock.Anything, authorId, &domain.ProfileUpdate{Protected: &protected}).
+			Return(&domain.User{Id: authorId, Username: "alice", FollowerCount: 1}, nil)
+		userRepository.On("GetById", mock.Anything, authorId).Return(&domain.User{Id: authorId, Username: "alice", FollowerCount: 3}, nil)
+
+		service := NewUserService(userRepository, WithUserFollowRequests(approver))
+		res, err := service.UpdateProfile(context.Background(), authorId, &dto.UpdateProfileInput{Protected: &protected})
+		require.NoError(t, err)
+		require.Equal(t, []string{authorId}, approver.approved)
+		require.Equal(t, 3, res.FollowerCount)
+	})
+
+	t.Run("going protected approves nothing", func(t *testing.T) {
+		t.Parallel()
+		userRepository := &mocks.UserRepositoryMock{}
+		approver := &requestApprover{}
+		protected := true
+		userRepository.On("UpdateProfile", mock.Anything, authorId, &domain.ProfileUpdate{Protected: &protected}).
+			Return(&domain.User{Id: authorId, Username: "alice", Protected: true}, nil)
+
+		service := NewUserService(userRepository, WithUserFollowRequests(approver))
+		res, err := service.UpdateProfile(context.Background(), authorId, &dto.UpdateProfileInput{Protected: &protected})
+		require.NoError(t, err)
+		require.True(t, res.Protected)
+		require.Empty(t, approver.approved)
+	})
+}
+
+// requestApprover records the users whose pending requests were approved.
+type requestApprover struct {
+	approved []string
+}
+
+func (r *requestApprover) ApproveAll(_ context.Context, userId string) error {
+	r.approved = append(r.approved, userId)
+	return nil
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as files below a root directory and serves them itself.
+type LocalStore struct {
+	root    string
+	baseURL string
+}
+
+type LocalOptions func(*LocalStore)
+
+// WithLocalBaseURL sets the URL prefix the blobs are served under, "/blobs" by
+// default.
+func WithLocalBaseURL(baseURL string) LocalOptions {
+	return func(l *LocalStore) {
+		l.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+func (l *LocalStore) path(key string) string {
+	return filepath.Join(l.root, filepath.FromSlash(key))
+}
+
+// Put writes the blob to a temporary file first and renames it into place, so
+// a blob is either missing or complete.
+func (l *LocalStore) Put(ctx context.Context, key, contentType string, body io.Reader) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	path := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *LocalStore) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(l.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, customErr.ErrNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+func (l *LocalStore) Exists(ctx context.Context, key string) (bool, error) {
+	if err := checkKey(key); err != nil {
+		return false, err
+	}
+
+	info, err := os.Stat(l.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	return info.Mode().IsRegular(), nil
+}
+
+func (l *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+
+	if err := os.Remove(l.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *LocalStore) URL(key string) string {
+	return l.baseURL + "/" + key
+}
+
+// Handler serves the blobs. It must be mounted on a pattern ending in
+// {key...}, such as "GET /blobs/{key...}". Blobs never change, so clients may
+// cache them for good.
+func (l *LocalStore) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.PathValue("key")
+		if !ValidKey(key) {
+			http.NotFound(w, r)
+			return
+		}
+
+		file, err := os.Open(l.path(key))
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		defer file.Close()
+
+		info, err := file.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		http.ServeContent(w, r, info.Name(), info.ModTime(), file)
+	})
+}
+
+func NewLocalStore(root string, opts ...LocalOptions) *LocalStore {
+	l := &LocalStore{
+		root:    root,
+		baseURL: "/blobs",
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
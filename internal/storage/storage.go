@@ -0,0 +1,45 @@
+// Package storage keeps blobs such as uploaded media. Keys are slash separated
+// paths like "media/ab/abcdef.../small.jpg"; blobs are written once and never
+// modified, so a key always names the same content and can be cached forever.
+package storage
+
+import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"io"
+	"path"
+	"strings"
+)
+
+// BlobStore is implemented by every storage backend. Open returns
+// customErr.ErrNotFound for a missing key, while deleting one is not an error.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, body io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	Delete(ctx context.Context, key string) error
+	// URL is where clients fetch the blob from.
+	URL(key string) string
+}
+
+// ValidKey reports whether key is a clean relative path that cannot escape the
+// store, such as "media/ab/file.jpg".
+func ValidKey(key string) bool {
+	if key == "" || strings.ContainsAny(key, "\\\x00") || path.IsAbs(key) || path.Clean(key) != key {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func checkKey(key string) error {
+	if !ValidKey(key) {
+		return fmt.Errorf("%w: invalid blob key %q", customErr.ErrValidation, key)
+	}
+	return nil
+}
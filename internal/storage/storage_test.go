@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/stretchr/testify/require"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// testBlobStore checks the behavior every BlobStore must have, so each backend
+// runs the same suite; an S3 compatible store runs it against a local stand-in.
+func testBlobStore(t *testing.T, store BlobStore) {
+	ctx := context.Background()
+	key := "media/ab/abcdef/original.png"
+
+	exists, err := store.Exists(ctx, key)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	_, err = store.Open(ctx, key)
+	require.ErrorIs(t, err, customErr.ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, "image/png", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, key, "image/png", strings.NewReader("second")))
+
+	exists, err = store.Exists(ctx, key)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	body, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.NoError(t, body.Close())
+	require.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key), "deleting a missing blob is not an error")
+	exists, err = store.Exists(ctx, key)
+	require.NoError(t, err)
+	require.False(t, exists)
+
+	for _, key := range []string{"", "/media/a", "../a", "media/../../a", "media//a", "media/a/", "media\\a", "."} {
+		require.ErrorIs(t, store.Put(ctx, key, "image/png", strings.NewReader("x")), customErr.ErrValidation, key)
+	}
+}
+
+func TestLocalStore(t *testing.T) {
+	testBlobStore(t, NewLocalStore(t.TempDir()))
+}
+
+func TestLocalStore_URL(t *testing.T) {
+	require.Equal(t, "/blobs/media/a.png", NewLocalStore(t.TempDir()).URL("media/a.png"))
+	require.Equal(t, "https://cdn.example.com/media/a.png", NewLocalStore(t.TempDir(), WithLocalBaseURL("https://cdn.example.com/")).URL("media/a.png"))
+}
+
+func TestLocalStore_Handler(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	require.NoError(t, store.Put(context.Background(), "media/ab/small.png", "image/png", strings.NewReader("png")))
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /blobs/{key...}", store.Handler())
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+	}{
+		{name: "blob", path: "/blobs/media/ab/small.png", status: http.StatusOK},
+		{name: "missing", path: "/blobs/media/ab/large.png", status: http.StatusNotFound},
+		{name: "directory", path: "/blobs/media/ab", status: http.StatusNotFound},
+		{name: "escape", path: "/blobs/media/%2e%2e/%2e%2e/secret", status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			require.Equal(t, tt.status, rec.Code)
+			if tt.status == http.StatusOK {
+				require.Equal(t, "png", rec.Body.String())
+				require.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+				require.Contains(t, rec.Header().Get("Cache-Control"), "immutable")
+			}
+		})
+	}
+}
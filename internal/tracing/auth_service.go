@@ -0,0 +1,36 @@
+package tracing
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/service"
+)
+
+type authService struct {
+	next service.AuthService
+}
+
+func (a *authService) Register(ctx context.Context, input *dto.AuthenticationInput) (*dto.AuthenticationResponse, error) {
+	ctx, span := tracer().Start(ctx, "AuthService.Register")
+	defer span.End()
+
+	res, err := a.next.Register(ctx, input)
+	recordError(span, err)
+	return res, err
+}
+
+func (a *authService) Login(ctx context.Context, input *dto.Login) (*dto.AuthenticationResponse, error) {
+	ctx, span := tracer().Start(ctx, "AuthService.Login")
+	defer span.End()
+
+	res, err := a.next.Login(ctx, input)
+	recordError(span, err)
+	return res, err
+}
+
+// NewAuthService decorates next so that every call runs in its own span.
+func NewAuthService(next service.AuthService) service.AuthService {
+	return &authService{
+		next: next,
+	}
+}
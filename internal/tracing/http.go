@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+	"strings"
+)
+
+// Middleware starts a server span per request, continuing any trace propagated
+// through the traceparent header, and names it after the matched route.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracer().Start(ctx, r.Method, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("url.path", r.URL.Path),
+			attribute.String("user_agent.original", r.UserAgent()),
+		))
+		defer span.End()
+
+		r, route := middleware.TrackRoute(r.WithContext(ctx))
+		rec := middleware.NewStatusRecorder(w)
+		next.ServeHTTP(rec, r)
+
+		// Patterns registered without a method are prefixed with it for a readable span name.
+		name := route()
+		if !strings.Contains(name, " ") {
+			name = r.Method + " " + name
+		}
+		span.SetName(name)
+		span.SetAttributes(
+			attribute.String("http.route", route()),
+			attribute.Int("http.response.status_code", rec.Status),
+		)
+		if rec.Status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.Status))
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"database/sql"
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// OpenDB opens a database whose driver emits a span for every statement with its
+// query text, the rows it returned and any error.
+func OpenDB(driverName, dsn string) (*sql.DB, error) {
+	return otelsql.Open(driverName, dsn,
+		otelsql.WithAttributes(attribute.String("db.system", "postgresql")),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			RowsNext:             true,
+			OmitConnResetSession: true,
+			OmitConnPrepare:      true,
+		}),
+	)
+}
@@ -0,0 +1,158 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"os"
+)
+
+const instrumentationName = "github.com/saleh-ghazimoradi/X"
+
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+type Provider struct {
+	ServiceName    string
+	ServiceVersion string
+	Exporter       string
+	Endpoint       string
+	Insecure       bool
+	SampleRatio    float64
+	SpanExporter   sdktrace.SpanExporter
+}
+
+type Options func(*Provider)
+
+func WithServiceName(name string) Options {
+	return func(p *Provider) {
+		p.ServiceName = name
+	}
+}
+
+func WithServiceVersion(version string) Options {
+	return func(p *Provider) {
+		p.ServiceVersion = version
+	}
+}
+
+// WithExporter selects the exporter by name: none, stdout or otlp.
+func WithExporter(exporter string) Options {
+	return func(p *Provider) {
+		p.Exporter = exporter
+	}
+}
+
+// WithEndpoint sets the OTLP/HTTP collector endpoint (host:port). When empty the
+// exporter falls back to the standard OTEL_EXPORTER_OTLP_* environment variables.
+func WithEndpoint(endpoint string, insecure bool) Options {
+	return func(p *Provider) {
+		p.Endpoint = endpoint
+		p.Insecure = insecure
+	}
+}
+
+func WithSampleRatio(ratio float64) Options {
+	return func(p *Provider) {
+		p.SampleRatio = ratio
+	}
+}
+
+// WithSpanExporter uses the given exporter instead of building one by name, e.g.
+// tracetest.NewInMemoryExporter in tests.
+func WithSpanExporter(exporter sdktrace.SpanExporter) Options {
+	return func(p *Provider) {
+		p.SpanExporter = exporter
+	}
+}
+
+func (p *Provider) exporter(ctx context.Context) (sdktrace.SpanExporter, error) {
+	if p.SpanExporter != nil {
+		return p.SpanExporter, nil
+	}
+
+	switch p.Exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterOTLP:
+		var opts []otlptracehttp.Option
+		if p.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(p.Endpoint))
+		}
+		if p.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", p.Exporter)
+	}
+}
+
+// Start builds the tracer provider and installs it, along with the W3C trace
+// context and baggage propagators, as the global default. The returned function
+// flushes pending spans and must be called on shutdown.
+func (p *Provider) Start(ctx context.Context) (func(context.Context) error, error) {
+	exporter, err := p.exporter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", p.ServiceName),
+		attribute.String("service.version", p.ServiceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(p.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	provider := sdktrace.NewTracerProvider(opts...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider.Shutdown, nil
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// recordError marks the span as failed with err, if any.
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+func New(opts ...Options) *Provider {
+	p := &Provider{
+		ServiceName: "x",
+		Exporter:    ExporterNone,
+		SampleRatio: 1,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
@@ -0,0 +1,69 @@
+package tracing
+
+import (
+	"context"
+	"github.com/saleh-ghazimoradi/X/internal/customErr"
+	"github.com/saleh-ghazimoradi/X/internal/dto"
+	"github.com/saleh-ghazimoradi/X/internal/middleware"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newExporter(t *testing.T) *tracetest.InMemoryExporter {
+	exporter := tracetest.NewInMemoryExporter()
+	shutdown, err := New(WithSpanExporter(exporter)).Start(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = shutdown(context.Background())
+	})
+	return exporter
+}
+
+type failingAuthService struct{}
+
+func (failingAuthService) Register(ctx context.Context, input *dto.AuthenticationInput) (*dto.AuthenticationResponse, error) {
+	return nil, customErr.ErrUserNameTaken
+}
+
+func (failingAuthService) Login(ctx context.Context, input *dto.Login) (*dto.AuthenticationResponse, error) {
+	return nil, customErr.ErrBadCredential
+}
+
+func TestMiddleware(t *testing.T) {
+	exporter := newExporter(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/users/{username}", func(w http.ResponseWriter, r *http.Request) {
+		NewAuthService(failingAuthService{}).Login(r.Context(), &dto.Login{})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/users/bob", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	Middleware(middleware.CaptureRoute(mux)).ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NoError(t, providerFlush(t))
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+
+	login, server := spans[0], spans[1]
+	require.Equal(t, "AuthService.Login", login.Name)
+	require.Equal(t, codes.Error, login.Status.Code)
+	require.Equal(t, server.SpanContext.SpanID(), login.Parent.SpanID())
+
+	require.Equal(t, "GET /v1/users/{username}", server.Name)
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+	require.True(t, server.Parent.IsRemote())
+}
+
+func providerFlush(t *testing.T) error {
+	t.Helper()
+	if flusher, ok := otel.GetTracerProvider().(interface{ ForceFlush(context.Context) error }); ok {
+		return flusher.ForceFlush(context.Background())
+	}
+	return nil
+}
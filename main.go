@@ -1,62 +1,57 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"github.com/saleh-ghazimoradi/X/cmd"
 	"github.com/saleh-ghazimoradi/X/config"
-	"github.com/saleh-ghazimoradi/X/migrations"
-	"github.com/saleh-ghazimoradi/X/utils"
+	"github.com/saleh-ghazimoradi/X/internal/logger"
 	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	bootstrapLogger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
 	cfg, err := config.NewConfig()
 	if err != nil {
-		logger.Error("failed to load config", "err", err.Error())
+		bootstrapLogger.Error("failed to load config", "err", err.Error())
 		os.Exit(1)
 	}
 
-	postgresql := utils.NewPostgresql(
-		utils.WithHost(cfg.Postgresql.Host),
-		utils.WithPort(cfg.Postgresql.Port),
-		utils.WithUser(cfg.Postgresql.User),
-		utils.WithPassword(cfg.Postgresql.Password),
-		utils.WithName(cfg.Postgresql.Name),
-		utils.WithTimeout(cfg.Postgresql.Timeout),
-		utils.WithSSLMode(cfg.Postgresql.SSLMode),
-		utils.WithMaxOpenConn(cfg.Postgresql.MaxOpenConn),
-		utils.WithMaxIdleTime(cfg.Postgresql.MaxIdleTime),
-		utils.WithMaxIdleConn(cfg.Postgresql.MaxIdleConn),
+	log, err := logger.New(
+		logger.WithLevel(cfg.Logger.Level),
+		logger.WithFormat(cfg.Logger.Format),
+		logger.WithHashEmails(cfg.Logger.HashEmails),
 	)
-
-	postgresqlDB, err := postgresql.Connect()
 	if err != nil {
-		logger.Error("failed to connect to postgresql", "err", err.Error())
+		bootstrapLogger.Error("failed to create logger", "err", err.Error())
 		os.Exit(1)
 	}
 
-	defer func() {
-		if err := postgresqlDB.Close(); err != nil {
-			logger.Error(err.Error())
-		}
-	}()
+	command := "http"
+	if len(os.Args) > 1 {
+		command = os.Args[1]
+	}
 
-	migrate, err := migrations.NewMigrate(postgresqlDB, postgresql.Name)
-	if err != nil {
-		logger.Error("failed to load migrations", "err", err.Error())
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	switch command {
+	case "http":
+		err = cmd.HTTP(ctx, cfg, log)
+	case "migrateUp":
+		err = cmd.MigrateUp(ctx, cfg, log)
+	case "migrateDown":
+		err = cmd.MigrateDown(ctx, cfg, log)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
 	}
 
-	if err := migrate.UP(); err != nil {
-		logger.Error("failed to up migrations", "err", err.Error())
+	if err != nil {
+		log.Error("command failed", "command", command, "err", err.Error())
+		stop()
 		os.Exit(1)
 	}
-
-	defer func() {
-		if err := migrate.Close(); err != nil {
-			logger.Error(err.Error())
-			os.Exit(1)
-		}
-	}()
-
 }
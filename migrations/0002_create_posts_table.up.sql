@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS posts (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    author_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS posts_author_id_created_at_idx ON posts (author_id, created_at DESC, id DESC) WHERE deleted_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS posts_conversation_id_idx;
+DROP INDEX IF EXISTS posts_parent_id_created_at_idx;
+
+ALTER TABLE posts
+    DROP COLUMN IF EXISTS depth,
+    DROP COLUMN IF EXISTS conversation_id,
+    DROP COLUMN IF EXISTS parent_id;
@@ -0,0 +1,11 @@
+ALTER TABLE posts
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES posts (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS conversation_id UUID,
+    ADD COLUMN IF NOT EXISTS depth INT NOT NULL DEFAULT 0;
+
+UPDATE posts SET conversation_id = id WHERE conversation_id IS NULL;
+
+ALTER TABLE posts ALTER COLUMN conversation_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS posts_parent_id_created_at_idx ON posts (parent_id, created_at, id) WHERE parent_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS posts_conversation_id_idx ON posts (conversation_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS follows;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS following_count,
+    DROP COLUMN IF EXISTS follower_count;
@@ -0,0 +1,15 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS follower_count INT NOT NULL DEFAULT 0 CHECK (follower_count >= 0),
+    ADD COLUMN IF NOT EXISTS following_count INT NOT NULL DEFAULT 0 CHECK (following_count >= 0),
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    followee_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS follows_follower_id_created_at_idx ON follows (follower_id, created_at DESC, followee_id DESC);
+CREATE INDEX IF NOT EXISTS follows_followee_id_created_at_idx ON follows (followee_id, created_at DESC, follower_id DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS timeline_entries (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS timeline_entries_user_id_created_at_idx ON timeline_entries (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS timeline_entries_user_id_author_id_idx ON timeline_entries (user_id, author_id);
+CREATE INDEX IF NOT EXISTS timeline_entries_post_id_idx ON timeline_entries (post_id);
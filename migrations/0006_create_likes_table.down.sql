@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS post_like_counts;
+DROP TABLE IF EXISTS likes;
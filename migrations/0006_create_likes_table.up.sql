@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS likes (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS likes_post_id_created_at_idx ON likes (post_id, created_at DESC, user_id DESC);
+CREATE INDEX IF NOT EXISTS likes_user_id_created_at_idx ON likes (user_id, created_at DESC, post_id DESC);
+
+-- Like counts are spread over several rows per post so concurrent likes of a
+-- popular post do not all wait on the same row lock. A post's count is the sum
+-- of its shards; a single shard may go negative.
+CREATE TABLE IF NOT EXISTS post_like_counts (
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    shard SMALLINT NOT NULL,
+    count BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, shard)
+);
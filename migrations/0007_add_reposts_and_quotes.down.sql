@@ -0,0 +1,7 @@
+ALTER TABLE timeline_entries DROP COLUMN IF EXISTS reposter_id;
+
+DROP TABLE IF EXISTS reposts;
+
+DROP INDEX IF EXISTS posts_quote_id_idx;
+
+ALTER TABLE posts DROP COLUMN IF EXISTS quote_id;
@@ -0,0 +1,17 @@
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS quote_id UUID REFERENCES posts (id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS posts_quote_id_idx ON posts (quote_id) WHERE quote_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS reposts (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS reposts_user_id_created_at_idx ON reposts (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS reposts_post_id_idx ON reposts (post_id);
+
+-- A timeline holds a post at most once; reposter_id tells who brought it in
+-- when it arrived through a repost.
+ALTER TABLE timeline_entries ADD COLUMN IF NOT EXISTS reposter_id UUID REFERENCES users (id) ON DELETE CASCADE;
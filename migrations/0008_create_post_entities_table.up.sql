@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS post_entities (
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL,
+    start_offset INT NOT NULL,
+    end_offset INT NOT NULL,
+    text TEXT NOT NULL,
+    user_id UUID REFERENCES users (id) ON DELETE SET NULL,
+    PRIMARY KEY (post_id, start_offset)
+);
+
+CREATE INDEX IF NOT EXISTS post_entities_hashtag_idx ON post_entities (LOWER(text)) WHERE type = 'hashtag';
+CREATE INDEX IF NOT EXISTS post_entities_user_id_idx ON post_entities (user_id) WHERE user_id IS NOT NULL;
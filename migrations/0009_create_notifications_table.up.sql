@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS notifications (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL,
+    post_id UUID REFERENCES posts (id) ON DELETE CASCADE,
+    group_key TEXT NOT NULL,
+    actor_count INT NOT NULL DEFAULT 0,
+    read_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Events join the unread notification of their group; once it is read the next
+-- event starts a new one.
+CREATE UNIQUE INDEX IF NOT EXISTS notifications_unread_group_idx ON notifications (user_id, group_key) WHERE read_at IS NULL;
+CREATE INDEX IF NOT EXISTS notifications_user_id_updated_at_idx ON notifications (user_id, updated_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS notification_actors (
+    notification_id UUID NOT NULL REFERENCES notifications (id) ON DELETE CASCADE,
+    actor_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (notification_id, actor_id)
+);
+
+CREATE TABLE IF NOT EXISTS notification_mutes (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL,
+    PRIMARY KEY (user_id, type)
+);
@@ -0,0 +1,16 @@
+-- stream_events backs the real-time stream: every event is stored so clients can
+-- resume from the last event they saw. An event either goes to one user or is
+-- broadcast to the followers of an author.
+CREATE TABLE IF NOT EXISTS stream_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id UUID REFERENCES users (id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users (id) ON DELETE CASCADE,
+    type VARCHAR(16) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (num_nonnulls(user_id, author_id) = 1)
+);
+
+CREATE INDEX IF NOT EXISTS stream_events_user_id_idx ON stream_events (user_id, id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS stream_events_author_id_idx ON stream_events (author_id, id) WHERE author_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS stream_events_created_at_idx ON stream_events (created_at);
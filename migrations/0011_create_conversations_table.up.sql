@@ -0,0 +1,37 @@
+-- Who may start a conversation with the user: everyone, their followers or nobody.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS message_privacy VARCHAR(16) NOT NULL DEFAULT 'everyone';
+
+-- A one-to-one conversation has a direct key made of both participant ids, so
+-- the same two users always share one conversation. Groups have none.
+CREATE TABLE IF NOT EXISTS conversations (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    direct_key TEXT UNIQUE,
+    created_by UUID REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- The read cursor is the last message the participant read and when they read
+-- it. A participant who deleted the conversation no longer sees the messages
+-- sent before cleared_at.
+CREATE TABLE IF NOT EXISTS conversation_participants (
+    conversation_id UUID NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    last_read_message_id UUID,
+    read_at TIMESTAMPTZ,
+    cleared_at TIMESTAMPTZ,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (conversation_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS conversation_participants_user_id_idx ON conversation_participants (user_id);
+
+CREATE TABLE IF NOT EXISTS messages (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    conversation_id UUID NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    sender_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS messages_conversation_id_created_at_idx ON messages (conversation_id, created_at DESC, id DESC);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS blocks (
+    blocker_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    blocked_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (blocker_id, blocked_id),
+    CHECK (blocker_id <> blocked_id)
+);
+
+CREATE INDEX IF NOT EXISTS blocks_blocker_id_created_at_idx ON blocks (blocker_id, created_at DESC, blocked_id DESC);
+CREATE INDEX IF NOT EXISTS blocks_blocked_id_idx ON blocks (blocked_id, blocker_id);
+
+CREATE TABLE IF NOT EXISTS mutes (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    muted_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, muted_id),
+    CHECK (user_id <> muted_id)
+);
+
+CREATE INDEX IF NOT EXISTS mutes_user_id_created_at_idx ON mutes (user_id, created_at DESC, muted_id DESC);
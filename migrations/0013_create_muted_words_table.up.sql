@@ -0,0 +1,14 @@
+-- A muted word hides the posts containing it from the scopes it applies to
+-- until it expires. The pattern is the phrase compiled to a case-insensitive
+-- regular expression matching whole words only; posts are matched against it
+-- when read, so adding or removing a word applies to existing posts at once.
+CREATE TABLE IF NOT EXISTS muted_words (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    phrase TEXT NOT NULL,
+    pattern TEXT NOT NULL,
+    scopes VARCHAR(16)[] NOT NULL,
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, phrase)
+);
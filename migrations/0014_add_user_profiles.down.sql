@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS users_username_lower_idx;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS post_count,
+    DROP COLUMN IF EXISTS banner_url,
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS website,
+    DROP COLUMN IF EXISTS location,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS display_name;
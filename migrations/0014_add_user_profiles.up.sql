@@ -0,0 +1,30 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS display_name VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS bio TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS location VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS website TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS avatar_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS banner_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS post_count INT NOT NULL DEFAULT 0 CHECK (post_count >= 0);
+
+-- post_count is kept up to date by the post repository from now on; count the
+-- posts written so far.
+UPDATE users u SET post_count = c.count
+FROM (SELECT author_id, COUNT(*) AS count FROM posts WHERE deleted_at IS NULL GROUP BY author_id) c
+WHERE u.id = c.author_id;
+
+-- Usernames are looked up case-insensitively, so they must also be unique
+-- regardless of case. Until now they were only unique as typed, so @Alice and
+-- @alice may both exist: the account created first keeps its username, and the
+-- others get an underscore and their id appended, e.g. alice_3f0c…, which no
+-- other account can hold; the username is cut short when needed to stay within
+-- the column. They still sign in with their email, and their former username
+-- now leads to the account that kept it.
+UPDATE users u SET username = LEFT(u.username, 222) || '_' || REPLACE(u.id::text, '-', '')
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(username) ORDER BY created_at, id) AS rank
+    FROM users
+) r
+WHERE u.id = r.id AND r.rank > 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_username_lower_idx ON users (LOWER(username));
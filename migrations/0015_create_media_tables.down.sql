@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS media;
+DROP TABLE IF EXISTS media_blobs;
@@ -0,0 +1,32 @@
+-- An uploaded image is stored once per distinct content, keyed by the SHA-256
+-- of the uploaded bytes, however many times it is uploaded. variants lists the
+-- stored renditions with their blob keys and URLs, the original first.
+-- used_at is bumped whenever an upload reuses the blob, so the cleanup of
+-- unused blobs never races an upload about to reference it.
+CREATE TABLE IF NOT EXISTS media_blobs (
+    hash CHAR(64) PRIMARY KEY NOT NULL,
+    content_type VARCHAR(32) NOT NULL,
+    width INT NOT NULL,
+    height INT NOT NULL,
+    size BIGINT NOT NULL,
+    blurhash TEXT NOT NULL,
+    variants JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- A media row is one upload by a user. It is attached to at most one post;
+-- uploads still unattached after a while are removed.
+CREATE TABLE IF NOT EXISTS media (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    hash CHAR(64) NOT NULL REFERENCES media_blobs (hash),
+    post_id UUID REFERENCES posts (id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL DEFAULT 0,
+    alt_text TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS media_post_id_idx ON media (post_id, position) WHERE post_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS media_hash_idx ON media (hash);
+CREATE INDEX IF NOT EXISTS media_orphans_idx ON media (created_at) WHERE post_id IS NULL;
@@ -0,0 +1,13 @@
+-- The posts of a protected account are only shown to its followers, and
+-- following it takes a request the account approves.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS protected BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE IF NOT EXISTS follow_requests (
+    requester_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    target_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (requester_id, target_id),
+    CHECK (requester_id <> target_id)
+);
+
+CREATE INDEX IF NOT EXISTS follow_requests_target_id_idx ON follow_requests (target_id, created_at DESC, requester_id DESC);
@@ -0,0 +1,23 @@
+-- Bookmarks are private to the user who saved them and are never counted. A
+-- bookmark may be filed in one of the user's folders; deleting the folder keeps
+-- its bookmarks, unfiled.
+CREATE TABLE IF NOT EXISTS bookmark_folders (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookmark_folders_user_id_name_idx ON bookmark_folders (user_id, LOWER(name));
+
+CREATE TABLE IF NOT EXISTS bookmarks (
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    post_id UUID NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    folder_id UUID REFERENCES bookmark_folders (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, post_id)
+);
+
+CREATE INDEX IF NOT EXISTS bookmarks_user_id_created_at_idx ON bookmarks (user_id, created_at DESC, post_id DESC);
+CREATE INDEX IF NOT EXISTS bookmarks_folder_id_created_at_idx ON bookmarks (folder_id, created_at DESC, post_id DESC) WHERE folder_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS bookmarks_post_id_idx ON bookmarks (post_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS list_subscribers;
+
+DROP TABLE IF EXISTS list_members;
+
+DROP TABLE IF EXISTS lists;
@@ -0,0 +1,36 @@
+-- A list gathers accounts chosen by its owner into a timeline of their posts.
+-- Private lists are only seen by their owner, so only public lists have
+-- subscribers. Member and subscriber counts are kept on the list row.
+CREATE TABLE IF NOT EXISTS lists (
+    id UUID PRIMARY KEY NOT NULL DEFAULT uuid_generate_v1(),
+    owner_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    private BOOLEAN NOT NULL DEFAULT FALSE,
+    member_count INTEGER NOT NULL DEFAULT 0,
+    subscriber_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS lists_owner_id_created_at_idx ON lists (owner_id, created_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS list_members (
+    list_id UUID NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS list_members_list_id_created_at_idx ON list_members (list_id, created_at DESC, user_id DESC);
+CREATE INDEX IF NOT EXISTS list_members_user_id_idx ON list_members (user_id);
+
+CREATE TABLE IF NOT EXISTS list_subscribers (
+    list_id UUID NOT NULL REFERENCES lists (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (list_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS list_subscribers_list_id_created_at_idx ON list_subscribers (list_id, created_at DESC, user_id DESC);
+CREATE INDEX IF NOT EXISTS list_subscribers_user_id_created_at_idx ON list_subscribers (user_id, created_at DESC, list_id DESC);
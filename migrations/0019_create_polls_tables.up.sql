@@ -0,0 +1,33 @@
+-- A poll is attached to the post asking it and closes at closes_at. Vote counts
+-- are kept on the poll and its options while it is open; finalized_at is set
+-- once the finalizer recounted them from the votes and notified the author and
+-- the voters.
+CREATE TABLE IF NOT EXISTS polls (
+    post_id UUID PRIMARY KEY NOT NULL REFERENCES posts (id) ON DELETE CASCADE,
+    vote_count INT NOT NULL DEFAULT 0,
+    closes_at TIMESTAMPTZ NOT NULL,
+    finalized_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS polls_unfinalized_closes_at_idx ON polls (closes_at) WHERE finalized_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS poll_options (
+    post_id UUID NOT NULL REFERENCES polls (post_id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL CHECK (position >= 0),
+    text TEXT NOT NULL,
+    vote_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, position)
+);
+
+-- The primary key is what allows a single vote per user and poll.
+CREATE TABLE IF NOT EXISTS poll_votes (
+    post_id UUID NOT NULL REFERENCES polls (post_id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id),
+    FOREIGN KEY (post_id, position) REFERENCES poll_options (post_id, position) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS poll_votes_user_id_idx ON poll_votes (user_id);
@@ -0,0 +1,8 @@
+DELETE FROM timeline_entries t
+USING timeline_entries o
+WHERE o.user_id = t.user_id AND o.post_id = t.post_id
+AND (o.created_at, COALESCE(o.reposter_id::text, '')) < (t.created_at, COALESCE(t.reposter_id::text, ''));
+
+DROP INDEX IF EXISTS timeline_entries_user_id_post_id_reposter_id_idx;
+
+ALTER TABLE timeline_entries ADD PRIMARY KEY (user_id, post_id);
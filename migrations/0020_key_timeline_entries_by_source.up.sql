@@ -0,0 +1,7 @@
+-- A timeline holds one entry per way a post reached it: the author's own post
+-- and each followed reposter's repost. Reads list the earliest of them, so
+-- removing one source leaves the post in place as long as another remains.
+ALTER TABLE timeline_entries DROP CONSTRAINT IF EXISTS timeline_entries_pkey;
+
+CREATE UNIQUE INDEX IF NOT EXISTS timeline_entries_user_id_post_id_reposter_id_idx
+    ON timeline_entries (user_id, post_id, COALESCE(reposter_id, '00000000-0000-0000-0000-000000000000'::uuid));
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS polls_unannounced_finalized_at_idx;
+
+ALTER TABLE polls DROP COLUMN IF EXISTS announced_at;
+ALTER TABLE polls DROP COLUMN IF EXISTS announced_through;
@@ -0,0 +1,11 @@
+-- The "poll closed" notifications of a finalized poll are stored a page of
+-- voters at a time, in the transaction that records the progress:
+-- announced_through is the last voter notified, in id order, and announced_at
+-- is set once every voter was.
+ALTER TABLE polls ADD COLUMN IF NOT EXISTS announced_through UUID;
+ALTER TABLE polls ADD COLUMN IF NOT EXISTS announced_at TIMESTAMPTZ;
+
+-- Polls finalized until now were announced through the notification queue.
+UPDATE polls SET announced_at = finalized_at WHERE finalized_at IS NOT NULL AND announced_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS polls_unannounced_finalized_at_idx ON polls (finalized_at) WHERE finalized_at IS NOT NULL AND announced_at IS NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE muted_words ADD COLUMN IF NOT EXISTS pattern TEXT;
+
+UPDATE muted_words SET pattern = '(^|[^[:alnum:]_])'
+    || REPLACE(REGEXP_REPLACE(phrase, '([\\.+*?()|[\]{}^$])', '\\\1', 'g'), ' ', '\s+')
+    || '($|[^[:alnum:]_])';
+
+ALTER TABLE muted_words ALTER COLUMN pattern SET NOT NULL;
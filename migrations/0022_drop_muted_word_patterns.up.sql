@@ -0,0 +1,3 @@
+-- Muted phrases are compiled to their pattern when posts are read, so how they
+-- match can change without rewriting the stored words.
+ALTER TABLE muted_words DROP COLUMN IF EXISTS pattern;
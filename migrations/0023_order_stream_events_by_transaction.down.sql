@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS stream_events_xact_id_idx;
+DROP INDEX IF EXISTS stream_events_author_id_xact_id_idx;
+DROP INDEX IF EXISTS stream_events_user_id_xact_id_idx;
+
+CREATE INDEX IF NOT EXISTS stream_events_user_id_idx ON stream_events (user_id, id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS stream_events_author_id_idx ON stream_events (author_id, id) WHERE author_id IS NOT NULL;
+
+ALTER TABLE stream_events DROP COLUMN IF EXISTS xact_id;
@@ -0,0 +1,14 @@
+-- Event ids are handed out before the publishing transaction commits, so they
+-- may become visible out of order. Readers therefore follow the order of the
+-- publishing transactions, xact_id then id, and only read events published by
+-- transactions older than every transaction still running, which can no longer
+-- be overtaken. Events published so far share the id of this migration's
+-- transaction and keep their id order.
+ALTER TABLE stream_events ADD COLUMN IF NOT EXISTS xact_id XID8 NOT NULL DEFAULT pg_current_xact_id();
+
+DROP INDEX IF EXISTS stream_events_user_id_idx;
+DROP INDEX IF EXISTS stream_events_author_id_idx;
+
+CREATE INDEX IF NOT EXISTS stream_events_user_id_xact_id_idx ON stream_events (user_id, xact_id, id) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS stream_events_author_id_xact_id_idx ON stream_events (author_id, xact_id, id) WHERE author_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS stream_events_xact_id_idx ON stream_events (xact_id, id);
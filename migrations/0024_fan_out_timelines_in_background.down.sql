@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS users_pushed_follower_count_idx;
+DROP INDEX IF EXISTS users_pulled_follower_count_idx;
+
+ALTER TABLE users DROP COLUMN IF EXISTS timeline_pulled;
+
+DROP INDEX IF EXISTS reposts_pending_fan_out_idx;
+DROP INDEX IF EXISTS posts_pending_fan_out_idx;
+
+ALTER TABLE reposts DROP COLUMN IF EXISTS fanned_out_at;
+ALTER TABLE posts DROP COLUMN IF EXISTS fanned_out_at;
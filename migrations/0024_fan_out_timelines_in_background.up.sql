@@ -0,0 +1,24 @@
+-- Posts and reposts reach their followers' timelines through a background
+-- worker; fanned_out_at is set once one was written to them, or was left to be
+-- merged on read. Rows existing so far were fanned out when they were created:
+-- they get the time of this migration through the column default, which is then
+-- dropped so that new rows start out pending.
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS fanned_out_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE posts ALTER COLUMN fanned_out_at DROP DEFAULT;
+ALTER TABLE reposts ADD COLUMN IF NOT EXISTS fanned_out_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE reposts ALTER COLUMN fanned_out_at DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS posts_pending_fan_out_idx ON posts (created_at, id) WHERE fanned_out_at IS NULL;
+CREATE INDEX IF NOT EXISTS reposts_pending_fan_out_idx ON reposts (created_at, user_id, post_id) WHERE fanned_out_at IS NULL;
+
+-- timeline_pulled tells that an account's posts and reposts are merged into
+-- home timelines on read instead of being fanned out. The worker sets it for
+-- accounts reaching the fan-out threshold and, for accounts falling below it,
+-- backfills their followers' timelines before clearing it. It starts out from
+-- the default threshold; the worker corrects it on its first run when another
+-- one is configured.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS timeline_pulled BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE users SET timeline_pulled = TRUE WHERE follower_count >= 5000;
+
+CREATE INDEX IF NOT EXISTS users_pulled_follower_count_idx ON users (follower_count) WHERE timeline_pulled;
+CREATE INDEX IF NOT EXISTS users_pushed_follower_count_idx ON users (follower_count) WHERE NOT timeline_pulled;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS notifications_user_id_created_at_idx;
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_updated_at_idx ON notifications (user_id, updated_at DESC, id DESC);
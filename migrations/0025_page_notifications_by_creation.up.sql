@@ -0,0 +1,6 @@
+-- Notifications are paged by when they were created, which never changes,
+-- rather than by when they last got an actor, so that a group joined while
+-- the inbox is being paged is neither skipped nor listed twice.
+DROP INDEX IF EXISTS notifications_user_id_updated_at_idx;
+
+CREATE INDEX IF NOT EXISTS notifications_user_id_created_at_idx ON notifications (user_id, created_at DESC, id DESC);
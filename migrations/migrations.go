@@ -1,6 +1,7 @@
 package migrations
 
 import (
+	"context"
 	"database/sql"
 	"embed"
 	"errors"
@@ -9,6 +10,8 @@ import (
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"log/slog"
+	"strings"
 )
 
 //go:embed *.sql
@@ -18,13 +21,31 @@ type Migrate struct {
 	db        *sql.DB
 	dbName    string
 	migration *migrate.Migrate
+	logger    *slog.Logger
+}
+
+// migrateLogger adapts slog to the logger interface of golang-migrate.
+type migrateLogger struct {
+	logger *slog.Logger
+}
+
+func (l *migrateLogger) Printf(format string, v ...any) {
+	l.logger.Debug(strings.TrimSpace(fmt.Sprintf(format, v...)))
+}
+
+func (l *migrateLogger) Verbose() bool {
+	return false
 }
 
 func (m *Migrate) UP() error {
 	if err := m.migration.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
-	fmt.Println("Migrations applied successfully")
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	m.logger.Info("migrations applied successfully", "version", version, "dirty", dirty)
 	return nil
 }
 
@@ -32,10 +53,44 @@ func (m *Migrate) Rollback() error {
 	if err := m.migration.Steps(-1); err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		return fmt.Errorf("failed to apply migrations: %w", err)
 	}
-	fmt.Println("Last migration rolled back successfully")
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	m.logger.Info("last migration rolled back successfully", "version", version, "dirty", dirty)
 	return nil
 }
 
+// Version returns the currently applied migration version and whether it is dirty.
+// A database without any applied migration reports version 0.
+func (m *Migrate) Version() (uint, bool, error) {
+	version, dirty, err := m.migration.Version()
+	if err != nil {
+		if errors.Is(err, migrate.ErrNilVersion) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// SchemaVersion reads the applied migration version and whether it is dirty
+// through db, without holding a connection like Migrate does. A database
+// without any applied migration reports version 0.
+func SchemaVersion(ctx context.Context, db *sql.DB) (uint, bool, error) {
+	var (
+		version uint
+		dirty   bool
+	)
+	if err := db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations`).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("failed to read migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
 func (m *Migrate) Close() error {
 	if m.migration != nil {
 		source, driver := m.migration.Close()
@@ -49,25 +104,39 @@ func (m *Migrate) Close() error {
 	return nil
 }
 
-func NewMigrate(db *sql.DB, dbName string) (*Migrate, error) {
-	driver, err := postgres.WithInstance(db, &postgres.Config{})
+// NewMigrate takes a connection of its own from db for as long as it is open.
+// Closing it gives the connection back and leaves db open.
+func NewMigrate(db *sql.DB, dbName string, logger *slog.Logger) (*Migrate, error) {
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration driver: %w", err)
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
 	if err != nil {
+		conn.Close()
 		return nil, fmt.Errorf("failed to create migration driver: %w", err)
 	}
 
 	source, err := iofs.New(migrationsFs, ".")
 	if err != nil {
+		driver.Close()
 		return nil, fmt.Errorf("failed to load migration files: %w", err)
 	}
 
 	m, err := migrate.NewWithInstance("iofs", source, dbName, driver)
 	if err != nil {
+		driver.Close()
 		return nil, fmt.Errorf("failed to initialize migrate: %w", err)
 	}
 
+	m.Log = &migrateLogger{logger: logger}
+
 	return &Migrate{
 		db:        db,
 		dbName:    dbName,
 		migration: m,
+		logger:    logger,
 	}, nil
 }
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff computes exponentially growing delays with random jitter.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	// Jitter is the fraction (0..1) by which each delay is randomly spread around its nominal value.
+	Jitter float64
+}
+
+// Duration returns the delay to wait before the given retry attempt, starting at 1.
+func (b Backoff) Duration(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(attempt-1))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	if jitter := math.Min(math.Max(b.Jitter, 0), 1); jitter > 0 {
+		delay = delay * (1 - jitter + 2*jitter*rand.Float64())
+		if b.Max > 0 && delay > float64(b.Max) {
+			delay = float64(b.Max)
+		}
+	}
+
+	return time.Duration(delay)
+}
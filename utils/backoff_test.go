@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestBackoff_Duration(t *testing.T) {
+	t.Run("grows exponentially up to max", func(t *testing.T) {
+		b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+		require.Equal(t, 100*time.Millisecond, b.Duration(1))
+		require.Equal(t, 200*time.Millisecond, b.Duration(2))
+		require.Equal(t, 400*time.Millisecond, b.Duration(3))
+		require.Equal(t, time.Second, b.Duration(10))
+	})
+
+	t.Run("jitter stays within bounds", func(t *testing.T) {
+		b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2, Jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			d := b.Duration(2)
+			require.GreaterOrEqual(t, d, 100*time.Millisecond)
+			require.LessOrEqual(t, d, 300*time.Millisecond)
+			require.LessOrEqual(t, b.Duration(20), time.Second)
+		}
+	})
+}
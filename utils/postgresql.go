@@ -3,26 +3,67 @@ package utils
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	_ "github.com/lib/pq"
+	"log/slog"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Postgresql struct {
-	Host        string
-	Port        string
-	User        string
-	Password    string
-	Name        string
-	MaxOpenConn int
-	MaxIdleConn int
-	MaxIdleTime time.Duration
-	SSLMode     string
-	Timeout     time.Duration
+	URL              string
+	Host             string
+	Port             string
+	User             string
+	Password         string
+	Name             string
+	MaxOpenConn      int
+	MaxIdleConn      int
+	MaxIdleTime      time.Duration
+	MaxLifetime      time.Duration
+	SSLMode          string
+	SSLRootCert      string
+	SSLCert          string
+	SSLKey           string
+	ApplicationName  string
+	SearchPath       string
+	StatementTimeout time.Duration
+	ConnectTimeout   time.Duration
+	Timeout          time.Duration
+	RetryAttempts    int
+	RetryDeadline    time.Duration
+	RetryBackoff     Backoff
+	Logger           *slog.Logger
+	Opener           func(driverName, dsn string) (*sql.DB, error)
 }
 
 type Options func(*Postgresql)
 
+// Pinger is what WaitReady waits for; *sql.DB implements it.
+type Pinger interface {
+	PingContext(ctx context.Context) error
+}
+
+// minRetryBackoff and maxRetryBackoff bound the delay between pings, so that an
+// unset backoff does not retry in a tight loop and one without a maximum does
+// not grow without limit.
+const (
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 30 * time.Second
+)
+
+// WithURL sets a full connection URL (e.g. DATABASE_URL) which takes precedence
+// over the individual host, port, user, password and name settings.
+func WithURL(url string) Options {
+	return func(p *Postgresql) {
+		p.URL = url
+	}
+}
+
 func WithHost(host string) Options {
 	return func(p *Postgresql) {
 		p.Host = host
@@ -71,36 +112,254 @@ func WithMaxIdleTime(maxIdleTime time.Duration) Options {
 	}
 }
 
+func WithMaxLifetime(maxLifetime time.Duration) Options {
+	return func(p *Postgresql) {
+		p.MaxLifetime = maxLifetime
+	}
+}
+
 func WithSSLMode(mode string) Options {
 	return func(p *Postgresql) {
 		p.SSLMode = mode
 	}
 }
 
+func WithSSLRootCert(path string) Options {
+	return func(p *Postgresql) {
+		p.SSLRootCert = path
+	}
+}
+
+// WithSSLClientCert sets the client certificate and key used for TLS client authentication.
+func WithSSLClientCert(certPath, keyPath string) Options {
+	return func(p *Postgresql) {
+		p.SSLCert = certPath
+		p.SSLKey = keyPath
+	}
+}
+
+func WithApplicationName(name string) Options {
+	return func(p *Postgresql) {
+		p.ApplicationName = name
+	}
+}
+
+func WithSearchPath(searchPath string) Options {
+	return func(p *Postgresql) {
+		p.SearchPath = searchPath
+	}
+}
+
+func WithStatementTimeout(timeout time.Duration) Options {
+	return func(p *Postgresql) {
+		p.StatementTimeout = timeout
+	}
+}
+
+func WithConnectTimeout(timeout time.Duration) Options {
+	return func(p *Postgresql) {
+		p.ConnectTimeout = timeout
+	}
+}
+
 func WithTimeout(timeout time.Duration) Options {
 	return func(p *Postgresql) {
 		p.Timeout = timeout
 	}
 }
 
-func (p *Postgresql) uri() string {
-	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", p.Host, p.Port, p.User, p.Password, p.Name, p.SSLMode)
+// WithRetry configures how Connect and WaitReady retry an unreachable server. A zero
+// attempts value retries until the deadline expires. A zero backoff waits
+// minRetryBackoff between attempts.
+func WithRetry(attempts int, deadline time.Duration, backoff Backoff) Options {
+	return func(p *Postgresql) {
+		p.RetryAttempts = attempts
+		p.RetryDeadline = deadline
+		p.RetryBackoff = backoff
+	}
 }
 
-func (p *Postgresql) Connect() (*sql.DB, error) {
-	db, err := sql.Open("postgres", p.uri())
+// WithOpener replaces sql.Open, e.g. with a driver wrapper that adds instrumentation.
+func WithOpener(opener func(driverName, dsn string) (*sql.DB, error)) Options {
+	return func(p *Postgresql) {
+		p.Opener = opener
+	}
+}
+
+func WithLogger(logger *slog.Logger) Options {
+	return func(p *Postgresql) {
+		p.Logger = logger
+	}
+}
+
+// params returns the optional connection parameters that have been configured.
+func (p *Postgresql) params() url.Values {
+	params := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+
+	set("sslmode", p.SSLMode)
+	set("sslrootcert", p.SSLRootCert)
+	set("sslcert", p.SSLCert)
+	set("sslkey", p.SSLKey)
+	set("application_name", p.ApplicationName)
+	set("search_path", p.SearchPath)
+
+	if p.StatementTimeout > 0 {
+		params.Set("statement_timeout", strconv.FormatInt(p.StatementTimeout.Milliseconds(), 10))
+	}
+
+	if p.ConnectTimeout > 0 {
+		seconds := int64((p.ConnectTimeout + time.Second - 1) / time.Second)
+		params.Set("connect_timeout", strconv.FormatInt(seconds, 10))
+	}
+
+	return params
+}
+
+// uri builds a URL-form DSN, escaping every component so credentials may contain
+// spaces, quotes or any other reserved character. When a full URL is configured it
+// is used as the base and the explicitly configured parameters are merged in
+// without overriding the ones the URL already carries. A password without a user
+// is rejected rather than dropped.
+func (p *Postgresql) uri() (string, error) {
+	if p.URL != "" {
+		u, err := url.Parse(p.URL)
+		if err != nil {
+			return "", fmt.Errorf("invalid database url: %w", err)
+		}
+
+		if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+			return "", fmt.Errorf("invalid database url: unsupported scheme %q", u.Scheme)
+		}
+
+		query := u.Query()
+		for key, values := range p.params() {
+			if !query.Has(key) {
+				query[key] = values
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.String(), nil
+	}
+
+	u := &url.URL{
+		Scheme:   "postgres",
+		Host:     p.Host,
+		Path:     "/" + p.Name,
+		RawQuery: p.params().Encode(),
+	}
+
+	if p.Port != "" {
+		u.Host = net.JoinHostPort(p.Host, p.Port)
+	}
+
+	switch {
+	case p.User != "" && p.Password != "":
+		u.User = url.UserPassword(p.User, p.Password)
+	case p.User != "":
+		u.User = url.User(p.User)
+	case p.Password != "":
+		return "", errors.New("invalid database settings: password set without a user")
+	}
+
+	return u.String(), nil
+}
+
+// DSN returns the connection string Open connects with, for clients that manage
+// their own connection such as a LISTEN connection.
+func (p *Postgresql) DSN() (string, error) {
+	return p.uri()
+}
+
+// DatabaseName returns the name of the database being connected to, taking the
+// URL override into account.
+func (p *Postgresql) DatabaseName() string {
+	if p.URL != "" {
+		if u, err := url.Parse(p.URL); err == nil {
+			if name := strings.TrimPrefix(u.Path, "/"); name != "" {
+				return name
+			}
+		}
+	}
+	return p.Name
+}
+
+// Open creates the connection pool without contacting the server.
+func (p *Postgresql) Open() (*sql.DB, error) {
+	dsn, err := p.uri()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := p.Opener("postgres", dsn)
 	if err != nil {
 		return nil, err
 	}
 
 	db.SetMaxOpenConns(p.MaxOpenConn)
 	db.SetMaxIdleConns(p.MaxIdleConn)
-	db.SetConnMaxLifetime(p.MaxIdleTime)
+	db.SetConnMaxIdleTime(p.MaxIdleTime)
+	db.SetConnMaxLifetime(p.MaxLifetime)
+
+	return db, nil
+}
+
+// WaitReady pings the server until it answers, backing off between attempts, and
+// gives up once the retry attempts or the overall retry deadline are exhausted.
+// The backoff is kept between minRetryBackoff and maxRetryBackoff.
+func (p *Postgresql) WaitReady(ctx context.Context, db Pinger) error {
+	if p.RetryDeadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.RetryDeadline)
+		defer cancel()
+	}
+
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		err := p.ping(ctx, db)
+		if err == nil {
+			p.Logger.Info("connected to postgresql", "attempt", attempt, "duration", time.Since(start))
+			return nil
+		}
+
+		if p.RetryAttempts > 0 && attempt >= p.RetryAttempts {
+			p.Logger.Error("postgresql ping failed, giving up", "attempt", attempt, "err", err.Error())
+			return fmt.Errorf("postgresql not reachable after %d attempts: %w", attempt, err)
+		}
 
-	ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
-	defer cancel()
+		delay := min(max(p.RetryBackoff.Duration(attempt), minRetryBackoff), maxRetryBackoff)
+		p.Logger.Warn("postgresql ping failed, retrying", "attempt", attempt, "backoff", delay, "err", err.Error())
 
-	if err = db.PingContext(ctx); err != nil {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("postgresql not reachable after %d attempts: %w", attempt, errors.Join(ctx.Err(), err))
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *Postgresql) ping(ctx context.Context, db Pinger) error {
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+	return db.PingContext(ctx)
+}
+
+func (p *Postgresql) Connect() (*sql.DB, error) {
+	db, err := p.Open()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = p.WaitReady(context.Background(), db); err != nil {
 		db.Close()
 		return nil, err
 	}
@@ -108,7 +367,11 @@ func (p *Postgresql) Connect() (*sql.DB, error) {
 }
 
 func NewPostgresql(opts ...Options) *Postgresql {
-	p := &Postgresql{}
+	p := &Postgresql{
+		RetryAttempts: 1,
+		Logger:        slog.New(slog.DiscardHandler),
+		Opener:        sql.Open,
+	}
 	for _, opt := range opts {
 		opt(p)
 	}